@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/wowsims/sod/sim/core"
@@ -11,6 +12,18 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+var (
+	targetError        float64
+	maxIterations      int32
+	timelineBinSeconds float64
+	itemEffectsFile    string
+
+	presetSpec       string
+	presetName       string
+	presetEncounter  string
+	presetIterations int32
+)
+
 var simCmd = &cobra.Command{
 	Use:   "sim",
 	Short: "simulate items & settings",
@@ -21,19 +34,52 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
-	simCmd.MarkFlagRequired("infile")
+	simCmd.Flags().Float64Var(&targetError, "target-error", 0, "keep running iterations until the 95% confidence interval of the mean DPS is within this fraction of it, e.g. 0.001")
+	simCmd.Flags().Int32Var(&maxIterations, "max-iterations", 1000000, "maximum number of iterations to run when --target-error is set")
+	simCmd.Flags().Float64Var(&timelineBinSeconds, "timeline-bin", 0, "collect per-unit metrics over fight time in bins of this many seconds")
+	simCmd.Flags().StringVar(&itemEffectsFile, "item-effects", "", "location of a file with extra item effect definitions (ItemEffectDefinitions in protojson format)")
+	simCmd.Flags().StringVar(&presetSpec, "spec", "", "spec to simulate a preset for instead of reading --infile, e.g. feral_druid")
+	simCmd.Flags().StringVar(&presetName, "preset", "", "name of the --spec preset to simulate, e.g. phase_4")
+	simCmd.Flags().StringVar(&presetEncounter, "encounter", "", "preset encounter path or name (e.g. Patchwerk), or location of an Encounter in protojson format, to use with --preset. Defaults to a single target of the preset's level")
+	simCmd.Flags().Int32Var(&presetIterations, "iterations", 3000, "number of iterations to run with --preset")
 }
 
 func simMain(cmd *cobra.Command, args []string) {
-	data, err := os.ReadFile(infile)
-	if err != nil {
-		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	if itemEffectsFile != "" {
+		effectsData, err := os.ReadFile(itemEffectsFile)
+		if err != nil {
+			log.Fatalf("failed to load item effects file %q: %v", itemEffectsFile, err)
+		}
+		if err := core.LoadItemEffectDefinitions(effectsData); err != nil {
+			log.Fatalf("failed to load item effects: %s", err)
+		}
 	}
-	input := &proto.RaidSimRequest{}
 
-	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
-	if err != nil {
-		log.Fatalf("failed to load input json file: %s", err)
+	var input *proto.RaidSimRequest
+	if presetSpec != "" || presetName != "" {
+		input = loadPresetRequest()
+	} else {
+		data, err := os.ReadFile(infile)
+		if err != nil {
+			log.Fatalf("failed to load input json file %q: %v", infile, err)
+		}
+		input = &proto.RaidSimRequest{}
+
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+		if err != nil {
+			log.Fatalf("failed to load input json file: %s", err)
+		}
+	}
+
+	if input.SimOptions == nil && (targetError > 0 || timelineBinSeconds > 0) {
+		input.SimOptions = &proto.SimOptions{}
+	}
+	if targetError > 0 {
+		input.SimOptions.TargetError = targetError
+		input.SimOptions.Iterations = maxIterations
+	}
+	if timelineBinSeconds > 0 {
+		input.SimOptions.TimelineBinSeconds = timelineBinSeconds
 	}
 
 	var output []byte
@@ -51,7 +97,12 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
+	if verbose && targetError > 0 {
+		dps := finalResult.RaidMetrics.Dps
+		fmt.Printf("Ran %d iterations, converged: %t (%0.2f +/- %0.2f)\n", finalResult.Iterations, dps.Converged, dps.Avg, dps.Ci95Upper-dps.Avg)
+	}
+
+	output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
 	if err != nil {
 		log.Fatalf("failed to marshal final results: %s", err)
 	}
@@ -68,3 +119,44 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 }
+
+func loadPresetRequest() *proto.RaidSimRequest {
+	spec, ok := core.SpecFromName(presetSpec)
+	if !ok {
+		log.Fatalf("unknown spec %q", presetSpec)
+	}
+
+	preset := core.GetSpecPreset(spec, presetName)
+	if preset == nil {
+		names := core.MapSlice(core.GetSpecPresets(spec), func(preset *core.SpecPreset) string { return preset.Name })
+		log.Fatalf("unknown preset %q for %s, available presets: %s", presetName, presetSpec, strings.Join(names, ", "))
+	}
+
+	var encounter *proto.Encounter
+	if presetEncounter != "" {
+		encounter = loadEncounter(presetEncounter, preset.Level)
+	}
+	return preset.RaidSimRequest(encounter, &proto.SimOptions{Iterations: presetIterations})
+}
+
+// Returns the preset encounter with the given path or name, or else reads the
+// encounter from a file.
+func loadEncounter(pathOrFile string, level int32) *proto.Encounter {
+	for _, preset := range core.PresetEncounters {
+		if preset.Path == pathOrFile || strings.HasSuffix(preset.Path, "/"+pathOrFile) {
+			encounter := core.MakeSingleTargetEncounter(level, 0)
+			encounter.Targets = core.MapSlice(preset.Targets, func(target *proto.PresetTarget) *proto.Target { return target.Target })
+			return encounter
+		}
+	}
+
+	data, err := os.ReadFile(pathOrFile)
+	if err != nil {
+		log.Fatalf("%q is not a preset encounter and could not be read as a file: %v", pathOrFile, err)
+	}
+	encounter := &proto.Encounter{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, encounter); err != nil {
+		log.Fatalf("failed to load encounter json file: %s", err)
+	}
+	return encounter
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	coveragePhases []int32
+	coverageJson   bool
+)
+
+var itemCoverageCmd = &cobra.Command{
+	Use:   "itemcoverage",
+	Short: "report items whose effects are not implemented",
+	Long:  "report items with Use, Chance on hit or Equip effects which are not implemented in the sim, grouped by phase and slot",
+	Run:   itemCoverageMain,
+}
+
+func init() {
+	itemCoverageCmd.Flags().Int32SliceVar(&coveragePhases, "phase", nil, "phases to include, defaults to all phases")
+	itemCoverageCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	itemCoverageCmd.Flags().BoolVar(&coverageJson, "json", false, "write the report as JSON (ItemEffectCoverageResult in protojson format)")
+}
+
+func itemCoverageMain(cmd *cobra.Command, args []string) {
+	result := core.ItemEffectCoverage(&proto.ItemEffectCoverageRequest{
+		Phases: coveragePhases,
+	})
+
+	var output string
+	if coverageJson {
+		data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			log.Fatalf("failed to marshal coverage report: %s", err)
+		}
+		output = string(data)
+	} else {
+		output = formatItemCoverage(result)
+	}
+
+	if outfile == "" {
+		fmt.Print(output)
+	} else if err := os.WriteFile(outfile, []byte(output), 0666); err != nil {
+		log.Fatalf("failed to write output file: %s", err)
+	}
+}
+
+func formatItemCoverage(result *proto.ItemEffectCoverageResult) string {
+	var sb strings.Builder
+	totalItems, totalImplemented := int32(0), int32(0)
+
+	for _, coverage := range result.Coverage {
+		totalItems += coverage.NumItemsWithEffects
+		totalImplemented += coverage.NumImplemented
+
+		fmt.Fprintf(&sb, "Phase %d %s: %d / %d implemented\n", coverage.Phase, strings.TrimPrefix(coverage.Type.String(), "ItemType"), coverage.NumImplemented, coverage.NumItemsWithEffects)
+		for _, item := range coverage.Unimplemented {
+			effectNames := make([]string, len(item.EffectTypes))
+			for i, effectType := range item.EffectTypes {
+				effectNames[i] = strings.TrimPrefix(effectType.String(), "ItemEffectType")
+			}
+			fmt.Fprintf(&sb, "\t%d %s (%s)\n", item.ItemId, item.Name, strings.Join(effectNames, ", "))
+		}
+	}
+
+	fmt.Fprintf(&sb, "Total: %d / %d implemented\n", totalImplemented, totalItems)
+	return sb.String()
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	rankingsTop     int32
+	rankingsPhase   int32
+	rankingsSources []string
+	rankingsZones   []int32
+	rankingsFaction string
+	rankingsMaxSims int32
+	rankingsEpOnly  bool
+	rankingsJson    bool
+)
+
+var itemRankingsCmd = &cobra.Command{
+	Use:   "itemrankings",
+	Short: "rank every item in the database by EP for each slot",
+	Long:  "rank every item in the database the player can equip by EP from stat weights, quick simming items with effects or set bonuses, and report the best upgrades for each slot",
+	Run:   itemRankingsMain,
+}
+
+func init() {
+	itemRankingsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (ItemRankingsRequest in protojson format, including stat_weights)")
+	itemRankingsCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	itemRankingsCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	itemRankingsCmd.Flags().Int32Var(&rankingsTop, "top", 0, "number of upgrades to report for each slot, overriding the input file")
+	itemRankingsCmd.Flags().Int32Var(&rankingsPhase, "phase", 0, "only include items released by this phase, overriding the input file")
+	itemRankingsCmd.Flags().StringSliceVar(&rankingsSources, "sources", nil, "only include items from these sources (crafted, drop, quest, soldby, rep), overriding the input file")
+	itemRankingsCmd.Flags().Int32SliceVar(&rankingsZones, "zones", nil, "only include drops from these zone IDs, overriding the input file")
+	itemRankingsCmd.Flags().StringVar(&rankingsFaction, "faction", "", "faction to include items for (alliance or horde), defaults to the player's faction")
+	itemRankingsCmd.Flags().Int32Var(&rankingsMaxSims, "max-sims", 0, "maximum number of quick sims for items with effects or set bonuses, overriding the input file")
+	itemRankingsCmd.Flags().BoolVar(&rankingsEpOnly, "ep-only", false, "rank every item by stat weights alone, without any sims")
+	itemRankingsCmd.Flags().BoolVar(&rankingsJson, "json", false, "write the rankings as JSON (ItemRankingsResult in protojson format)")
+}
+
+func itemRankingsMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.ItemRankingsRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	if input.Filters == nil {
+		input.Filters = &proto.ItemRankingsFilters{}
+	}
+	if rankingsTop > 0 {
+		input.NumResults = rankingsTop
+	}
+	if rankingsPhase > 0 {
+		input.Filters.Phase = rankingsPhase
+	}
+	if len(rankingsSources) > 0 {
+		input.Filters.Sources = parseItemSourceTypes(rankingsSources)
+	}
+	if len(rankingsZones) > 0 {
+		input.Filters.ZoneIds = rankingsZones
+	}
+	if rankingsFaction != "" {
+		input.Filters.Faction = parseFaction(rankingsFaction)
+	}
+	if rankingsMaxSims > 0 {
+		input.MaxSims = rankingsMaxSims
+	}
+	if rankingsEpOnly {
+		input.EpOnly = true
+	}
+
+	progress := make(chan *proto.ProgressMetrics, 100)
+	core.RunItemRankingsAsync(context.Background(), input, progress)
+
+	var result *proto.ItemRankingsResult
+	for status := range progress {
+		if status.FinalItemRankingsResult != nil {
+			result = status.FinalItemRankingsResult
+		} else if verbose && status.TotalSims > 0 {
+			fmt.Fprintf(os.Stderr, "Simmed %d / %d items\n", status.CompletedSims, status.TotalSims)
+		}
+	}
+	if result.ErrorResult != "" {
+		log.Fatalf("failed to rank items: %s", result.ErrorResult)
+	}
+
+	var output string
+	if rankingsJson {
+		data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			log.Fatalf("failed to marshal item rankings: %s", err)
+		}
+		output = string(data)
+	} else {
+		output = formatItemRankings(result)
+	}
+
+	if outfile == "" {
+		fmt.Print(output)
+	} else if err := os.WriteFile(outfile, []byte(output), 0666); err != nil {
+		log.Fatalf("failed to write output file: %s", err)
+	}
+}
+
+func parseItemSourceTypes(names []string) []proto.ItemSourceType {
+	sourceTypes := make([]proto.ItemSourceType, 0, len(names))
+	for _, name := range names {
+		sourceType, ok := int32(0), false
+		for enumName, value := range proto.ItemSourceType_value {
+			if strings.EqualFold(strings.TrimPrefix(enumName, "ItemSourceType"), name) {
+				sourceType, ok = value, true
+			}
+		}
+		if !ok {
+			log.Fatalf("unknown item source %q", name)
+		}
+		sourceTypes = append(sourceTypes, proto.ItemSourceType(sourceType))
+	}
+	return sourceTypes
+}
+
+func parseFaction(name string) proto.Faction {
+	switch strings.ToLower(name) {
+	case "alliance":
+		return proto.Faction_Alliance
+	case "horde":
+		return proto.Faction_Horde
+	}
+	log.Fatalf("unknown faction %q", name)
+	return proto.Faction_Unknown
+}
+
+func formatItemRankings(result *proto.ItemRankingsResult) string {
+	var sb strings.Builder
+	for _, slot := range result.Slots {
+		fmt.Fprintf(&sb, "%s (equipped: %0.1f EP)\n", strings.TrimPrefix(slot.Slot.String(), "ItemSlot"), slot.EquippedEp)
+		for _, ranking := range slot.Items {
+			simmed := ""
+			if ranking.Simmed {
+				simmed = " [simmed]"
+			}
+			fmt.Fprintf(&sb, "\t+%0.1f EP (+%0.1f DPS)\t%d %s%s\n", ranking.EpGain, ranking.DpsGain, ranking.Item.Id, core.ItemsByID[ranking.Item.Id].Name, simmed)
+		}
+	}
+	fmt.Fprintf(&sb, "Items simmed: %d\n", result.ItemsSimmed)
+	return sb.String()
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/ui"
 )
 
 var rootCmd = &cobra.Command{
@@ -14,10 +16,14 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute(version string) {
+	core.PresetFiles = ui.PresetFiles
+
 	rootCmd.AddCommand(newVersionCommand(version))
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(itemCoverageCmd)
+	rootCmd.AddCommand(itemRankingsCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -39,12 +39,13 @@ func init() {
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskEmpty,
 			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+			Aoe:         core.AoeConfig{Radius: 20, ConeAngle: 90},
 
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 
-			ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					baseDamage := sim.Roll(153, 173)
 					spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 				}
@@ -264,6 +265,7 @@ func init() {
 			SpellSchool: core.SpellSchoolNature,
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskEmpty,
+			Aoe:         core.AoeConfig{Radius: 8},
 
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
@@ -285,7 +287,7 @@ func init() {
 			},
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					result := spell.CalcOutcome(sim, aoeTarget, spell.OutcomeMagicHit)
 					if result.Landed() {
 						spell.Dot(aoeTarget).Apply(sim)
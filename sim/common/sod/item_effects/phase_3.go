@@ -339,6 +339,7 @@ func init() {
 			SpellSchool: core.SpellSchoolNature,
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskEmpty,
+			Aoe:         core.AoeConfig{Radius: 10},
 
 			Dot: core.DotConfig{
 				IsAOE: true,
@@ -349,7 +350,7 @@ func init() {
 				TickLength:    time.Second * 1,
 
 				OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-					for _, aoeTarget := range sim.Encounter.TargetUnits {
+					for _, aoeTarget := range dot.Spell.AoeTargets(target) {
 						tickSpell.Cast(sim, aoeTarget)
 					}
 				},
@@ -136,6 +136,7 @@ func init() {
 			SpellSchool: core.SpellSchoolFire,
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskEmpty,
+			Aoe:         core.AoeConfig{Radius: 8},
 
 			BonusCoefficient: .045,
 			DamageMultiplier: 1,
@@ -154,7 +155,7 @@ func init() {
 					Period:   time.Second * 2,
 					Priority: core.ActionPriorityDOT, // High prio
 					OnAction: func(sim *core.Simulation) {
-						for _, aoeTarget := range sim.Encounter.TargetUnits {
+						for _, aoeTarget := range spell.AoeTargets(character.CurrentTarget) {
 							spell.Cast(sim, aoeTarget)
 						}
 					},
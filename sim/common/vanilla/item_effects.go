@@ -250,8 +250,9 @@ func init() {
 			SpellSchool: core.SpellSchoolArcane,
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskEmpty,
+			Aoe:         core.AoeConfig{Radius: 20},
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					mightOfShahramAuras.Get(aoeTarget).Activate(sim)
 				}
 			},
@@ -353,10 +354,11 @@ func init() {
 			DefenseType:      core.DefenseTypeMagic,
 			ProcMask:         core.ProcMaskEmpty,
 			Flags:            core.SpellFlagIgnoreAttackerModifiers | core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+			Aoe:              core.AoeConfig{Radius: 20},
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					spell.CalcAndDealDamage(sim, aoeTarget, 90, spell.OutcomeMagicCrit)
 				}
 			},
@@ -719,12 +721,13 @@ func init() {
 			SpellSchool:      core.SpellSchoolFire,
 			DefenseType:      core.DefenseTypeMagic,
 			ProcMask:         core.ProcMaskEmpty,
+			Aoe:              core.AoeConfig{Radius: 8},
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 				shieldAura.Activate(sim)
 
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(130, 170), spell.OutcomeMagicHit)
 				}
 			},
@@ -1029,11 +1032,12 @@ func init() {
 			SpellSchool:      core.SpellSchoolFrost,
 			DefenseType:      core.DefenseTypeMagic,
 			ProcMask:         core.ProcMaskEmpty,
+			Aoe:              core.AoeConfig{Radius: 10},
 			BonusCoefficient: 1,
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					spell.CalcAndDealDamage(sim, aoeTarget, 28, spell.OutcomeMagicHitAndCrit)
 				}
 			},
@@ -1221,13 +1225,14 @@ func init() {
 			DefenseType: core.DefenseTypeMelee,
 			ProcMask:    core.ProcMaskMeleeMHSpecial,
 			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+			Aoe:         core.AoeConfig{Radius: 8},
 
 			DamageMultiplier: 1,
 			BonusCoefficient: 1,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 				damage := 5.0 + spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeMeleeSpecialHitAndCrit)
 				}
 			},
@@ -1369,6 +1374,7 @@ func init() {
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskEmpty,
 			Flags:       core.SpellFlagPoison | core.SpellFlagPureDot,
+			Aoe:         core.AoeConfig{Radius: 8},
 			Dot: core.DotConfig{
 				Aura: core.Aura{
 					Label: "Seeping Willow Poison",
@@ -1386,7 +1392,7 @@ func init() {
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					result := spell.CalcAndDealOutcome(sim, aoeTarget, spell.OutcomeMagicHit)
 					if result.Landed() {
 						spell.Dot(aoeTarget).Apply(sim)
@@ -1555,6 +1561,7 @@ func init() {
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskEmpty,
 			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+			Aoe:         core.AoeConfig{Radius: 8},
 
 			BonusCoefficient: .025,
 			DamageMultiplier: 1,
@@ -1572,7 +1579,7 @@ func init() {
 			ProcMask:   core.ProcMaskMelee,
 			ProcChance: .20,
 			Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range immolationSpell.AoeTargets(character.CurrentTarget) {
 					immolationSpell.Cast(sim, aoeTarget)
 				}
 			},
@@ -2613,10 +2620,11 @@ func init() {
 			SpellSchool:      core.SpellSchoolFire,
 			DefenseType:      core.DefenseTypeMagic,
 			ProcMask:         core.ProcMaskEmpty,
+			Aoe:              core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(75, 125), spell.OutcomeMagicHit)
 				}
 			},
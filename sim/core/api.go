@@ -60,3 +60,47 @@ func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
 func RunBulkSimAsync(ctx context.Context, request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics) {
 	go BulkSim(ctx, request, progress)
 }
+
+/**
+ * Searches party assignments of a roster for the arrangements with the highest raid DPS.
+ */
+func RunRaidOptimizer(request *proto.RaidOptimizerRequest) *proto.RaidOptimizerResult {
+	return RaidOptimizer(context.Background(), request, nil)
+}
+
+func RunRaidOptimizerAsync(ctx context.Context, request *proto.RaidOptimizerRequest, progress chan *proto.ProgressMetrics) {
+	go RaidOptimizer(ctx, request, progress)
+}
+
+/**
+ * Searches the timings of a player's cooldowns and consumables for the highest raid DPS.
+ */
+func RunCooldownTimings(request *proto.CooldownTimingsRequest) *proto.CooldownTimingsResult {
+	return CooldownTimings(context.Background(), request, nil)
+}
+
+func RunCooldownTimingsAsync(ctx context.Context, request *proto.CooldownTimingsRequest, progress chan *proto.ProgressMetrics) {
+	go CooldownTimings(ctx, request, progress)
+}
+
+/**
+ * Sweeps a player's stats over ranges, returning DPS curves with caps, breakpoints and local EP values.
+ */
+func RunStatScaling(request *proto.StatScalingRequest) *proto.StatScalingResult {
+	return StatScaling(context.Background(), request, nil)
+}
+
+func RunStatScalingAsync(ctx context.Context, request *proto.StatScalingRequest, progress chan *proto.ProgressMetrics) {
+	go StatScaling(ctx, request, progress)
+}
+
+/**
+ * Ranks every item in the database the player can equip, returning the best upgrades for each slot.
+ */
+func RunItemRankings(request *proto.ItemRankingsRequest) *proto.ItemRankingsResult {
+	return ItemRankings(context.Background(), request, nil)
+}
+
+func RunItemRankingsAsync(ctx context.Context, request *proto.ItemRankingsRequest, progress chan *proto.ProgressMetrics) {
+	go ItemRankings(ctx, request, progress)
+}
@@ -185,6 +185,8 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 		return rot.newActionCustomRotation(config.GetCustomRotation())
 	case *proto.APLAction_AddComboPoints:
 		return rot.newActionAddComboPoints(config.GetAddComboPoints())
+	case *proto.APLAction_ReapplyWeaponImbue:
+		return rot.newActionReapplyWeaponImbue(config.GetReapplyWeaponImbue())
 	default:
 		return nil
 	}
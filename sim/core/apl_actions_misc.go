@@ -35,6 +35,7 @@ func (action *APLActionChangeTarget) Execute(sim *Simulation) {
 		action.unit.Log(sim, "Changing target to %s", action.newTarget.Get().Label)
 	}
 	action.unit.CurrentTarget = action.newTarget.Get()
+	action.unit.updateDistanceFromTarget()
 }
 func (action *APLActionChangeTarget) String() string {
 	return fmt.Sprintf("Change Target(%s)", action.newTarget.Get().Label)
@@ -212,7 +213,7 @@ func (action *APLActionTriggerICD) String() string {
 type APLActionItemSwap struct {
 	defaultAPLActionImpl
 	character *Character
-	swapSet   proto.APLActionItemSwap_SwapSet
+	setIndex  int
 }
 
 func (rot *APLRotation) newActionItemSwap(config *proto.APLActionItemSwap) APLActionImpl {
@@ -229,27 +230,32 @@ func (rot *APLRotation) newActionItemSwap(config *proto.APLActionItemSwap) APLAc
 		return nil
 	}
 
+	setIndex := 0
+	if config.SwapSet != proto.APLActionItemSwap_Main {
+		setIndex = character.ItemSwap.GetSetIndex(config.SwapSetName)
+		if setIndex == -1 {
+			rot.ValidationWarning("No swap set named %s configured in Settings.", config.SwapSetName)
+			return nil
+		}
+	}
+
 	return &APLActionItemSwap{
 		character: character,
-		swapSet:   config.SwapSet,
+		setIndex:  setIndex,
 	}
 }
 func (action *APLActionItemSwap) IsReady(sim *Simulation) bool {
-	return (action.swapSet == proto.APLActionItemSwap_Main) == action.character.ItemSwap.IsSwapped()
+	return action.character.ItemSwap.EquippedSet() != action.setIndex
 }
 func (action *APLActionItemSwap) Execute(sim *Simulation) {
 	if sim.Log != nil {
-		action.character.Log(sim, "Item Swap to set %s", action.swapSet)
+		action.character.Log(sim, "Item Swap to set %s", action.character.ItemSwap.GetSetName(action.setIndex))
 	}
 
-	if action.swapSet == proto.APLActionItemSwap_Main {
-		action.character.ItemSwap.reset(sim)
-	} else {
-		action.character.ItemSwap.SwapItems(sim, action.character.ItemSwap.slots)
-	}
+	action.character.ItemSwap.EquipSet(sim, action.setIndex)
 }
 func (action *APLActionItemSwap) String() string {
-	return fmt.Sprintf("Item Swap(%s)", action.swapSet)
+	return fmt.Sprintf("Item Swap(%s)", action.character.ItemSwap.GetSetName(action.setIndex))
 }
 
 type APLActionMove struct {
@@ -313,3 +319,30 @@ func (action *APLActionCustomRotation) Execute(sim *Simulation) {
 func (action *APLActionCustomRotation) String() string {
 	return "Custom Rotation()"
 }
+
+type APLActionReapplyWeaponImbue struct {
+	defaultAPLActionImpl
+	imbue *WeaponImbue
+}
+
+func (rot *APLRotation) newActionReapplyWeaponImbue(config *proto.APLActionReapplyWeaponImbue) APLActionImpl {
+	character := rot.unit.Env.Raid.GetPlayerFromUnit(rot.unit).GetCharacter()
+	imbue := character.GetWeaponImbue(!config.OffHand)
+	if imbue == nil {
+		rot.ValidationWarning("No weapon imbue on the %s weapon", Ternary(config.OffHand, "off hand", "main hand"))
+		return nil
+	}
+
+	return &APLActionReapplyWeaponImbue{
+		imbue: imbue,
+	}
+}
+func (action *APLActionReapplyWeaponImbue) IsReady(sim *Simulation) bool {
+	return !action.imbue.IsActive() && action.imbue.ReapplySpell.CanCast(sim, action.imbue.character.CurrentTarget)
+}
+func (action *APLActionReapplyWeaponImbue) Execute(sim *Simulation) {
+	action.imbue.ReapplySpell.Cast(sim, action.imbue.character.CurrentTarget)
+}
+func (action *APLActionReapplyWeaponImbue) String() string {
+	return fmt.Sprintf("Reapply Weapon Imbue(%s)", action.imbue.Aura.ActionID)
+}
@@ -56,8 +56,9 @@ func (value *APLValueAuraIsActive) String() string {
 
 type APLValueAuraIsActiveWithReactionTime struct {
 	DefaultAPLValueImpl
-	aura         AuraReference
-	reactionTime time.Duration
+	unit     *Unit
+	aura     AuraReference
+	reaction auraReaction
 }
 
 func (rot *APLRotation) newValueAuraIsActiveWithReactionTime(config *proto.APLValueAuraIsActiveWithReactionTime) APLValue {
@@ -66,8 +67,9 @@ func (rot *APLRotation) newValueAuraIsActiveWithReactionTime(config *proto.APLVa
 		return nil
 	}
 	return &APLValueAuraIsActiveWithReactionTime{
-		aura:         aura,
-		reactionTime: rot.unit.ReactionTime,
+		unit:     rot.unit,
+		aura:     aura,
+		reaction: newAuraReaction(),
 	}
 }
 func (value *APLValueAuraIsActiveWithReactionTime) Type() proto.APLValueType {
@@ -75,7 +77,7 @@ func (value *APLValueAuraIsActiveWithReactionTime) Type() proto.APLValueType {
 }
 func (value *APLValueAuraIsActiveWithReactionTime) GetBool(sim *Simulation) bool {
 	aura := value.aura.Get()
-	return aura.IsActive() && aura.TimeActive(sim) >= value.reactionTime
+	return aura.IsActive() && aura.TimeActive(sim) >= value.reaction.get(sim, value.unit, aura)
 }
 func (value *APLValueAuraIsActiveWithReactionTime) String() string {
 	return fmt.Sprintf("Aura Active With Reaction Time(%s)", value.aura.String())
@@ -159,8 +161,9 @@ func (value *APLValueAuraInternalCooldown) String() string {
 
 type APLValueAuraICDIsReadyWithReactionTime struct {
 	DefaultAPLValueImpl
-	aura         AuraReference
-	reactionTime time.Duration
+	unit     *Unit
+	aura     AuraReference
+	reaction auraReaction
 }
 
 func (rot *APLRotation) newValueAuraICDIsReadyWithReactionTime(config *proto.APLValueAuraICDIsReadyWithReactionTime) APLValue {
@@ -169,8 +172,9 @@ func (rot *APLRotation) newValueAuraICDIsReadyWithReactionTime(config *proto.APL
 		return nil
 	}
 	return &APLValueAuraICDIsReadyWithReactionTime{
-		aura:         aura,
-		reactionTime: rot.unit.ReactionTime,
+		unit:     rot.unit,
+		aura:     aura,
+		reaction: newAuraReaction(),
 	}
 }
 func (value *APLValueAuraICDIsReadyWithReactionTime) Type() proto.APLValueType {
@@ -178,7 +182,7 @@ func (value *APLValueAuraICDIsReadyWithReactionTime) Type() proto.APLValueType {
 }
 func (value *APLValueAuraICDIsReadyWithReactionTime) GetBool(sim *Simulation) bool {
 	aura := value.aura.Get()
-	return aura.Icd.IsReady(sim) || (aura.IsActive() && aura.TimeActive(sim) < value.reactionTime)
+	return aura.Icd.IsReady(sim) || (aura.IsActive() && aura.TimeActive(sim) < value.reaction.get(sim, value.unit, aura))
 }
 func (value *APLValueAuraICDIsReadyWithReactionTime) String() string {
 	return fmt.Sprintf("Aura ICD Is Ready with Reaction Time(%s)", value.aura.String())
@@ -122,3 +122,32 @@ func (value *APLValueIsExecutePhase) GetBool(sim *Simulation) bool {
 func (value *APLValueIsExecutePhase) String() string {
 	return "Is Execute Phase"
 }
+
+type APLValueThreatPercentOfTank struct {
+	DefaultAPLValueImpl
+	unit   *Unit
+	target UnitReference
+}
+
+func (rot *APLRotation) newValueThreatPercentOfTank(config *proto.APLValueThreatPercentOfTank) APLValue {
+	target := rot.GetTargetUnit(config.TargetUnit)
+	if target.Get() == nil {
+		return nil
+	}
+	if rot.unit.Env.Raid.ThreatCeiling == nil {
+		rot.ValidationWarning("Threat Percent of Tank is always 0 without a threat ceiling")
+	}
+	return &APLValueThreatPercentOfTank{
+		unit:   rot.unit,
+		target: target,
+	}
+}
+func (value *APLValueThreatPercentOfTank) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueThreatPercentOfTank) GetFloat(sim *Simulation) float64 {
+	return value.unit.ThreatPercentOfTank(sim, value.target.Get())
+}
+func (value *APLValueThreatPercentOfTank) String() string {
+	return fmt.Sprintf("Threat Percent of Tank(%s)", value.target.Get().Label)
+}
@@ -40,7 +40,7 @@ func (value *APLValueFrontOfTarget) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeBool
 }
 func (value *APLValueFrontOfTarget) GetBool(sim *Simulation) bool {
-	return value.unit.PseudoStats.InFrontOfTarget
+	return value.unit.IsInFrontOf(value.unit.CurrentTarget)
 }
 func (value *APLValueFrontOfTarget) String() string {
 	return "Front of Target()"
@@ -121,13 +121,16 @@ func (value *APLValueCurrentManaPercent) String() string {
 
 type APLValueCurrentRage struct {
 	DefaultAPLValueImpl
-	unit *Unit
+	unit UnitReference
 }
 
-func (rot *APLRotation) newValueCurrentRage(_ *proto.APLValueCurrentRage) APLValue {
-	unit := rot.unit
-	if !unit.HasRageBar() {
-		rot.ValidationWarning("%s does not use Rage", unit.Label)
+func (rot *APLRotation) newValueCurrentRage(config *proto.APLValueCurrentRage) APLValue {
+	unit := rot.GetSourceUnit(config.SourceUnit)
+	if unit.Get() == nil {
+		return nil
+	}
+	if !unit.Get().HasRageBar() {
+		rot.ValidationWarning("%s does not use Rage", unit.Get().Label)
 		return nil
 	}
 	return &APLValueCurrentRage{
@@ -138,7 +141,7 @@ func (value *APLValueCurrentRage) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeFloat
 }
 func (value *APLValueCurrentRage) GetFloat(_ *Simulation) float64 {
-	return value.unit.CurrentRage()
+	return value.unit.Get().CurrentRage()
 }
 func (value *APLValueCurrentRage) String() string {
 	return "Current Rage"
@@ -146,13 +149,16 @@ func (value *APLValueCurrentRage) String() string {
 
 type APLValueCurrentEnergy struct {
 	DefaultAPLValueImpl
-	unit *Unit
+	unit UnitReference
 }
 
-func (rot *APLRotation) newValueCurrentEnergy(_ *proto.APLValueCurrentEnergy) APLValue {
-	unit := rot.unit
-	if !unit.HasEnergyBar() {
-		rot.ValidationWarning("%s does not use Energy", unit.Label)
+func (rot *APLRotation) newValueCurrentEnergy(config *proto.APLValueCurrentEnergy) APLValue {
+	unit := rot.GetSourceUnit(config.SourceUnit)
+	if unit.Get() == nil {
+		return nil
+	}
+	if !unit.Get().HasEnergyBar() {
+		rot.ValidationWarning("%s does not use Energy", unit.Get().Label)
 		return nil
 	}
 	return &APLValueCurrentEnergy{
@@ -163,12 +169,40 @@ func (value *APLValueCurrentEnergy) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeFloat
 }
 func (value *APLValueCurrentEnergy) GetFloat(_ *Simulation) float64 {
-	return value.unit.CurrentEnergy()
+	return value.unit.Get().CurrentEnergy()
 }
 func (value *APLValueCurrentEnergy) String() string {
 	return "Current Energy"
 }
 
+type APLValueCurrentFocus struct {
+	DefaultAPLValueImpl
+	unit UnitReference
+}
+
+func (rot *APLRotation) newValueCurrentFocus(config *proto.APLValueCurrentFocus) APLValue {
+	unit := rot.GetSourceUnit(config.SourceUnit)
+	if unit.Get() == nil {
+		return nil
+	}
+	if !unit.Get().HasFocusBar() {
+		rot.ValidationWarning("%s does not use Focus", unit.Get().Label)
+		return nil
+	}
+	return &APLValueCurrentFocus{
+		unit: unit,
+	}
+}
+func (value *APLValueCurrentFocus) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentFocus) GetFloat(_ *Simulation) float64 {
+	return value.unit.Get().CurrentFocus()
+}
+func (value *APLValueCurrentFocus) String() string {
+	return "Current Focus"
+}
+
 type APLValueCurrentComboPoints struct {
 	DefaultAPLValueImpl
 	unit *Unit
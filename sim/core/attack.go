@@ -367,7 +367,7 @@ func (wa *WeaponAttack) swing(sim *Simulation) time.Duration {
 		}
 
 		if !sim.Options.Interactive && wa.unit.Rotation != nil {
-			wa.unit.Rotation.DoNextAction(sim)
+			wa.unit.doNextActionAfterDelay(sim)
 		}
 	} else {
 		// Delay till cast finishes if casting or 100 ms if not
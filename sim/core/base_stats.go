@@ -1,6 +1,9 @@
 package core
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/wowsims/sod/sim/core/proto"
 	"github.com/wowsims/sod/sim/core/stats"
 )
@@ -588,7 +591,26 @@ func getBaseStatsCombo(r proto.Race, c proto.Class, level int) stats.Stats {
 		level = 60
 	}
 
-	starting := ClassBaseStats[c][level]
+	starting := getClassBaseStats(c, level)
 
 	return starting.Add(RaceOffsets[r]).Add(ClassBaseCrit[c])
 }
+
+// Class base stats are only listed for some levels, so interpolate between the
+// closest listed levels for everything in between.
+func getClassBaseStats(c proto.Class, level int) stats.Stats {
+	levelStats := ClassBaseStats[c]
+	if baseStats, ok := levelStats[level]; ok {
+		return baseStats
+	}
+
+	levels := getTableLevels(levelStats)
+	upperIdx, _ := slices.BinarySearch(levels, level)
+	if upperIdx == 0 || upperIdx == len(levels) {
+		panic(fmt.Sprintf("No base stats for %s at level %d, supported levels are %d to %d.", c, level, levels[0], levels[len(levels)-1]))
+	}
+
+	lowerLevel, upperLevel := levels[upperIdx-1], levels[upperIdx]
+	weight := float64(level-lowerLevel) / float64(upperLevel-lowerLevel)
+	return levelStats[lowerLevel].Multiply(1 - weight).Add(levelStats[upperLevel].Multiply(weight))
+}
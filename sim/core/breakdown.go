@@ -0,0 +1,175 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Execute phases, by remaining target health, in the order they're reached.
+var executePhases = [...]int32{100, 35, 25, 20}
+
+const numExecutePhases = len(executePhases)
+
+const numDamageCategories = int(proto.DamageCategory_DamageCategoryDot) + 1
+
+func executePhaseIndex(phase int32) int {
+	switch phase {
+	case 35:
+		return 1
+	case 25:
+		return 2
+	case 20:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type breakdownTotals struct {
+	damage  float64
+	healing float64
+}
+
+// Damage and healing done by a unit, rolled up by school, category and
+// execute phase.
+type breakdownMetrics struct {
+	schools      [math.MaxUint8 + 1]breakdownTotals // Indexed by SpellSchool mask.
+	categories   [numDamageCategories]breakdownTotals
+	phases       [numExecutePhases]breakdownTotals
+	phaseSeconds [numExecutePhases]float64
+}
+
+func (breakdown *breakdownMetrics) add(other *breakdownMetrics) {
+	for i := range breakdown.schools {
+		breakdown.schools[i].damage += other.schools[i].damage
+		breakdown.schools[i].healing += other.schools[i].healing
+	}
+	for i := range breakdown.categories {
+		breakdown.categories[i].damage += other.categories[i].damage
+		breakdown.categories[i].healing += other.categories[i].healing
+	}
+	for i := range breakdown.phases {
+		breakdown.phases[i].damage += other.phases[i].damage
+		breakdown.phases[i].healing += other.phases[i].healing
+		breakdown.phaseSeconds[i] += other.phaseSeconds[i]
+	}
+}
+
+// Returns the category a spell's damage or healing is reported under.
+func (spell *Spell) damageCategory(isPeriodic bool) proto.DamageCategory {
+	switch {
+	case spell.Unit.Type == PetUnit:
+		return proto.DamageCategory_DamageCategoryPet
+	case isPeriodic:
+		return proto.DamageCategory_DamageCategoryDot
+	case spell.ProcMask.Matches(ProcMaskWhiteHit):
+		return proto.DamageCategory_DamageCategoryWhite
+	case spell.Flags.Matches(SpellFlagPassiveSpell) || spell.ProcMask.Matches(ProcMaskEmpty|ProcMaskProc|ProcMaskWeaponProc) || spell.ProcMask == ProcMaskUnknown:
+		return proto.DamageCategory_DamageCategoryProc
+	default:
+		return proto.DamageCategory_DamageCategoryYellow
+	}
+}
+
+// Like damage metrics, the breakdown leaves out anything done before the pull.
+func (unitMetrics *UnitMetrics) addBreakdownDamage(sim *Simulation, spell *Spell, isPeriodic bool, damage float64) {
+	if sim.CurrentTime < 0 {
+		return
+	}
+
+	breakdown := &unitMetrics.breakdown
+	breakdown.schools[spell.SpellSchool].damage += damage
+	breakdown.categories[spell.damageCategory(isPeriodic)].damage += damage
+	breakdown.phases[executePhaseIndex(sim.executePhase)].damage += damage
+}
+
+func (unitMetrics *UnitMetrics) addBreakdownHealing(sim *Simulation, spell *Spell, isPeriodic bool, healing float64) {
+	if sim.CurrentTime < 0 {
+		return
+	}
+
+	breakdown := &unitMetrics.breakdown
+	breakdown.schools[spell.SpellSchool].healing += healing
+	breakdown.categories[spell.damageCategory(isPeriodic)].healing += healing
+	breakdown.phases[executePhaseIndex(sim.executePhase)].healing += healing
+}
+
+// Returns the time spent in each execute phase so far this iteration.
+func (sim *Simulation) executePhaseSeconds() [numExecutePhases]float64 {
+	var seconds [numExecutePhases]float64
+	for i, start := range sim.executePhaseStarts {
+		end := sim.CurrentTime
+		if i+1 < numExecutePhases {
+			end = min(end, sim.executePhaseStarts[i+1])
+		}
+		seconds[i] = max(end-start, 0).Seconds()
+	}
+	return seconds
+}
+
+func (sim *Simulation) resetExecutePhaseStarts() {
+	sim.executePhaseStarts = [numExecutePhases]time.Duration{0, NeverExpires, NeverExpires, NeverExpires}
+}
+
+func (totals breakdownTotals) toProto(n float64, total breakdownTotals) *proto.DamageBreakdown {
+	protoBreakdown := &proto.DamageBreakdown{
+		DamageAvg:  totals.damage / n,
+		HealingAvg: totals.healing / n,
+	}
+	if total.damage > 0 {
+		protoBreakdown.DamagePercent = totals.damage / total.damage
+	}
+	if total.healing > 0 {
+		protoBreakdown.HealingPercent = totals.healing / total.healing
+	}
+	return protoBreakdown
+}
+
+func (breakdown *breakdownMetrics) fillProto(protoMetrics *proto.UnitMetrics, n float64) {
+	if n == 0 {
+		return
+	}
+
+	// Every bit of damage and healing falls into exactly one category.
+	var total breakdownTotals
+	for _, totals := range breakdown.categories {
+		total.damage += totals.damage
+		total.healing += totals.healing
+	}
+
+	for school, totals := range breakdown.schools {
+		if totals.damage == 0 && totals.healing == 0 {
+			continue
+		}
+		protoMetrics.DamageBySchool = append(protoMetrics.DamageBySchool, &proto.SchoolDamageBreakdown{
+			SpellSchool: int32(school),
+			Breakdown:   totals.toProto(n, total),
+		})
+	}
+
+	for category, totals := range breakdown.categories {
+		if totals.damage == 0 && totals.healing == 0 {
+			continue
+		}
+		protoMetrics.DamageByCategory = append(protoMetrics.DamageByCategory, &proto.CategoryDamageBreakdown{
+			Category:  proto.DamageCategory(category),
+			Breakdown: totals.toProto(n, total),
+		})
+	}
+
+	for i, totals := range breakdown.phases {
+		seconds := breakdown.phaseSeconds[i]
+		if seconds == 0 {
+			continue
+		}
+		protoMetrics.DamageByExecutePhase = append(protoMetrics.DamageByExecutePhase, &proto.ExecutePhaseDamageBreakdown{
+			ExecutePhase: executePhases[i],
+			Breakdown:    totals.toProto(n, total),
+			SecondsAvg:   seconds / n,
+			Dps:          totals.damage / seconds,
+			Hps:          totals.healing / seconds,
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExecutePhaseSeconds(t *testing.T) {
+	sim := &Simulation{CurrentTime: time.Second * 100}
+	sim.resetExecutePhaseStarts()
+
+	// 25% is reached at the same time as 35%, and 20% is never reached.
+	sim.executePhaseStarts[executePhaseIndex(35)] = time.Second * 60
+	sim.executePhaseStarts[executePhaseIndex(25)] = time.Second * 60
+
+	expected := [numExecutePhases]float64{60, 0, 40, 0}
+	if seconds := sim.executePhaseSeconds(); seconds != expected {
+		t.Errorf("Expected execute phase seconds %v, got %v", expected, seconds)
+	}
+}
+
+func TestBreakdownIgnoresPrepull(t *testing.T) {
+	spell := &Spell{Unit: &Unit{Type: PlayerUnit}, SpellSchool: SpellSchoolHoly, ProcMask: ProcMaskSpellHealing}
+	sim := &Simulation{CurrentTime: -time.Second}
+
+	var metrics UnitMetrics
+	metrics.addBreakdownDamage(sim, spell, false, 100)
+	metrics.addBreakdownHealing(sim, spell, false, 100)
+	if totals := metrics.breakdown.schools[SpellSchoolHoly]; totals.damage != 0 || totals.healing != 0 {
+		t.Errorf("Expected nothing done before the pull in the breakdown, got %0.1f damage and %0.1f healing", totals.damage, totals.healing)
+	}
+
+	sim.CurrentTime = time.Second
+	metrics.addBreakdownDamage(sim, spell, false, 100)
+	metrics.addBreakdownHealing(sim, spell, false, 100)
+	if totals := metrics.breakdown.schools[SpellSchoolHoly]; totals.damage != 100 || totals.healing != 100 {
+		t.Errorf("Expected 100 damage and 100 healing in the breakdown, got %0.1f and %0.1f", totals.damage, totals.healing)
+	}
+}
@@ -782,7 +782,7 @@ func applyBuffEffects(agent Agent, playerFaction proto.Faction, raidBuffs *proto
 
 	if raidBuffs.DemonicPact > 0 {
 		power := float64(raidBuffs.DemonicPact)
-		dpAura := DemonicPactAura(&character.Unit, power, CharacterBuildPhaseBuffs)
+		dpAura := DemonicPactAura(&character.Unit, power, CharacterBuildPhaseBuffs, "Raid Buff")
 		dpAura.ExclusiveEffects[0].Priority = float64(power)
 		MakePermanent(dpAura)
 	}
@@ -1918,9 +1918,11 @@ const ReplenishmentAuraDuration = time.Second * 15
 // 	return unit.ReplenishmentAura
 // }
 
-func DemonicPactAura(unit *Unit, spellpower float64, buildPhase CharacterBuildPhase) *Aura {
+// Each source of Demonic Pact gets its own aura, told apart by label so APLs
+// can still refer to it by spell ID.
+func DemonicPactAura(unit *Unit, spellpower float64, buildPhase CharacterBuildPhase, source string) *Aura {
 	aura := unit.GetOrRegisterAura(Aura{
-		Label:      "Demonic Pact",
+		Label:      "Demonic Pact-" + source,
 		ActionID:   ActionID{SpellID: 425464},
 		Duration:   time.Second * 45,
 		BuildPhase: buildPhase,
@@ -2243,7 +2245,13 @@ var (
 )
 
 func ApplyWindfury(character *Character) *Aura {
-	level := character.Level
+	return MakePermanent(WindfuryAura(&character.Unit))
+}
+
+// The Windfury Totem proc, without a duration. Returns nil below the level of
+// the first rank.
+func WindfuryAura(unit *Unit) *Aura {
+	level := unit.Level
 	if level < 32 {
 		return nil
 	}
@@ -2252,7 +2260,7 @@ func ApplyWindfury(character *Character) *Aura {
 	spellId := WindfuryBuffSpellId[rank]
 	bonusAP := WindfuryBuffBonusAP[rank]
 
-	windfuryBuffAura := character.GetOrRegisterAura(Aura{
+	windfuryBuffAura := unit.GetOrRegisterAura(Aura{
 		Label:     "Windfury Buff",
 		ActionID:  ActionID{SpellID: spellId},
 		Duration:  time.Millisecond * 1500,
@@ -2266,13 +2274,13 @@ func ApplyWindfury(character *Character) *Aura {
 	})
 
 	icd := Cooldown{
-		Timer:    character.NewTimer(),
+		Timer:    unit.NewTimer(),
 		Duration: time.Millisecond * 1500,
 	}
 
 	windfuryBuffAura.Icd = &icd
 
-	MakePermanent(character.GetOrRegisterAura(Aura{
+	return unit.GetOrRegisterAura(Aura{
 		Label: "Windfury",
 		OnSpellHitDealt: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
 			// charges are removed by every auto or next melee, whether it lands or not
@@ -2298,9 +2306,7 @@ func ApplyWindfury(character *Character) *Aura {
 				aura.Unit.AutoAttacks.ExtraMHAttack(sim, 1, ActionID{SpellID: 10610}, spell.ActionID)
 			}
 		},
-	}))
-
-	return windfuryBuffAura
+	})
 }
 
 ///////////////////////////////////////////////////////////////////////////
@@ -412,7 +412,8 @@ func (es *equipmentSubstitution) CanonicalHash() string {
 }
 
 // isValidEquipment returns true if the specified equipment spec is valid. An equipment spec
-// is valid if it does not reference a two-hander and off-hand weapon combo.
+// is valid if it does not reference a two-hander and off-hand weapon combo, or a unique item
+// more than once.
 func isValidEquipment(equipment *proto.EquipmentSpec) bool {
 	var usesTwoHander, usesOffhand bool
 
@@ -447,6 +448,17 @@ func isValidEquipment(equipment *proto.EquipmentSpec) bool {
 		return false
 	}
 
+	// Validate unique items, e.g. one-handers in both hands
+	uniqueIDs := make(map[int32]bool)
+	for _, spec := range equipment.Items {
+		if knownItem, ok := ItemsByID[spec.GetId()]; ok && knownItem.Unique {
+			if uniqueIDs[knownItem.ID] {
+				return false
+			}
+			uniqueIDs[knownItem.ID] = true
+		}
+	}
+
 	return true
 }
 
@@ -204,7 +204,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			if !spell.Flags.Matches(SpellFlagChanneled) {
 				spell.SpellMetrics[target.UnitIndex].TotalCastTime += effectiveTime
 			}
-			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+effectiveTime)
+			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+effectiveTime+spell.Unit.ActionDelay())
 		}
 
 		if (spell.CurCast.CastTime > 0) && spell.Unit.Moving {
@@ -276,7 +276,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 					}
 
 					if !sim.Options.Interactive {
-						spell.Unit.Rotation.DoNextAction(sim)
+						spell.Unit.doNextActionAfterDelay(sim)
 					}
 				},
 				Target: target,
@@ -92,6 +92,14 @@ type Character struct {
 
 	Pets []*Pet // cached in AddPet, for advance()
 
+	// Oils, stones and poisons on this Character's weapons, if any.
+	mainHandImbue *WeaponImbue
+	offHandImbue  *WeaponImbue
+
+	// Raid buffs and debuffs this Character provides, replacing their toggles.
+	providedBuffs         []ProvidedBuffConfig
+	suppressProvidedBuffs bool
+
 	ActiveShapeShift *Aura // Some things can't be used in shapeshift forms
 }
 
@@ -104,17 +112,22 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 		Unit: Unit{
 			Type:        PlayerUnit,
 			Index:       int32(party.Index*5 + partyIndex),
-			Level:       player.GetLevel(),
+			Level:       TernaryInt32(player.GetLevel() == 0, CharacterMaxLevel, player.GetLevel()),
 			auraTracker: newAuraTracker(),
 			PseudoStats: stats.NewPseudoStats(),
 			Metrics:     NewUnitMetrics(),
 
 			StatDependencyManager: stats.NewStatDependencyManager(),
 
-			ReactionTime:            max(0, time.Duration(player.ReactionTimeMs)*time.Millisecond),
-			ChannelClipDelay:        max(0, time.Duration(player.ChannelClipDelayMs)*time.Millisecond),
-			DistanceFromTarget:      player.DistanceFromTarget,
-			StartDistanceFromTarget: player.DistanceFromTarget,
+			ReactionTime:             max(0, time.Duration(player.ReactionTimeMs)*time.Millisecond),
+			ReactionTimeStdev:        max(0, time.Duration(player.GetLatency().GetReactionTimeStdevMs())*time.Millisecond),
+			ReactionTimeDistribution: player.GetLatency().GetReactionTimeDistribution(),
+			Ping:                     max(0, time.Duration(player.GetLatency().GetPingMs())*time.Millisecond),
+			SpellQueueWindow:         max(0, time.Duration(player.GetLatency().GetSpellQueueWindowMs())*time.Millisecond),
+			ChannelClipDelay:         max(0, time.Duration(player.ChannelClipDelayMs)*time.Millisecond),
+			DistanceFromTarget:       player.DistanceFromTarget,
+			StartDistanceFromTarget:  player.DistanceFromTarget,
+			StartPosition:            Vector2FromProto(player.Position),
 		},
 
 		Name:  player.Name,
@@ -133,6 +146,8 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 		PartyIndex: partyIndex,
 
 		majorCooldownManager: newMajorCooldownManager(player.Cooldowns),
+
+		suppressProvidedBuffs: player.SuppressProvidedBuffs,
 	}
 
 	character.GCD = character.NewTimer()
@@ -196,8 +211,8 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 	character.PseudoStats.CanBlock = character.OffHand().WeaponType == proto.WeaponType_WeaponTypeShield
 	character.PseudoStats.InFrontOfTarget = player.InFrontOfTarget
 
-	if player.EnableItemSwap && player.ItemSwap != nil {
-		character.enableItemSwap(player.ItemSwap)
+	if player.EnableItemSwap {
+		character.enableItemSwap(append([]*proto.ItemSwap{player.ItemSwap}, player.ItemSwapSets...))
 	}
 
 	return character
@@ -272,24 +287,28 @@ func (character *Character) addUniversalStatDependencies() {
 
 func (character *Character) applyWeaponSkills() {
 	for _, item := range character.Equipment {
-		character.PseudoStats.AxesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillAxes)]
-		character.PseudoStats.SwordsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillSwords)]
-		character.PseudoStats.MacesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillMaces)]
-		character.PseudoStats.DaggersSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillDaggers)]
-		character.PseudoStats.UnarmedSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillUnarmed)]
-		character.PseudoStats.TwoHandedAxesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillTwoHandedAxes)]
-		character.PseudoStats.TwoHandedSwordsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillTwoHandedSwords)]
-		character.PseudoStats.TwoHandedMacesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillTwoHandedMaces)]
-		character.PseudoStats.PolearmsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillPolearms)]
-		character.PseudoStats.StavesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillStaves)]
-		character.PseudoStats.ThrownSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillThrown)]
-		character.PseudoStats.BowsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillBows)]
-		character.PseudoStats.CrossbowsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillCrossbows)]
-		character.PseudoStats.GunsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillGuns)]
-		character.PseudoStats.FeralCombatSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillFeralCombat)]
+		character.addItemWeaponSkills(item, 1)
 	}
 }
 
+func (character *Character) addItemWeaponSkills(item Item, multiplier float64) {
+	character.PseudoStats.AxesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillAxes)] * multiplier
+	character.PseudoStats.SwordsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillSwords)] * multiplier
+	character.PseudoStats.MacesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillMaces)] * multiplier
+	character.PseudoStats.DaggersSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillDaggers)] * multiplier
+	character.PseudoStats.UnarmedSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillUnarmed)] * multiplier
+	character.PseudoStats.TwoHandedAxesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillTwoHandedAxes)] * multiplier
+	character.PseudoStats.TwoHandedSwordsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillTwoHandedSwords)] * multiplier
+	character.PseudoStats.TwoHandedMacesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillTwoHandedMaces)] * multiplier
+	character.PseudoStats.PolearmsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillPolearms)] * multiplier
+	character.PseudoStats.StavesSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillStaves)] * multiplier
+	character.PseudoStats.ThrownSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillThrown)] * multiplier
+	character.PseudoStats.BowsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillBows)] * multiplier
+	character.PseudoStats.CrossbowsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillCrossbows)] * multiplier
+	character.PseudoStats.GunsSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillGuns)] * multiplier
+	character.PseudoStats.FeralCombatSkill += item.WeaponSkills[int32(proto.WeaponSkill_WeaponSkillFeralCombat)] * multiplier
+}
+
 // Returns a partially-filled PlayerStats proto for use in the CharacterStats api call.
 func (character *Character) applyAllEffects(agent Agent, raidBuffs *proto.RaidBuffs, partyBuffs *proto.PartyBuffs, individualBuffs *proto.IndividualBuffs) *proto.PlayerStats {
 	playerStats := &proto.PlayerStats{}
@@ -317,6 +336,7 @@ func (character *Character) applyAllEffects(agent Agent, raidBuffs *proto.RaidBu
 	character.ApplyRingRunes()
 	character.applyItemEffects(agent)
 	character.applyItemSetBonusEffects(agent)
+	character.ItemSwap.applyMainSetEffects()
 	character.applyBuildPhaseAuras(CharacterBuildPhaseGear)
 	playerStats.GearStats = measureStats()
 
@@ -365,29 +385,16 @@ func (character *Character) clearBuildPhaseAuras(phase CharacterBuildPhase) {
 func (character *Character) applyItemEffects(agent Agent) {
 	for slot, eq := range character.Equipment {
 		if applyItemEffect, ok := itemEffects[eq.ID]; ok {
-			applyItemEffect(agent)
-		}
-
-		if applyEnchantEffect, ok := enchantEffects[eq.Enchant.EffectID]; ok {
-			applyEnchantEffect(agent)
+			character.ItemSwap.applyEffect(character.ItemSwap.getItemActiveInSets(eq.ID), true, func() {
+				applyItemEffect(agent)
+			})
 		}
 
-		if applyWeaponEffect, ok := weaponEffects[eq.Enchant.EffectID]; ok {
-			applyWeaponEffect(agent, proto.ItemSlot(slot))
-		}
+		character.ItemSwap.applyEnchantEffects(agent, proto.ItemSlot(slot), eq.Enchant.EffectID)
 	}
 
 	if character.ItemSwap.IsEnabled() {
-		offset := int(proto.ItemSlot_ItemSlotMainHand)
-		for i, item := range character.ItemSwap.unEquippedItems {
-			if applyEnchantEffect, ok := enchantEffects[item.Enchant.EffectID]; ok {
-				applyEnchantEffect(agent)
-			}
-
-			if applyWeaponEffect, ok := weaponEffects[item.Enchant.EffectID]; ok {
-				applyWeaponEffect(agent, proto.ItemSlot(offset+i))
-			}
-		}
+		character.ItemSwap.applySwapItemEffects(agent)
 	}
 }
 
@@ -430,7 +437,7 @@ func (character *Character) initialize(agent Agent) {
 				}
 			}
 
-			if sim.CurrentTime < 0 {
+			if sim.CurrentTime < 0 || !character.IsEnabled() {
 				return
 			}
 
@@ -473,6 +480,7 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 	}
 	character.clearBuildPhaseAuras(CharacterBuildPhaseAll)
 	playerStats.Sets = character.GetActiveSetBonusNames()
+	playerStats.UnimplementedItemEffects = character.getUnimplementedItemEffects()
 
 	playerStats.Metadata = character.GetMetadata()
 	for _, pet := range character.Pets {
@@ -491,6 +499,7 @@ func (character *Character) reset(sim *Simulation, agent Agent) {
 	character.majorCooldownManager.reset(sim)
 	character.ItemSwap.reset(sim)
 	character.CurrentTarget = character.defaultTarget
+	character.updateDistanceFromTarget()
 
 	agent.Reset(sim)
 
@@ -596,6 +605,12 @@ func (character *Character) doneIteration(sim *Simulation) {
 	}
 
 	character.Unit.doneIteration(sim)
+
+	for _, imbue := range []*WeaponImbue{character.mainHandImbue, character.offHandImbue} {
+		if imbue != nil {
+			imbue.metrics.doneIteration()
+		}
+	}
 }
 
 func (character *Character) GetPseudoStatsProto() []float64 {
@@ -637,6 +652,7 @@ func (character *Character) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = character.Name
 	metrics.UnitIndex = character.UnitIndex
 	metrics.Auras = character.auraTracker.GetMetricsProto()
+	metrics.WeaponImbues = character.getWeaponImbueMetricsProto()
 
 	metrics.Pets = make([]*proto.UnitMetrics, len(character.Pets))
 	for i, pet := range character.Pets {
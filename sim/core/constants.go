@@ -15,6 +15,8 @@ const ArmorPenPerPercentArmor = 13.99
 
 const MaxMeleeAttackDistance = 5
 const MinRangedAttackDistance = 12
+const MaxRangedAttackDistance = 35
+const DefaultSpellRange = 30
 
 const MissDodgeParryBlockCritChancePerDefense = 0.04
 
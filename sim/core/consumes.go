@@ -99,89 +99,102 @@ func applyWeaponImbueConsumes(character *Character, consumes *proto.Consumes) {
 
 func addImbueStats(character *Character, imbue proto.WeaponImbue, isMh bool, shadowOilIcd Cooldown) {
 	if imbue != proto.WeaponImbue_WeaponImbueUnknown {
+		config := WeaponImbueConfig{
+			Imbue:    imbue,
+			IsMH:     isMh,
+			Duration: WeaponImbueDuration,
+		}
+
 		switch imbue {
 		// Wizard Oils
 		case proto.WeaponImbue_MinorWizardOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 20744}
+			config.Stats = stats.Stats{
 				stats.SpellPower: 8,
-			})
+			}
 		case proto.WeaponImbue_LesserWizardOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 20746}
+			config.Stats = stats.Stats{
 				stats.SpellPower: 16,
-			})
+			}
 		case proto.WeaponImbue_WizardOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 20750}
+			config.Stats = stats.Stats{
 				stats.SpellPower: 24,
-			})
+			}
 		case proto.WeaponImbue_BrillianWizardOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 20749}
+			config.Stats = stats.Stats{
 				stats.SpellPower: 36,
 				stats.SpellCrit:  1 * SpellCritRatingPerCritChance,
-			})
+			}
 
 		// Mana Oils
 		case proto.WeaponImbue_MinorManaOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 20745}
+			config.Stats = stats.Stats{
 				stats.MP5: 4,
-			})
+			}
 		case proto.WeaponImbue_LesserManaOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 20747}
+			config.Stats = stats.Stats{
 				stats.MP5: 8,
-			})
+			}
 		case proto.WeaponImbue_BrilliantManaOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 20748}
+			config.Stats = stats.Stats{
 				stats.MP5:          12,
 				stats.HealingPower: 25,
-			})
+			}
 		case proto.WeaponImbue_BlackfathomManaOil:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 211848}
+			config.Stats = stats.Stats{
 				stats.MP5:      12,
 				stats.SpellHit: 2 * SpellHitRatingPerHitChance,
-			})
+			}
 
 		// Shield Oil
 		case proto.WeaponImbue_ConductiveShieldCoating:
-			character.AddStat(stats.SpellPower, 24)
+			config.ActionID = ActionID{ItemID: 228980}
+			config.Stats = stats.Stats{
+				stats.SpellPower: 24,
+			}
 
 		// Sharpening Stones
 		case proto.WeaponImbue_SolidSharpeningStone:
-			weapon := character.AutoAttacks.MH()
-			if !isMh {
-				weapon = character.AutoAttacks.OH()
-			}
-			weapon.BaseDamageMin += 6
-			weapon.BaseDamageMax += 6
+			config.ActionID = ActionID{ItemID: 7964}
+			config.WeaponDamage = 6
 		case proto.WeaponImbue_DenseSharpeningStone:
-			weapon := character.AutoAttacks.MH()
-			if !isMh {
-				weapon = character.AutoAttacks.OH()
-			}
-			weapon.BaseDamageMin += 8
-			weapon.BaseDamageMax += 8
+			config.ActionID = ActionID{ItemID: 12404}
+			config.WeaponDamage = 8
 		case proto.WeaponImbue_ElementalSharpeningStone:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 18262}
+			config.Stats = stats.Stats{
 				stats.MeleeCrit: 2 * CritRatingPerCritChance,
-			})
+			}
 		case proto.WeaponImbue_BlackfathomSharpeningStone:
-			character.AddStats(stats.Stats{
+			config.ActionID = ActionID{ItemID: 211845}
+			config.Stats = stats.Stats{
 				stats.MeleeHit: 2 * MeleeHitRatingPerHitChance,
-			})
+			}
 
 		// Weightstones
 		case proto.WeaponImbue_SolidWeightstone:
-			weapon := character.AutoAttacks.MH()
-			if !isMh {
-				weapon = character.AutoAttacks.OH()
-			}
-			weapon.BaseDamageMin += 6
-			weapon.BaseDamageMax += 6
+			config.ActionID = ActionID{ItemID: 7965}
+			config.WeaponDamage = 6
 		case proto.WeaponImbue_DenseWeightstone:
-			weapon := character.AutoAttacks.MH()
-			if !isMh {
-				weapon = character.AutoAttacks.OH()
-			}
-			weapon.BaseDamageMin += 8
-			weapon.BaseDamageMax += 8
+			config.ActionID = ActionID{ItemID: 12643}
+			config.WeaponDamage = 8
+
+		// Spell Oils
+		case proto.WeaponImbue_ShadowOil:
+			config.ActionID = ActionID{ItemID: 3824}
+			registerShadowOil(character, character.RegisterWeaponImbue(config), shadowOilIcd)
+			return
+		case proto.WeaponImbue_FrostOil:
+			config.ActionID = ActionID{ItemID: 3829}
+			registerFrostOil(character, character.RegisterWeaponImbue(config))
+			return
 
 		// Windfury
 		case proto.WeaponImbue_WildStrikes:
@@ -189,17 +202,21 @@ func addImbueStats(character *Character, imbue proto.WeaponImbue, isMh bool, sha
 			if !character.HasRuneById(int32(proto.DruidRune_RuneChestWildStrikes)) {
 				ApplyWildStrikes(character)
 			}
+			return
 		case proto.WeaponImbue_Windfury:
 			ApplyWindfury(character)
-		case proto.WeaponImbue_ShadowOil:
-			registerShadowOil(character, isMh, shadowOilIcd)
-		case proto.WeaponImbue_FrostOil:
-			registerFrostOil(character, isMh)
+			return
+
+		// Class imbues, such as poisons, are registered by the class.
+		default:
+			return
 		}
+
+		character.RegisterWeaponImbue(config)
 	}
 }
 
-func registerShadowOil(character *Character, isMh bool, icd Cooldown) {
+func registerShadowOil(character *Character, imbue *WeaponImbue, icd Cooldown) {
 	procChance := 0.15
 
 	procSpell := character.GetOrRegisterSpell(SpellConfig{
@@ -221,7 +238,7 @@ func registerShadowOil(character *Character, isMh bool, icd Cooldown) {
 
 	label := " MH"
 	procMask := ProcMaskMeleeMH
-	if !isMh {
+	if !imbue.IsMH {
 		label = " OH"
 		procMask = ProcMaskMeleeOH
 	}
@@ -233,7 +250,7 @@ func registerShadowOil(character *Character, isMh bool, icd Cooldown) {
 				return
 			}
 
-			if !spell.ProcMask.Matches(procMask) {
+			if !spell.ProcMask.Matches(procMask) || !imbue.IsActive() {
 				return
 			}
 
@@ -249,7 +266,7 @@ func registerShadowOil(character *Character, isMh bool, icd Cooldown) {
 	}))
 }
 
-func registerFrostOil(character *Character, isMh bool) {
+func registerFrostOil(character *Character, imbue *WeaponImbue) {
 	procChance := 0.10
 
 	procSpell := character.GetOrRegisterSpell(SpellConfig{
@@ -271,7 +288,7 @@ func registerFrostOil(character *Character, isMh bool) {
 
 	label := " MH"
 	procMask := ProcMaskMeleeMHAuto
-	if !isMh {
+	if !imbue.IsMH {
 		label = " OH"
 		procMask = ProcMaskMeleeOHAuto
 	}
@@ -283,7 +300,7 @@ func registerFrostOil(character *Character, isMh bool) {
 				return
 			}
 
-			if !spell.ProcMask.Matches(procMask) {
+			if !spell.ProcMask.Matches(procMask) || !imbue.IsActive() {
 				return
 			}
 
@@ -0,0 +1,325 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const (
+	defaultCooldownTimingsSearchIterations = 200
+	defaultCooldownTimingsMaxSchedules     = 200
+	defaultCooldownTimingsIterations       = 1000
+	defaultCooldownTimingStep              = 1.0
+)
+
+// Timing of each variable, in seconds, in the order of the request's variables.
+type cooldownSchedule []float64
+
+func (schedule cooldownSchedule) key() string {
+	return fmt.Sprint([]float64(schedule))
+}
+
+// cooldownTimingsOptimizer searches the timings of a player's major cooldowns
+// and consumables for the highest raid DPS. All sims use the same seed, so
+// schedules are compared on the same rolls and fight lengths.
+type cooldownTimingsOptimizer struct {
+	// SingleRaidSimRunner used to sim one schedule.
+	SingleRaidSimRunner raidSimRunner
+	// Request used for this optimization.
+	Request *proto.CooldownTimingsRequest
+
+	baseSettings *proto.RaidSimRequest
+	variables    []*proto.CooldownTimingVariable
+
+	searchIterations int32
+	iterations       int32
+	maxSchedules     int
+
+	simBatchProgress
+}
+
+func CooldownTimings(ctx context.Context, request *proto.CooldownTimingsRequest, progress chan *proto.ProgressMetrics) *proto.CooldownTimingsResult {
+	optimizer := &cooldownTimingsOptimizer{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+
+	result, err := optimizer.Run(ctx, progress)
+	if err != nil {
+		result = &proto.CooldownTimingsResult{
+			ErrorResult: err.Error(),
+		}
+	}
+
+	sendFinalProgress(progress, &proto.ProgressMetrics{
+		FinalCooldownTimingsResult: result,
+	})
+
+	return result
+}
+
+func (o *cooldownTimingsOptimizer) Run(ctx context.Context, progress chan *proto.ProgressMetrics) (result *proto.CooldownTimingsResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.CooldownTimingsResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if err := o.init(progress); err != nil {
+		return nil, err
+	}
+
+	simmed := map[string]float64{}
+	evaluate := func(schedules []cooldownSchedule) error {
+		var toSim []cooldownSchedule
+		for _, schedule := range schedules {
+			if len(simmed)+len(toSim) >= o.maxSchedules {
+				break
+			}
+			if _, ok := simmed[schedule.key()]; ok || slices.ContainsFunc(toSim, func(s cooldownSchedule) bool { return s.key() == schedule.key() }) {
+				continue
+			}
+			toSim = append(toSim, schedule)
+		}
+
+		results, err := o.simSchedules(ctx, toSim, o.searchIterations)
+		if err != nil {
+			return err
+		}
+		for i, schedule := range toSim {
+			simmed[schedule.key()] = results[i].RaidMetrics.Dps.Avg
+		}
+		return nil
+	}
+
+	best := o.initialSchedule()
+	if err := evaluate([]cooldownSchedule{best}); err != nil {
+		return nil, err
+	}
+	bestDps := simmed[best.key()]
+
+	// Sweep each variable over its range with the others fixed, until a full
+	// pass doesn't find a better schedule.
+	for improved := true; improved && len(simmed) < o.maxSchedules; {
+		improved = false
+		for i, variable := range o.variables {
+			var candidates []cooldownSchedule
+			for _, timing := range o.variableTimings(variable) {
+				candidate := slices.Clone(best)
+				candidate[i] = timing
+				candidates = append(candidates, candidate)
+			}
+			if err := evaluate(candidates); err != nil {
+				return nil, err
+			}
+
+			for _, candidate := range candidates {
+				if dps, ok := simmed[candidate.key()]; ok && dps > bestDps {
+					best, bestDps = candidate, dps
+					improved = true
+				}
+			}
+		}
+	}
+
+	// Compare the best schedule with the player's current cooldowns, using
+	// full iterations and paired per-iteration differences.
+	baselineRequest := googleProto.Clone(o.baseSettings).(*proto.RaidSimRequest)
+	baselineRequest.SimOptions.Iterations = o.iterations
+	baselineRequest.SimOptions.SaveAllValues = true
+	bestRequest := o.raidSimRequest(best, o.iterations)
+	bestRequest.SimOptions.SaveAllValues = true
+
+	finalResults, err := runSimBatch(ctx, o.SingleRaidSimRunner, []*proto.RaidSimRequest{baselineRequest, bestRequest}, o.onSimDone)
+	if err != nil {
+		return nil, err
+	}
+	baselineDps, bestDpsMetrics := finalResults[0].RaidMetrics.Dps, finalResults[1].RaidMetrics.Dps
+
+	dpsGain, dpsGainCi95 := pairedDpsGain(baselineDps, bestDpsMetrics)
+
+	// Don't send every iteration's DPS back.
+	baselineDps.AllValues = nil
+	bestDpsMetrics.AllValues = nil
+
+	return &proto.CooldownTimingsResult{
+		BestCooldowns:   playerAtRaidIndex(bestRequest.Raid, o.Request.RaidIndex).Cooldowns,
+		BestTimings:     best,
+		BaselineDps:     baselineDps,
+		BestDps:         bestDpsMetrics,
+		DpsGain:         dpsGain,
+		DpsGainCi95:     dpsGainCi95,
+		SchedulesSimmed: int32(len(simmed)),
+	}, nil
+}
+
+// Returns the mean DPS gain from paired per-iteration differences, and its 95%
+// confidence interval. Without per-iteration values, e.g. from a runner that
+// doesn't save them, it falls back to the difference of the averages with a
+// confidence interval of 0.
+func pairedDpsGain(baselineDps, bestDps *proto.DistributionMetrics) (float64, float64) {
+	var gain aggregator
+	for i := 0; i < min(len(baselineDps.AllValues), len(bestDps.AllValues)); i++ {
+		gain.add(bestDps.AllValues[i] - baselineDps.AllValues[i])
+	}
+	if gain.n == 0 {
+		return bestDps.Avg - baselineDps.Avg, 0
+	}
+
+	gainMean, gainStdev := gain.meanAndStdDevOrZero()
+	return gainMean, gainStdev / math.Sqrt(float64(gain.n)) * ConfidenceInterval95Z
+}
+
+func (o *cooldownTimingsOptimizer) init(progress chan *proto.ProgressMetrics) error {
+	if o.Request.BaseSettings == nil || o.Request.BaseSettings.Raid == nil {
+		return errors.New("cooldown timings: missing base settings")
+	}
+	if len(o.Request.Variables) == 0 {
+		return errors.New("cooldown timings: no timings to optimize")
+	}
+
+	o.baseSettings = googleProto.Clone(o.Request.BaseSettings).(*proto.RaidSimRequest)
+	if o.baseSettings.SimOptions == nil {
+		o.baseSettings.SimOptions = &proto.SimOptions{}
+	}
+	// All schedules use the same seed, so they're compared on the same rolls.
+	pinRandomSeed(o.baseSettings.SimOptions)
+	// Every sim needs the same number of iterations for them to be paired.
+	o.baseSettings.SimOptions.TargetError = 0
+	o.baseSettings.SimOptions.Debug = false
+	o.baseSettings.SimOptions.DebugFirstIteration = false
+	o.baseSettings.SimOptions.BuffContributions = false
+
+	player := playerAtRaidIndex(o.baseSettings.Raid, o.Request.RaidIndex)
+	if player == nil || player.Class == proto.Class_ClassUnknown {
+		return fmt.Errorf("cooldown timings: no player at raid index %d", o.Request.RaidIndex)
+	}
+
+	// Only major cooldowns follow timings, so check the variables against the
+	// player's cooldowns once it's constructed.
+	env, _, _ := NewEnvironment(googleProto.Clone(o.baseSettings.Raid).(*proto.Raid), o.baseSettings.Encounter, false)
+	var cooldownIDs []*proto.ActionID
+	for _, party := range env.Raid.Parties {
+		for _, agent := range party.Players {
+			if agent.GetCharacter().Index == o.Request.RaidIndex {
+				cooldownIDs = agent.GetCharacter().GetMajorCooldownIDs()
+			}
+		}
+	}
+
+	for _, variable := range o.Request.Variables {
+		if variable.Id == nil {
+			return errors.New("cooldown timings: missing cooldown ID")
+		}
+		actionID := ProtoToActionID(variable.Id)
+		if !slices.ContainsFunc(cooldownIDs, func(id *proto.ActionID) bool { return ProtoToActionID(id).SameAction(actionID) }) {
+			return fmt.Errorf("cooldown timings: %s is not a major cooldown of %s", actionID, player.Name)
+		}
+		if variable.Usage < 0 || variable.MinSeconds < 0 || variable.MaxSeconds < variable.MinSeconds {
+			return fmt.Errorf("cooldown timings: invalid range for %s", actionID)
+		}
+
+		variable = googleProto.Clone(variable).(*proto.CooldownTimingVariable)
+		if variable.StepSeconds <= 0 {
+			variable.StepSeconds = defaultCooldownTimingStep
+		}
+		o.variables = append(o.variables, variable)
+	}
+
+	o.searchIterations = TernaryInt32(o.Request.SearchIterations > 0, o.Request.SearchIterations, defaultCooldownTimingsSearchIterations)
+	o.iterations = TernaryInt32(o.baseSettings.SimOptions.Iterations > 0, o.baseSettings.SimOptions.Iterations, defaultCooldownTimingsIterations)
+	o.maxSchedules = int(TernaryInt32(o.Request.MaxSchedules > 0, o.Request.MaxSchedules, defaultCooldownTimingsMaxSchedules))
+
+	o.progress = progress
+	o.totalSims = int32(o.maxSchedules + 2)
+	return nil
+}
+
+// Starts from the player's current timings where they're in range, and the
+// earliest timing otherwise.
+func (o *cooldownTimingsOptimizer) initialSchedule() cooldownSchedule {
+	player := playerAtRaidIndex(o.baseSettings.Raid, o.Request.RaidIndex)
+
+	schedule := make(cooldownSchedule, len(o.variables))
+	for i, variable := range o.variables {
+		schedule[i] = variable.MinSeconds
+		if cooldown := findCooldownConfig(player.Cooldowns, variable.Id); cooldown != nil && int(variable.Usage) < len(cooldown.Timings) {
+			timing := cooldown.Timings[variable.Usage]
+			if timing >= variable.MinSeconds && timing <= variable.MaxSeconds {
+				schedule[i] = timing
+			}
+		}
+	}
+	return schedule
+}
+
+func (o *cooldownTimingsOptimizer) variableTimings(variable *proto.CooldownTimingVariable) []float64 {
+	var timings []float64
+	for i := 0; ; i++ {
+		timing := variable.MinSeconds + float64(i)*variable.StepSeconds
+		if timing > variable.MaxSeconds+1e-9 {
+			break
+		}
+		timings = append(timings, timing)
+	}
+	return timings
+}
+
+func (o *cooldownTimingsOptimizer) raidSimRequest(schedule cooldownSchedule, iterations int32) *proto.RaidSimRequest {
+	request := googleProto.Clone(o.baseSettings).(*proto.RaidSimRequest)
+	request.SimOptions.Iterations = iterations
+
+	player := playerAtRaidIndex(request.Raid, o.Request.RaidIndex)
+	if player.Cooldowns == nil {
+		player.Cooldowns = &proto.Cooldowns{}
+	}
+	for i, variable := range o.variables {
+		cooldown := findCooldownConfig(player.Cooldowns, variable.Id)
+		if cooldown == nil {
+			cooldown = &proto.Cooldown{Id: variable.Id}
+			player.Cooldowns.Cooldowns = append(player.Cooldowns.Cooldowns, cooldown)
+		}
+		// Earlier uses without a timing are used as soon as possible.
+		for len(cooldown.Timings) <= int(variable.Usage) {
+			cooldown.Timings = append(cooldown.Timings, 0)
+		}
+		cooldown.Timings[variable.Usage] = schedule[i]
+	}
+
+	return request
+}
+
+func (o *cooldownTimingsOptimizer) simSchedules(ctx context.Context, schedules []cooldownSchedule, iterations int32) ([]*proto.RaidSimResult, error) {
+	requests := MapSlice(schedules, func(schedule cooldownSchedule) *proto.RaidSimRequest {
+		return o.raidSimRequest(schedule, iterations)
+	})
+	return runSimBatch(ctx, o.SingleRaidSimRunner, requests, o.onSimDone)
+}
+
+func playerAtRaidIndex(raid *proto.Raid, raidIndex int32) *proto.Player {
+	partyIdx, playerIdx := int(raidIndex/5), int(raidIndex%5)
+	if raidIndex < 0 || partyIdx >= len(raid.Parties) || playerIdx >= len(raid.Parties[partyIdx].GetPlayers()) {
+		return nil
+	}
+	return raid.Parties[partyIdx].Players[playerIdx]
+}
+
+func findCooldownConfig(cooldowns *proto.Cooldowns, id *proto.ActionID) *proto.Cooldown {
+	actionID := ProtoToActionID(id)
+	for _, cooldown := range cooldowns.GetCooldowns() {
+		if ProtoToActionID(cooldown.Id).SameAction(actionID) {
+			return cooldown
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestCooldownTimingsSchedule(t *testing.T) {
+	potion := &proto.ActionID{RawId: &proto.ActionID_ItemId{ItemId: 13442}}
+	trinket := &proto.ActionID{RawId: &proto.ActionID_ItemId{ItemId: 11815}}
+
+	optimizer := &cooldownTimingsOptimizer{
+		Request: &proto.CooldownTimingsRequest{RaidIndex: 1},
+		baseSettings: &proto.RaidSimRequest{
+			Raid: &proto.Raid{
+				Parties: []*proto.Party{{
+					Players: []*proto.Player{
+						{Name: "Other"},
+						{Name: "Player", Cooldowns: &proto.Cooldowns{Cooldowns: []*proto.Cooldown{{Id: potion, Timings: []float64{5}}}}},
+					},
+				}},
+			},
+			SimOptions: &proto.SimOptions{},
+		},
+		variables: []*proto.CooldownTimingVariable{
+			{Id: potion, MinSeconds: 0, MaxSeconds: 10, StepSeconds: 2.5},
+			{Id: trinket, Usage: 1, MinSeconds: 90, MaxSeconds: 120, StepSeconds: 15},
+		},
+	}
+
+	if got, want := optimizer.variableTimings(optimizer.variables[0]), []float64{0, 2.5, 5, 7.5, 10}; !slices.Equal(got, want) {
+		t.Errorf("variableTimings() = %v, want %v", got, want)
+	}
+
+	// The current potion timing is in range, but the trinket has none.
+	schedule := optimizer.initialSchedule()
+	if want := (cooldownSchedule{5, 90}); !slices.Equal(schedule, want) {
+		t.Errorf("initialSchedule() = %v, want %v", schedule, want)
+	}
+
+	request := optimizer.raidSimRequest(cooldownSchedule{7.5, 105}, 10)
+	cooldowns := request.Raid.Parties[0].Players[1].Cooldowns
+	if got := findCooldownConfig(cooldowns, potion).Timings; !slices.Equal(got, []float64{7.5}) {
+		t.Errorf("Potion timings = %v, want [7.5]", got)
+	}
+	if got := findCooldownConfig(cooldowns, trinket).Timings; !slices.Equal(got, []float64{0, 105}) {
+		t.Errorf("Trinket timings = %v, want [0 105]", got)
+	}
+	if request.SimOptions.Iterations != 10 {
+		t.Errorf("Expected 10 iterations, got %d", request.SimOptions.Iterations)
+	}
+
+	// The base settings aren't modified.
+	if got := optimizer.baseSettings.Raid.Parties[0].Players[1].Cooldowns.Cooldowns; len(got) != 1 || got[0].Timings[0] != 5 {
+		t.Errorf("Base settings were modified: %v", got)
+	}
+}
+
+func TestPairedDpsGain(t *testing.T) {
+	// Without per-iteration values, only the averages can be compared.
+	gain, ci95 := pairedDpsGain(&proto.DistributionMetrics{Avg: 100}, &proto.DistributionMetrics{Avg: 110})
+	if gain != 10 || ci95 != 0 {
+		t.Errorf("Expected a gain of 10 +/- 0 without per-iteration values, got %0.3f +/- %0.3f", gain, ci95)
+	}
+
+	// A constant difference has no variance, which shouldn't turn into NaN.
+	gain, ci95 = pairedDpsGain(
+		&proto.DistributionMetrics{Avg: 101, AllValues: []float64{100, 101, 102}},
+		&proto.DistributionMetrics{Avg: 106, AllValues: []float64{105, 106, 107}},
+	)
+	if !WithinToleranceFloat64(5, gain, 0.0001) || math.IsNaN(ci95) || ci95 > 0.0001 {
+		t.Errorf("Expected a gain of 5 +/- 0 for a constant difference, got %0.3f +/- %0.3f", gain, ci95)
+	}
+}
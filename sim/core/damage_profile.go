@@ -0,0 +1,132 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+type damageProfileSource struct {
+	spell *Spell
+	dtps  float64
+}
+
+// Schools of the DamageProfile fields, in the order returned by damageProfileDtps.
+var damageProfileSchools = []SpellSchool{
+	SpellSchoolPhysical,
+	SpellSchoolArcane,
+	SpellSchoolFire,
+	SpellSchoolFrost,
+	SpellSchoolHoly,
+	SpellSchoolNature,
+	SpellSchoolShadow,
+}
+
+func damageProfileDtps(profile *proto.DamageProfile) []float64 {
+	return []float64{
+		profile.PhysicalDtps,
+		profile.ArcaneDtps,
+		profile.FireDtps,
+		profile.FrostDtps,
+		profile.HolyDtps,
+		profile.NatureDtps,
+		profile.ShadowDtps,
+	}
+}
+
+func damageProfileIsEnabled(profile *proto.DamageProfile) bool {
+	if profile == nil {
+		return false
+	}
+	for _, dtps := range damageProfileDtps(profile) {
+		if dtps > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func validateDamageProfile(profile *proto.DamageProfile) error {
+	if profile == nil {
+		return nil
+	}
+	for _, dtps := range damageProfileDtps(profile) {
+		if dtps < 0 {
+			return fmt.Errorf("damage profile DTPS must not be negative, got %0.1f", dtps)
+		}
+	}
+	if profile.CadenceSeconds < 0 {
+		return fmt.Errorf("damage profile cadence must not be negative, got %0.2fs", profile.CadenceSeconds)
+	}
+	if profile.CadenceVariation < 0 {
+		return fmt.Errorf("damage profile cadence variation must not be negative, got %0.2fs", profile.CadenceVariation)
+	}
+	if profile.DamageVariation < 0 || profile.DamageVariation > 1 {
+		return fmt.Errorf("damage profile variation must be between 0 and 1, got %0.2f", profile.DamageVariation)
+	}
+	return nil
+}
+
+// Makes the primary target hit this character at a regular cadence, according
+// to its incoming damage profile. The damage goes through the normal damage
+// taken pipeline, so it's mitigated by armor and resistances, removes health
+// and procs damage taken effects, e.g. rage generation. The profile is checked
+// by validateDamageProfile when the request comes in.
+func (character *Character) applyDamageProfile(profile *proto.DamageProfile) {
+	if !damageProfileIsEnabled(profile) || len(character.Env.Encounter.TargetUnits) == 0 {
+		return
+	}
+	// The spells are shared by all players with a damage profile.
+	attacker := character.Env.Encounter.TargetUnits[0]
+
+	var sources []damageProfileSource
+	for i, dtps := range damageProfileDtps(profile) {
+		if dtps <= 0 {
+			continue
+		}
+
+		school := damageProfileSchools[i]
+		sources = append(sources, damageProfileSource{
+			spell: attacker.GetOrRegisterSpell(SpellConfig{
+				ActionID:    ActionID{OtherID: proto.OtherAction_OtherActionDamageProfile, Tag: int32(i)},
+				SpellSchool: school,
+				DefenseType: Ternary(school == SpellSchoolPhysical, DefenseTypeMelee, DefenseTypeMagic),
+				ProcMask:    ProcMaskEmpty,
+				Flags:       SpellFlagIgnoreAttackerModifiers | SpellFlagNoOnCastComplete,
+
+				DamageMultiplier: 1,
+			}),
+			dtps: dtps,
+		})
+	}
+
+	medianCadence := profile.CadenceSeconds
+	if medianCadence == 0 {
+		medianCadence = 2.0
+	}
+	rollCadence := newCadenceRoller("Damage Profile", medianCadence, profile.CadenceVariation)
+
+	character.RegisterResetEffect(func(sim *Simulation) {
+		timeToNextHit := rollCadence(sim)
+		pa := &PendingAction{
+			NextActionAt: timeToNextHit,
+		}
+
+		pa.OnAction = func(sim *Simulation) {
+			// Scale each hit by the time since the last one, so DTPS stays constant.
+			for _, source := range sources {
+				damage := source.dtps * timeToNextHit.Seconds()
+				if profile.DamageVariation > 0 {
+					damage *= 1 + profile.DamageVariation*(2*sim.RandomFloat("Damage Profile Variation")-1)
+				}
+				source.spell.CalcAndDealDamage(sim, &character.Unit, damage, source.spell.OutcomeAlwaysHit)
+			}
+
+			timeToNextHit = rollCadence(sim)
+			pa.NextActionAt = sim.CurrentTime + timeToNextHit
+			sim.AddPendingAction(pa)
+		}
+
+		sim.AddPendingAction(pa)
+	})
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestDamageProfileSchools(t *testing.T) {
+	if damageProfileIsEnabled(nil) || damageProfileIsEnabled(&proto.DamageProfile{CadenceSeconds: 2}) {
+		t.Errorf("Expected a damage profile without any DTPS to be disabled")
+	}
+
+	dtps := damageProfileDtps(&proto.DamageProfile{
+		PhysicalDtps: 1,
+		ArcaneDtps:   2,
+		FireDtps:     3,
+		FrostDtps:    4,
+		HolyDtps:     5,
+		NatureDtps:   6,
+		ShadowDtps:   7,
+	})
+	expected := map[SpellSchool]float64{
+		SpellSchoolPhysical: 1,
+		SpellSchoolArcane:   2,
+		SpellSchoolFire:     3,
+		SpellSchoolFrost:    4,
+		SpellSchoolHoly:     5,
+		SpellSchoolNature:   6,
+		SpellSchoolShadow:   7,
+	}
+	for i, school := range damageProfileSchools {
+		if dtps[i] != expected[school] {
+			t.Errorf("Expected %0.0f DTPS for school %d, got %0.0f", expected[school], school, dtps[i])
+		}
+	}
+
+	if !damageProfileIsEnabled(&proto.DamageProfile{ShadowDtps: 10}) {
+		t.Errorf("Expected a damage profile with shadow DTPS to be enabled")
+	}
+}
+
+func TestCadenceRoller(t *testing.T) {
+	sim := SetupFakeSim()
+
+	rollFixed := newCadenceRoller("Test", 2, 0)
+	rollVaried := newCadenceRoller("Test Varied", 2, 3)
+	for i := 0; i < 100; i++ {
+		if cadence := rollFixed(sim); cadence != time.Second*2 {
+			t.Fatalf("Expected a 2s cadence without variation, got %s", cadence)
+		}
+		// The low side is clamped at 0, the high side isn't.
+		if cadence := rollVaried(sim); cadence < 0 || cadence > time.Second*5 {
+			t.Fatalf("Expected a cadence between 0s and 5s, got %s", cadence)
+		}
+	}
+}
+
+func runDamageProfileSim(profile *proto.DamageProfile) *SpellMetrics {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:          "Caster",
+							Class:         proto.Class_ClassShaman,
+							Consumes:      &proto.Consumes{},
+							Buffs:         &proto.IndividualBuffs{},
+							Spec:          &proto.Player_ElementalShaman{},
+							Equipment:     &proto.EquipmentSpec{},
+							DamageProfile: profile,
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 60,
+		},
+	})
+	sim.runOnce()
+
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	spell := sim.Encounter.TargetUnits[0].GetSpell(ActionID{OtherID: proto.OtherAction_OtherActionDamageProfile, Tag: 4})
+	return &spell.SpellMetrics[character.UnitIndex]
+}
+
+func TestDamageProfileHits(t *testing.T) {
+	// Holy damage can't be mitigated, so every hit should be exactly DTPS * cadence.
+	metrics := runDamageProfileSim(&proto.DamageProfile{HolyDtps: 100, CadenceSeconds: 2})
+	if metrics.Hits < 29 || metrics.Hits > 30 {
+		t.Fatalf("Expected a hit every 2s for 60s, got %d hits", metrics.Hits)
+	}
+	if expected := 200 * float64(metrics.Hits); !WithinToleranceFloat64(expected, metrics.TotalDamage, 0.0001) {
+		t.Errorf("Expected %0.1f damage from %d hits, got %0.1f", expected, metrics.Hits, metrics.TotalDamage)
+	}
+}
+
+func TestDamageProfileVariation(t *testing.T) {
+	metrics := runDamageProfileSim(&proto.DamageProfile{HolyDtps: 100, CadenceSeconds: 2, DamageVariation: 0.5})
+	if metrics.Hits == 0 {
+		t.Fatalf("Expected the damage profile to hit")
+	}
+	// Each hit is randomly between 100 and 300, rather than exactly 200.
+	hits := float64(metrics.Hits)
+	if metrics.TotalDamage < 100*hits || metrics.TotalDamage > 300*hits || metrics.TotalDamage == 200*hits {
+		t.Errorf("Expected %d hits of 100 to 300 varied damage, got %0.1f total", metrics.Hits, metrics.TotalDamage)
+	}
+}
+
+func TestValidateDamageProfile(t *testing.T) {
+	valid := []*proto.DamageProfile{
+		nil,
+		{},
+		{PhysicalDtps: 100, CadenceSeconds: 2, CadenceVariation: 1, DamageVariation: 1},
+	}
+	for _, profile := range valid {
+		if err := validateDamageProfile(profile); err != nil {
+			t.Errorf("Expected %v to be valid, got %v", profile, err)
+		}
+	}
+
+	invalid := []*proto.DamageProfile{
+		{PhysicalDtps: -100},
+		{PhysicalDtps: 100, CadenceSeconds: -2},
+		{PhysicalDtps: 100, CadenceVariation: -1},
+		{PhysicalDtps: 100, DamageVariation: -0.5},
+		{PhysicalDtps: 100, DamageVariation: 1.5},
+	}
+	for _, profile := range invalid {
+		if err := validateDamageProfile(profile); err == nil {
+			t.Errorf("Expected %v to be rejected", profile)
+		}
+	}
+}
+
+func TestInvalidDamageProfileReturnsError(t *testing.T) {
+	player := &proto.Player{
+		Name:          "Caster",
+		Class:         proto.Class_ClassShaman,
+		Spec:          &proto.Player_ElementalShaman{},
+		DamageProfile: &proto.DamageProfile{HolyDtps: 100, DamageVariation: 2},
+	}
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid:       SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter:  MakeSingleTargetEncounter(60, 0),
+		SimOptions: &proto.SimOptions{Iterations: 1, IsTest: true},
+	})
+	if result.ErrorResult == "" {
+		t.Errorf("Expected an invalid damage profile to return an error")
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/wowsims/sod/sim/core/proto"
@@ -15,6 +16,7 @@ var rwMutex = sync.RWMutex{}
 var ItemsByID = map[int32]Item{}
 var RandomSuffixesByID = map[int32]RandomSuffix{}
 var EnchantsByEffectID = map[int32]Enchant{}
+var RunesByID = map[int32]Rune{}
 
 func addToDatabase(newDB *proto.SimDatabase) {
 	for _, v := range newDB.Items {
@@ -42,7 +44,11 @@ func addToDatabase(newDB *proto.SimDatabase) {
 	}
 
 	for _, v := range newDB.Runes {
-		fmt.Println(RuneFromProto(v))
+		rwMutex.Lock()
+		if _, ok := RunesByID[v.Id]; !ok {
+			RunesByID[v.Id] = RuneFromProto(v)
+		}
+		rwMutex.Unlock()
 	}
 }
 
@@ -67,6 +73,16 @@ type Item struct {
 	SetName      string // Empty string if not part of a set.
 	SetID        int32  // 0 if not part of a set.
 	WeaponSkills stats.WeaponSkills
+	Phase        int32
+
+	// Special effects described by the item tooltip.
+	EffectTypes []proto.ItemEffectType
+
+	// Used to filter item rankings.
+	Sources             []*proto.SimItemSource
+	Faction             proto.Faction // Unknown if both factions can use the item.
+	RandomSuffixOptions []int32
+	Unique              bool
 
 	// Modified for each instance of the item.
 	RandomSuffix RandomSuffix
@@ -95,6 +111,13 @@ func ItemFromProto(pData *proto.SimItem) Item {
 		SetName:          pData.SetName,
 		SetID:            pData.SetId,
 		WeaponSkills:     stats.WeaponSkillsFloatArray(pData.WeaponSkills),
+		Phase:            pData.Phase,
+		EffectTypes:      pData.EffectTypes,
+
+		Sources:             pData.Sources,
+		Faction:             pData.Faction,
+		RandomSuffixOptions: pData.RandomSuffixOptions,
+		Unique:              pData.Unique,
 	}
 }
 
@@ -125,22 +148,61 @@ func RandomSuffixFromProto(pData *proto.ItemRandomSuffix) RandomSuffix {
 type Enchant struct {
 	EffectID int32 // Used by UI to apply effect to tooltip
 	Stats    stats.Stats
+
+	Type        proto.ItemType
+	ExtraTypes  []proto.ItemType
+	EnchantType proto.EnchantType
 }
 
 func EnchantFromProto(pData *proto.SimEnchant) Enchant {
 	return Enchant{
-		EffectID: pData.EffectId,
-		Stats:    stats.FromFloatArray(pData.Stats),
+		EffectID:    pData.EffectId,
+		Stats:       stats.FromFloatArray(pData.Stats),
+		Type:        pData.Type,
+		ExtraTypes:  pData.ExtraTypes,
+		EnchantType: pData.EnchantType,
+	}
+}
+
+// Whether the enchant can be applied to the item.
+// See enchantAppliesToItem in proto_utils/utils.ts.
+func (enchant Enchant) AppliesToItem(item Item) bool {
+	if enchant.Type != item.Type && !slices.Contains(enchant.ExtraTypes, item.Type) {
+		return false
+	}
+	if enchant.EnchantType == proto.EnchantType_EnchantTypeTwoHand && item.HandType != proto.HandType_HandTypeTwoHand {
+		return false
+	}
+	if (enchant.EnchantType == proto.EnchantType_EnchantTypeShield) != (item.WeaponType == proto.WeaponType_WeaponTypeShield) {
+		return false
+	}
+	if enchant.EnchantType == proto.EnchantType_EnchantTypeStaff && item.WeaponType != proto.WeaponType_WeaponTypeStaff {
+		return false
+	}
+	if item.WeaponType == proto.WeaponType_WeaponTypeOffHand {
+		return false
+	}
+	if item.Type == proto.ItemType_ItemTypeRanged {
+		return slices.Contains([]proto.RangedWeaponType{
+			proto.RangedWeaponType_RangedWeaponTypeBow,
+			proto.RangedWeaponType_RangedWeaponTypeCrossbow,
+			proto.RangedWeaponType_RangedWeaponTypeGun,
+		}, item.RangedWeaponType)
 	}
+	return true
 }
 
 type Rune struct {
-	ID int32
+	ID            int32
+	RequiresLevel int32
+	Type          proto.ItemType
 }
 
 func RuneFromProto(pData *proto.SimRune) Rune {
 	return Rune{
-		ID: pData.Id,
+		ID:            pData.Id,
+		RequiresLevel: pData.RequiresLevel,
+		Type:          pData.Type,
 	}
 }
 
@@ -17,6 +17,7 @@ func init() {
 		Items:          make([]*proto.SimItem, len(db.Items)),
 		Enchants:       make([]*proto.SimEnchant, len(db.Enchants)),
 		RandomSuffixes: make([]*proto.ItemRandomSuffix, len(db.RandomSuffixes)),
+		Runes:          make([]*proto.SimRune, len(db.Runes)),
 	}
 
 	for i, item := range db.Items {
@@ -37,13 +38,23 @@ func init() {
 			SetName:          item.SetName,
 			SetId:            item.SetId,
 			WeaponSkills:     item.WeaponSkills,
+			Phase:            item.Phase,
+			EffectTypes:      item.EffectTypes,
+
+			Sources:             MapSlice(item.Sources, simItemSourceFromUI),
+			Faction:             factionFromRestriction(item.FactionRestriction),
+			RandomSuffixOptions: item.RandomSuffixOptions,
+			Unique:              item.Unique,
 		}
 	}
 
 	for i, enchant := range db.Enchants {
 		simDB.Enchants[i] = &proto.SimEnchant{
-			EffectId: enchant.EffectId,
-			Stats:    enchant.Stats,
+			EffectId:    enchant.EffectId,
+			Stats:       enchant.Stats,
+			Type:        enchant.Type,
+			ExtraTypes:  enchant.ExtraTypes,
+			EnchantType: enchant.EnchantType,
 		}
 	}
 
@@ -55,5 +66,40 @@ func init() {
 		}
 	}
 
+	for i, dbRune := range db.Runes {
+		simDB.Runes[i] = &proto.SimRune{
+			Id:            dbRune.Id,
+			RequiresLevel: dbRune.RequiresLevel,
+			Type:          dbRune.Type,
+		}
+	}
+
 	addToDatabase(simDB)
+	addItemEffectDefinitionsToDatabase(db.ItemEffects)
+}
+
+func simItemSourceFromUI(source *proto.UIItemSource) *proto.SimItemSource {
+	switch src := source.Source.(type) {
+	case *proto.UIItemSource_Crafted:
+		return &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeCrafted}
+	case *proto.UIItemSource_Drop:
+		return &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeDrop, ZoneId: src.Drop.ZoneId}
+	case *proto.UIItemSource_Quest:
+		return &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeQuest}
+	case *proto.UIItemSource_SoldBy:
+		return &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeSoldBy, ZoneId: src.SoldBy.ZoneId}
+	case *proto.UIItemSource_Rep:
+		return &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeRep}
+	}
+	return &proto.SimItemSource{}
+}
+
+func factionFromRestriction(restriction proto.UIItem_FactionRestriction) proto.Faction {
+	switch restriction {
+	case proto.UIItem_FACTION_RESTRICTION_ALLIANCE_ONLY:
+		return proto.Faction_Alliance
+	case proto.UIItem_FACTION_RESTRICTION_HORDE_ONLY:
+		return proto.Faction_Horde
+	}
+	return proto.Faction_Unknown
 }
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Adds fake items, enchants, random suffixes and runes to the database, and
+// removes them again when the test finishes.
+func addToTestDatabase(t *testing.T, db *proto.SimDatabase) {
+	t.Helper()
+	addToDatabase(db)
+	t.Cleanup(func() {
+		rwMutex.Lock()
+		defer rwMutex.Unlock()
+		for _, item := range db.Items {
+			delete(ItemsByID, item.Id)
+		}
+		for _, suffix := range db.RandomSuffixes {
+			delete(RandomSuffixesByID, suffix.Id)
+		}
+		for _, enchant := range db.Enchants {
+			delete(EnchantsByEffectID, enchant.EffectId)
+		}
+		for _, dbRune := range db.Runes {
+			delete(RunesByID, dbRune.Id)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"math"
 	"slices"
 	"time"
 
@@ -87,8 +88,10 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 
 	// Apply extra debuffs from raid.
 	if raidProto.Debuffs != nil && len(env.Encounter.TargetUnits) > 0 {
+		// Debuffs provided by players come from their casts instead.
+		debuffs := env.Raid.clearProvidedDebuffs(raidProto.Debuffs)
 		for targetIdx, targetUnit := range env.Encounter.TargetUnits {
-			applyDebuffEffects(targetUnit, targetIdx, raidProto.Debuffs, raidProto)
+			applyDebuffEffects(targetUnit, targetIdx, debuffs, raidProto)
 		}
 	}
 
@@ -173,7 +176,14 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 			}
 			playerProto := partyProto.Players[playerIdx]
 			char := player.GetCharacter()
+			char.validateLevel(playerProto.Rotation)
 			char.Rotation = char.newAPLRotation(playerProto.Rotation)
+
+			for _, pet := range char.Pets {
+				if petRotation := playerProto.PetRotations[pet.Name]; petRotation != nil && petRotation.Type == proto.APLRotation_TypeAPL {
+					pet.Rotation = pet.newAPLRotation(petRotation)
+				}
+			}
 		}
 	}
 
@@ -269,6 +279,15 @@ func (env *Environment) reset(sim *Simulation) {
 
 // The maximum possible duration for any iteration.
 func (env *Environment) GetMaxDuration() time.Duration {
+	switch env.Encounter.DurationDistribution {
+	case proto.DurationDistribution_DurationEmpirical:
+		return slices.Max(env.Encounter.KillTimes)
+	case proto.DurationDistribution_DurationNormal:
+		return env.BaseDuration + maxDurationStdDevs*env.DurationVariation
+	case proto.DurationDistribution_DurationLognormal:
+		mu, sigma := lognormalDurationParams(env.BaseDuration, env.DurationVariation)
+		return DurationFromSeconds(math.Exp(mu + maxDurationStdDevs*sigma))
+	}
 	return env.BaseDuration + env.DurationVariation
 }
 
@@ -346,6 +365,14 @@ func (env *Environment) GetUnit(ref *proto.UnitReference, contextUnit *Unit) *Un
 			return nil
 		}
 		return contextUnit.CurrentTarget
+	case proto.UnitReference_Owner:
+		if contextUnit == nil || contextUnit.Type != PetUnit {
+			return nil
+		}
+		if petAgent, ok := env.Raid.GetPlayerFromUnit(contextUnit).(PetAgent); ok {
+			return &petAgent.GetPet().Owner.Unit
+		}
+		return nil
 	}
 
 	return nil
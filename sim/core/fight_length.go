@@ -0,0 +1,154 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Normal and lognormal fight lengths are truncated at this many standard
+// deviations above the mean, or of the log above the log-mean, so that
+// GetMaxDuration stays finite. This cuts off about 0.003% of draws.
+const maxDurationStdDevs = 4
+
+func validateDurationDistribution(options *proto.Encounter) error {
+	if options.UseHealth {
+		return nil
+	}
+
+	switch options.DurationDistribution {
+	case proto.DurationDistribution_DurationEmpirical:
+		if len(options.KillTimes) == 0 {
+			return fmt.Errorf("empirical duration distribution requires at least 1 kill time")
+		}
+		for _, killTime := range options.KillTimes {
+			if killTime <= 0 {
+				return fmt.Errorf("kill times must be positive, got %0.1fs", killTime)
+			}
+		}
+	case proto.DurationDistribution_DurationNormal, proto.DurationDistribution_DurationLognormal:
+		if options.Duration <= 0 {
+			return fmt.Errorf("%s requires a positive duration, got %0.1fs", options.DurationDistribution, options.Duration)
+		}
+		if options.DurationVariation < 0 {
+			return fmt.Errorf("%s requires a non-negative duration variation, got %0.1fs", options.DurationDistribution, options.DurationVariation)
+		}
+	}
+	return nil
+}
+
+// The encounter options are checked by validateDurationDistribution when the
+// request comes in.
+func (encounter *Encounter) initDurationDistribution(options *proto.Encounter) {
+	encounter.DurationDistribution = options.DurationDistribution
+
+	if encounter.DurationDistribution == proto.DurationDistribution_DurationEmpirical && len(options.KillTimes) > 0 {
+		var total time.Duration
+		for _, killTime := range options.KillTimes {
+			encounter.KillTimes = append(encounter.KillTimes, DurationFromSeconds(killTime))
+			total += DurationFromSeconds(killTime)
+		}
+		// Other effects scale with the base duration, so use the mean kill time.
+		encounter.Duration = total / time.Duration(len(encounter.KillTimes))
+	}
+}
+
+// Parameters of the lognormal distribution, in log seconds, whose mean and
+// standard deviation are the given duration and variation.
+func lognormalDurationParams(duration time.Duration, variation time.Duration) (mu float64, sigma float64) {
+	mean := duration.Seconds()
+	stdev := variation.Seconds()
+	mu = math.Log(mean * mean / math.Sqrt(mean*mean+stdev*stdev))
+	sigma = math.Sqrt(math.Log(1 + stdev*stdev/(mean*mean)))
+	return mu, sigma
+}
+
+// Whether iterations can have different fight lengths.
+func (encounter *Encounter) durationVaries() bool {
+	if encounter.EndFightAtHealth > 0 {
+		return false
+	}
+
+	switch encounter.DurationDistribution {
+	case proto.DurationDistribution_DurationEmpirical:
+		return slices.ContainsFunc(encounter.KillTimes, func(killTime time.Duration) bool {
+			return killTime != encounter.KillTimes[0]
+		})
+	default:
+		// Uniform, normal and lognormal fight lengths only vary with a variation.
+		return encounter.DurationVariation != 0
+	}
+}
+
+// Draws the fight length for the next iteration.
+func (sim *Simulation) rollDuration() time.Duration {
+	switch sim.Encounter.DurationDistribution {
+	case proto.DurationDistribution_DurationEmpirical:
+		killTimes := sim.Encounter.KillTimes
+		return killTimes[min(int(sim.RandomFloat("sim duration")*float64(len(killTimes))), len(killTimes)-1)]
+	case proto.DurationDistribution_DurationNormal:
+		duration := sim.BaseDuration + time.Duration(sim.RandomNormFloat("sim duration")*float64(sim.DurationVariation))
+		return max(min(duration, sim.GetMaxDuration()), time.Second)
+	case proto.DurationDistribution_DurationLognormal:
+		mu, sigma := lognormalDurationParams(sim.BaseDuration, sim.DurationVariation)
+		duration := DurationFromSeconds(math.Exp(mu + sigma*sim.RandomNormFloat("sim duration")))
+		return max(min(duration, sim.GetMaxDuration()), time.Second)
+	}
+
+	if sim.DurationVariation == 0 {
+		return sim.BaseDuration
+	}
+	variation := sim.DurationVariation * 2
+	return sim.BaseDuration + time.Duration(sim.RandomFloat("sim duration")*float64(variation)) - sim.DurationVariation
+}
+
+// Raid DPS of each iteration, binned by its fight length.
+type fightLengthMetrics struct {
+	binDuration time.Duration
+	bins        map[int]*aggregator
+}
+
+func newFightLengthMetrics(binSeconds float64) *fightLengthMetrics {
+	if binSeconds <= 0 {
+		binSeconds = 10
+	}
+	return &fightLengthMetrics{
+		binDuration: DurationFromSeconds(binSeconds),
+		bins:        make(map[int]*aggregator),
+	}
+}
+
+func (metrics *fightLengthMetrics) add(duration time.Duration, dps float64) {
+	binIdx := int(duration / metrics.binDuration)
+	bin, ok := metrics.bins[binIdx]
+	if !ok {
+		bin = &aggregator{}
+		metrics.bins[binIdx] = bin
+	}
+	bin.add(dps)
+}
+
+func (metrics *fightLengthMetrics) ToProto() []*proto.FightLengthBin {
+	binIdxs := make([]int, 0, len(metrics.bins))
+	for binIdx := range metrics.bins {
+		binIdxs = append(binIdxs, binIdx)
+	}
+	slices.Sort(binIdxs)
+
+	protoBins := make([]*proto.FightLengthBin, 0, len(binIdxs))
+	for _, binIdx := range binIdxs {
+		bin := metrics.bins[binIdx]
+		mean, stdev := bin.meanAndStdDevOrZero()
+		protoBins = append(protoBins, &proto.FightLengthBin{
+			MinSeconds: (metrics.binDuration * time.Duration(binIdx)).Seconds(),
+			MaxSeconds: (metrics.binDuration * time.Duration(binIdx+1)).Seconds(),
+			Iterations: int32(bin.n),
+			DpsAvg:     mean,
+			DpsStdev:   stdev,
+		})
+	}
+	return protoBins
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestFightLengthMetricsToProto(t *testing.T) {
+	metrics := newFightLengthMetrics(30)
+	metrics.add(time.Second*95, 1000)
+	metrics.add(time.Second*100, 1200)
+	metrics.add(time.Second*35, 800)
+
+	bins := metrics.ToProto()
+	if len(bins) != 2 {
+		t.Fatalf("Expected 2 bins, got %d", len(bins))
+	}
+	if bins[0].MinSeconds != 30 || bins[0].MaxSeconds != 60 || bins[0].Iterations != 1 || bins[0].DpsAvg != 800 || bins[0].DpsStdev != 0 {
+		t.Errorf("Unexpected first bin: %v", bins[0])
+	}
+	if bins[1].MinSeconds != 90 || bins[1].MaxSeconds != 120 || bins[1].Iterations != 2 {
+		t.Errorf("Unexpected second bin: %v", bins[1])
+	}
+	if !WithinToleranceFloat64(1100, bins[1].DpsAvg, 0.0001) || !WithinToleranceFloat64(100, bins[1].DpsStdev, 0.0001) {
+		t.Errorf("Expected 1100 +/- 100 DPS in the second bin, got %0.1f +/- %0.1f", bins[1].DpsAvg, bins[1].DpsStdev)
+	}
+}
+
+func TestEmpiricalDurationDistribution(t *testing.T) {
+	encounter := NewEncounter(&proto.Encounter{
+		Duration:             180,
+		DurationDistribution: proto.DurationDistribution_DurationEmpirical,
+		KillTimes:            []float64{60, 90, 150},
+	})
+
+	if encounter.Duration != time.Second*100 {
+		t.Errorf("Expected the base duration to be the mean kill time, got %s", encounter.Duration)
+	}
+	if !encounter.durationVaries() {
+		t.Errorf("Expected an empirical distribution to vary the fight length")
+	}
+}
+
+func TestDurationVaries(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   *proto.Encounter
+		expected bool
+	}{
+		{name: "Uniform", config: &proto.Encounter{Duration: 180, DurationVariation: 20}, expected: true},
+		{name: "Fixed", config: &proto.Encounter{Duration: 180}, expected: false},
+		{name: "Normal", config: &proto.Encounter{Duration: 180, DurationVariation: 20, DurationDistribution: proto.DurationDistribution_DurationNormal}, expected: true},
+		{name: "Normal without variation", config: &proto.Encounter{Duration: 180, DurationDistribution: proto.DurationDistribution_DurationNormal}, expected: false},
+		{name: "Lognormal without variation", config: &proto.Encounter{Duration: 180, DurationDistribution: proto.DurationDistribution_DurationLognormal}, expected: false},
+		{name: "Single kill time", config: &proto.Encounter{Duration: 180, DurationDistribution: proto.DurationDistribution_DurationEmpirical, KillTimes: []float64{90, 90}}, expected: false},
+	}
+
+	for _, c := range cases {
+		if encounter := NewEncounter(c.config); encounter.durationVaries() != c.expected {
+			t.Errorf("%s: Expected durationVaries() to be %t", c.name, c.expected)
+		}
+	}
+}
+
+func TestValidateDurationDistribution(t *testing.T) {
+	cases := []struct {
+		name   string
+		config *proto.Encounter
+		valid  bool
+	}{
+		{name: "Uniform", config: &proto.Encounter{Duration: 180, DurationVariation: 20}, valid: true},
+		{name: "Kill times", config: &proto.Encounter{DurationDistribution: proto.DurationDistribution_DurationEmpirical, KillTimes: []float64{60, 90}}, valid: true},
+		{name: "No kill times", config: &proto.Encounter{DurationDistribution: proto.DurationDistribution_DurationEmpirical}, valid: false},
+		{name: "Zero kill time", config: &proto.Encounter{DurationDistribution: proto.DurationDistribution_DurationEmpirical, KillTimes: []float64{60, 0}}, valid: false},
+		{name: "Lognormal", config: &proto.Encounter{Duration: 180, DurationVariation: 20, DurationDistribution: proto.DurationDistribution_DurationLognormal}, valid: true},
+		{name: "Lognormal without duration", config: &proto.Encounter{DurationDistribution: proto.DurationDistribution_DurationLognormal}, valid: false},
+		{name: "Normal with negative variation", config: &proto.Encounter{Duration: 180, DurationVariation: -20, DurationDistribution: proto.DurationDistribution_DurationNormal}, valid: false},
+		{name: "Health based", config: &proto.Encounter{UseHealth: true, DurationDistribution: proto.DurationDistribution_DurationEmpirical}, valid: true},
+	}
+
+	for _, c := range cases {
+		if err := validateDurationDistribution(c.config); (err == nil) != c.valid {
+			t.Errorf("%s: Expected valid to be %t, got error %v", c.name, c.valid, err)
+		}
+	}
+}
+
+func TestLognormalDurationMoments(t *testing.T) {
+	mu, sigma := lognormalDurationParams(time.Second*180, time.Second*30)
+
+	// Mean exp(mu + sigma^2 / 2) and variance (exp(sigma^2) - 1) * exp(2mu + sigma^2).
+	mean := math.Exp(mu + sigma*sigma/2)
+	stdev := math.Sqrt((math.Exp(sigma*sigma) - 1) * math.Exp(2*mu+sigma*sigma))
+	if !WithinToleranceFloat64(180, mean, 0.0001) || !WithinToleranceFloat64(30, stdev, 0.0001) {
+		t.Errorf("Expected a 180s +/- 30s lognormal fight length, got %0.2fs +/- %0.2fs", mean, stdev)
+	}
+}
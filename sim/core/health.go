@@ -45,7 +45,8 @@ func (hb *healthBar) CurrentHealthPercent() float64 {
 	return hb.currentHealth / hb.unit.stats[stats.Health]
 }
 
-func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *ResourceMetrics) {
+// Returns the amount of health actually gained, i.e. excluding overhealing.
+func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *ResourceMetrics) float64 {
 	if amount < 0 {
 		panic("Trying to gain negative health!")
 	}
@@ -59,6 +60,7 @@ func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *Resour
 	}
 
 	hb.currentHealth = newHealth
+	return newHealth - oldHealth
 }
 
 func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
@@ -89,14 +91,14 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 
 var ChanceOfDeathAuraLabel = "Chance of Death"
 
-func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel) {
+func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel, damageProfile *proto.DamageProfile) {
 	character.Unit.Metrics.isTanking = false
 	for _, target := range character.Env.Encounter.TargetUnits {
 		if target.CurrentTarget == &character.Unit {
 			character.Unit.Metrics.isTanking = true
 		}
 	}
-	if !character.Unit.Metrics.isTanking {
+	if !character.Unit.Metrics.isTanking && !damageProfileIsEnabled(damageProfile) {
 		return
 	}
 
@@ -144,15 +146,11 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 }
 
 func (character *Character) applyHealingModel(healingModel *proto.HealingModel) {
-	// Store variance parameters for healing cadence. Note that low rolls on
-	// cadence are special cased here so that the model is still well-behaved
-	// when CadenceVariation exceeds CadenceSeconds.
 	medianCadence := healingModel.CadenceSeconds
 	if medianCadence == 0 {
 		medianCadence = 2.0
 	}
-	minCadence := max(0.0, medianCadence-healingModel.CadenceVariation)
-	cadenceVariationLow := medianCadence - minCadence
+	rollCadence := newCadenceRoller("Healing", medianCadence, healingModel.CadenceVariation)
 
 	healthMetrics := character.NewHealthMetrics(ActionID{OtherID: proto.OtherAction_OtherActionHealingModel})
 
@@ -184,17 +182,8 @@ func (character *Character) applyHealingModel(healingModel *proto.HealingModel)
 				willOfTheNecropolisAura.Deactivate(sim)
 			}
 
-			// Random roll for time to next heal. In the case where CadenceVariation exceeds CadenceSeconds, then
-			// CadenceSeconds is treated as the median, with two separate uniform distributions to the left and right
-			// of it.
-			signRoll := sim.RandomFloat("Healing Cadence Variation Sign")
-			magnitudeRoll := sim.RandomFloat("Healing Cadence Variation Magnitude")
-
-			if signRoll < 0.5 {
-				timeToNextHeal = DurationFromSeconds(minCadence + magnitudeRoll*cadenceVariationLow)
-			} else {
-				timeToNextHeal = DurationFromSeconds(medianCadence + magnitudeRoll*healingModel.CadenceVariation)
-			}
+			// Random roll for time to next heal.
+			timeToNextHeal = rollCadence(sim)
 
 			// Refresh action
 			pa.NextActionAt = sim.CurrentTime + timeToNextHeal
@@ -205,6 +194,27 @@ func (character *Character) applyHealingModel(healingModel *proto.HealingModel)
 	})
 }
 
+// Returns a function rolling random intervals around a median cadence. Low rolls
+// are special cased so that the model is still well-behaved when the variation
+// exceeds the cadence: the cadence is then treated as the median, with two
+// separate uniform distributions to the left and right of it.
+func newCadenceRoller(label string, medianCadence float64, variation float64) func(sim *Simulation) time.Duration {
+	minCadence := max(0.0, medianCadence-variation)
+	variationLow := medianCadence - minCadence
+	signLabel := label + " Cadence Variation Sign"
+	magnitudeLabel := label + " Cadence Variation Magnitude"
+
+	return func(sim *Simulation) time.Duration {
+		signRoll := sim.RandomFloat(signLabel)
+		magnitudeRoll := sim.RandomFloat(magnitudeLabel)
+
+		if signRoll < 0.5 {
+			return DurationFromSeconds(minCadence + magnitudeRoll*variationLow)
+		}
+		return DurationFromSeconds(medianCadence + magnitudeRoll*variation)
+	}
+}
+
 func (character *Character) GetPresimOptions(playerConfig *proto.Player) *PresimOptions {
 	healingModel := playerConfig.HealingModel
 	if healingModel == nil || healingModel.Hps != 0 || healingModel.CadenceSeconds == 0 {
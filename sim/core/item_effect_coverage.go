@@ -0,0 +1,102 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Returns the special effects of an item which have no sim implementation.
+// Items without a registered effect only contribute their stats.
+func UnimplementedItemEffectTypes(item Item) []proto.ItemEffectType {
+	if len(item.EffectTypes) == 0 || isItemEffectImplemented(item) {
+		return nil
+	}
+	return item.EffectTypes
+}
+
+// Item effects are registered as item effects, as weapon or enchant effects
+// under the item's ID, or as part of the bonuses of the item's set.
+func isItemEffectImplemented(item Item) bool {
+	if HasItemEffect(item.ID) || HasWeaponEffect(item.ID) || HasEnchantEffect(item.ID) {
+		return true
+	}
+	return item.SetName != "" && HasItemSet(item.SetID, item.SetName)
+}
+
+func newUnimplementedItemEffectProto(item Item) *proto.UnimplementedItemEffect {
+	effectTypes := UnimplementedItemEffectTypes(item)
+	if len(effectTypes) == 0 {
+		return nil
+	}
+	return &proto.UnimplementedItemEffect{
+		ItemId:      item.ID,
+		Name:        item.Name,
+		EffectTypes: effectTypes,
+	}
+}
+
+// Returns the equipped items whose effects are not implemented.
+func (character *Character) getUnimplementedItemEffects() []*proto.UnimplementedItemEffect {
+	var unimplemented []*proto.UnimplementedItemEffect
+	for _, item := range character.Equipment {
+		if item.ID == 0 {
+			continue
+		}
+		if effect := newUnimplementedItemEffectProto(item); effect != nil {
+			unimplemented = append(unimplemented, effect)
+		}
+	}
+	return unimplemented
+}
+
+// Cross-references every item in the database that has special effects against
+// the registered item effects, grouped by phase and item type.
+func ItemEffectCoverage(request *proto.ItemEffectCoverageRequest) *proto.ItemEffectCoverageResult {
+	type coverageKey struct {
+		phase    int32
+		itemType proto.ItemType
+	}
+	coverageByKey := make(map[coverageKey]*proto.ItemEffectCoverage)
+
+	for _, item := range ItemsByID {
+		if len(item.EffectTypes) == 0 {
+			continue
+		}
+		if len(request.Phases) > 0 && !slices.Contains(request.Phases, item.Phase) {
+			continue
+		}
+
+		key := coverageKey{phase: item.Phase, itemType: item.Type}
+		coverage, ok := coverageByKey[key]
+		if !ok {
+			coverage = &proto.ItemEffectCoverage{
+				Phase: item.Phase,
+				Type:  item.Type,
+			}
+			coverageByKey[key] = coverage
+		}
+
+		coverage.NumItemsWithEffects++
+		if effect := newUnimplementedItemEffectProto(item); effect != nil {
+			coverage.Unimplemented = append(coverage.Unimplemented, effect)
+		} else {
+			coverage.NumImplemented++
+		}
+	}
+
+	result := &proto.ItemEffectCoverageResult{}
+	for _, coverage := range coverageByKey {
+		slices.SortFunc(coverage.Unimplemented, func(a, b *proto.UnimplementedItemEffect) int {
+			return int(a.ItemId - b.ItemId)
+		})
+		result.Coverage = append(result.Coverage, coverage)
+	}
+	slices.SortFunc(result.Coverage, func(a, b *proto.ItemEffectCoverage) int {
+		if a.Phase != b.Phase {
+			return int(a.Phase - b.Phase)
+		}
+		return int(a.Type - b.Type)
+	})
+	return result
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestItemEffectCoverage(t *testing.T) {
+	const testPhase = 99
+	const (
+		itemEffectItem = 900301 + iota
+		weaponEffectItem
+		setItem
+		unimplementedItem
+		plainItem
+	)
+	use := []proto.ItemEffectType{proto.ItemEffectType_ItemEffectTypeUse}
+	proc := []proto.ItemEffectType{proto.ItemEffectType_ItemEffectTypeProc}
+	addToTestDatabase(t, &proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: itemEffectItem, Type: proto.ItemType_ItemTypeTrinket, Phase: testPhase, EffectTypes: use},
+			{Id: weaponEffectItem, Type: proto.ItemType_ItemTypeWeapon, Phase: testPhase, EffectTypes: proc},
+			{Id: setItem, Type: proto.ItemType_ItemTypeChest, Phase: testPhase, EffectTypes: proc, SetName: "Test Coverage Set"},
+			{Id: unimplementedItem, Type: proto.ItemType_ItemTypeTrinket, Phase: testPhase, EffectTypes: use},
+			{Id: plainItem, Type: proto.ItemType_ItemTypeTrinket, Phase: testPhase},
+		},
+	})
+	NewItemEffect(itemEffectItem, func(_ Agent) {})
+	AddWeaponEffect(weaponEffectItem, func(_ Agent, _ proto.ItemSlot) {})
+	numSets := len(sets)
+	NewItemSet(ItemSet{Name: "Test Coverage Set"})
+	t.Cleanup(func() {
+		delete(itemEffects, itemEffectItem)
+		delete(weaponEffects, weaponEffectItem)
+		sets = sets[:numSets]
+	})
+
+	for _, item := range []int32{itemEffectItem, weaponEffectItem, setItem, plainItem} {
+		if effectTypes := UnimplementedItemEffectTypes(ItemsByID[item]); len(effectTypes) > 0 {
+			t.Errorf("Expected item %d to have no unimplemented effects, got %v", item, effectTypes)
+		}
+	}
+
+	result := ItemEffectCoverage(&proto.ItemEffectCoverageRequest{Phases: []int32{testPhase}})
+	var numItems, numImplemented int32
+	var unimplemented []int32
+	for _, coverage := range result.Coverage {
+		if coverage.Phase != testPhase {
+			t.Errorf("Expected only phase %d, got phase %d", testPhase, coverage.Phase)
+		}
+		numItems += coverage.NumItemsWithEffects
+		numImplemented += coverage.NumImplemented
+		for _, effect := range coverage.Unimplemented {
+			unimplemented = append(unimplemented, effect.ItemId)
+		}
+	}
+
+	if numItems != 4 || numImplemented != 3 {
+		t.Errorf("Expected 3 of 4 items with effects to be implemented, got %d of %d", numImplemented, numItems)
+	}
+	if !slices.Equal(unimplemented, []int32{unimplementedItem}) {
+		t.Errorf("Expected only item %d to be unimplemented, got %v", unimplementedItem, unimplemented)
+	}
+}
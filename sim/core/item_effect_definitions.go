@@ -0,0 +1,284 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Items and enchants whose registered effect came from a definition, rather
+// than code. Code registered later replaces these instead of conflicting.
+var itemEffectsFromDefinitions = map[int32]bool{}
+var enchantEffectsFromDefinitions = map[int32]bool{}
+
+// Loads item effect definitions in protojson format, e.g. from a user-supplied
+// file, replacing any existing effects for the same items and enchants. This
+// must be called before any sims are run.
+func LoadItemEffectDefinitions(data []byte) error {
+	definitions := &proto.ItemEffectDefinitions{}
+	if err := protojson.Unmarshal(data, definitions); err != nil {
+		return err
+	}
+
+	for _, definition := range definitions.Effects {
+		if err := validateItemEffectDefinition(definition); err != nil {
+			return err
+		}
+	}
+	for _, definition := range definitions.Effects {
+		registerItemEffectDefinition(definition, true)
+	}
+	return nil
+}
+
+// Registers effects from the database for items and enchants that don't have one yet.
+func addItemEffectDefinitionsToDatabase(definitions []*proto.ItemEffectDefinition) {
+	for _, definition := range definitions {
+		if err := validateItemEffectDefinition(definition); err != nil {
+			panic(err)
+		}
+		registerItemEffectDefinition(definition, false)
+	}
+}
+
+func validateItemEffectDefinition(definition *proto.ItemEffectDefinition) error {
+	if (definition.ItemId == 0) == (definition.EnchantEffectId == 0) {
+		return fmt.Errorf("item effect definition %q must have exactly one of item_id and enchant_effect_id", definition.Name)
+	}
+
+	var trigger *proto.ItemProcTrigger
+	switch effect := definition.Effect.(type) {
+	case *proto.ItemEffectDefinition_StatProc:
+		if effect.StatProc.DurationSeconds <= 0 {
+			return fmt.Errorf("stat proc %q must have a duration", definition.Name)
+		}
+		trigger = effect.StatProc.Trigger
+	case *proto.ItemEffectDefinition_OnUseStats:
+		if definition.ItemId == 0 {
+			return fmt.Errorf("on-use effect %q must be for an item", definition.Name)
+		}
+		if effect.OnUseStats.DurationSeconds <= 0 || effect.OnUseStats.CooldownSeconds <= 0 {
+			return fmt.Errorf("on-use effect %q must have a duration and cooldown", definition.Name)
+		}
+	case *proto.ItemEffectDefinition_DamageProc:
+		if effect.DamageProc.SpellId == 0 {
+			return fmt.Errorf("damage proc %q must have a spell_id", definition.Name)
+		}
+		if effect.DamageProc.MaxDamage < effect.DamageProc.MinDamage {
+			return fmt.Errorf("damage proc %q has max_damage below min_damage", definition.Name)
+		}
+		trigger = effect.DamageProc.Trigger
+	default:
+		return fmt.Errorf("item effect definition %q has no effect", definition.Name)
+	}
+
+	if trigger != nil && trigger.ProcChance <= 0 && trigger.Ppm <= 0 {
+		return fmt.Errorf("proc %q must have a proc_chance or ppm", definition.Name)
+	}
+	return nil
+}
+
+func registerItemEffectDefinition(definition *proto.ItemEffectDefinition, override bool) {
+	effect := newItemEffectFromDefinition(definition)
+
+	if definition.ItemId != 0 {
+		if HasItemEffect(definition.ItemId) && !override {
+			return
+		}
+		itemEffects[definition.ItemId] = effect
+		itemEffectsFromDefinitions[definition.ItemId] = true
+	} else {
+		if HasEnchantEffect(definition.EnchantEffectId) && !override {
+			return
+		}
+		enchantEffects[definition.EnchantEffectId] = effect
+		enchantEffectsFromDefinitions[definition.EnchantEffectId] = true
+	}
+}
+
+func newItemEffectFromDefinition(definition *proto.ItemEffectDefinition) ApplyEffect {
+	switch effect := definition.Effect.(type) {
+	case *proto.ItemEffectDefinition_StatProc:
+		return newStatProcEffect(definition, effect.StatProc)
+	case *proto.ItemEffectDefinition_OnUseStats:
+		return newOnUseStatEffect(definition, effect.OnUseStats)
+	case *proto.ItemEffectDefinition_DamageProc:
+		return newDamageProcEffect(definition, effect.DamageProc)
+	default:
+		panic(fmt.Sprintf("Unsupported item effect definition: %v", definition))
+	}
+}
+
+func itemEffectActionID(definition *proto.ItemEffectDefinition, spellID int32) ActionID {
+	if spellID != 0 {
+		return ActionID{SpellID: spellID}
+	}
+	if definition.ItemId != 0 {
+		return ActionID{ItemID: definition.ItemId}
+	}
+	return ActionID{SpellID: definition.EnchantEffectId}
+}
+
+func itemEffectLabel(definition *proto.ItemEffectDefinition) string {
+	if definition.Name != "" {
+		return definition.Name
+	}
+	if definition.ItemId != 0 {
+		return "Item-" + strconv.Itoa(int(definition.ItemId))
+	}
+	return "Enchant-" + strconv.Itoa(int(definition.EnchantEffectId))
+}
+
+// Applies a ProcTrigger for the given definition, calling handler on each proc.
+func makeItemProcTrigger(character *Character, definition *proto.ItemEffectDefinition, trigger *proto.ItemProcTrigger, handler ProcHandler) *Aura {
+	config := ProcTrigger{
+		Name:       itemEffectLabel(definition),
+		ActionID:   itemEffectActionID(definition, 0),
+		Callback:   CallbackOnSpellHitDealt,
+		Outcome:    OutcomeLanded,
+		ProcChance: trigger.ProcChance,
+		PPM:        trigger.Ppm,
+		ICD:        DurationFromSeconds(trigger.IcdSeconds),
+		Handler:    handler,
+	}
+
+	switch trigger.Source {
+	case proto.ItemProcSource_ItemProcSourceMelee:
+		config.ProcMask = ProcMaskMelee
+	case proto.ItemProcSource_ItemProcSourceRanged:
+		config.ProcMask = ProcMaskRanged
+	case proto.ItemProcSource_ItemProcSourceMeleeOrRanged:
+		config.ProcMask = ProcMaskMeleeOrRanged
+	case proto.ItemProcSource_ItemProcSourceSpell:
+		config.ProcMask = ProcMaskSpellDamage
+		config.Harmful = true
+	case proto.ItemProcSource_ItemProcSourceHealing:
+		config.ProcMask = ProcMaskSpellHealing
+		config.Callback = CallbackOnHealDealt
+	case proto.ItemProcSource_ItemProcSourceWeapon:
+		if definition.ItemId != 0 {
+			config.ProcMask = character.GetProcMaskForItem(definition.ItemId)
+		} else {
+			config.ProcMask = character.GetProcMaskForEnchant(definition.EnchantEffectId)
+		}
+		config.SpellFlagsExclude = SpellFlagSuppressWeaponProcs
+	case proto.ItemProcSource_ItemProcSourceDamageTaken:
+		config.ProcMask = ProcMaskMeleeOrRanged
+		config.Callback = CallbackOnSpellHitTaken
+	}
+
+	if trigger.CritOnly {
+		config.Outcome = OutcomeCrit
+	}
+	// PPM is only defined for weapon hits, so it takes precedence over a flat chance.
+	if config.PPM > 0 {
+		config.ProcChance = 0
+	}
+
+	return MakeProcTriggerAura(&character.Unit, config)
+}
+
+func newStatProcEffect(definition *proto.ItemEffectDefinition, statProc *proto.StatProcEffect) ApplyEffect {
+	bonus := stats.FromFloatArray(statProc.Stats)
+	duration := DurationFromSeconds(statProc.DurationSeconds)
+
+	return func(agent Agent) {
+		character := agent.GetCharacter()
+
+		procAura := character.NewTemporaryStatsAura(itemEffectLabel(definition)+" Proc", itemEffectActionID(definition, statProc.SpellId), bonus, duration)
+		triggerAura := makeItemProcTrigger(character, definition, statProc.Trigger, func(sim *Simulation, _ *Spell, _ *SpellResult) {
+			procAura.Activate(sim)
+		})
+		procAura.Icd = triggerAura.Icd
+	}
+}
+
+func newOnUseStatEffect(definition *proto.ItemEffectDefinition, onUse *proto.OnUseStatEffect) ApplyEffect {
+	duration := DurationFromSeconds(onUse.DurationSeconds)
+	cooldown := DurationFromSeconds(onUse.CooldownSeconds)
+
+	flags := SpellFlagNoOnCastComplete
+	sharedCDFunc := func(character *Character) Cooldown {
+		return Cooldown{}
+	}
+	switch onUse.SharedCooldown {
+	case proto.ItemSharedCooldown_ItemSharedCooldownOffensiveTrinket:
+		flags |= SpellFlagOffensiveEquipment
+		sharedCDFunc = func(character *Character) Cooldown {
+			return Cooldown{
+				Timer:    character.GetOffensiveTrinketCD(),
+				Duration: duration,
+			}
+		}
+	case proto.ItemSharedCooldown_ItemSharedCooldownDefensiveTrinket:
+		flags |= SpellFlagDefensiveEquipment
+		sharedCDFunc = func(character *Character) Cooldown {
+			return Cooldown{
+				Timer:    character.GetDefensiveTrinketCD(),
+				Duration: duration,
+			}
+		}
+	}
+
+	return MakeTemporaryStatsOnUseCDRegistration(
+		"ItemActive-"+strconv.Itoa(int(definition.ItemId)),
+		stats.FromFloatArray(onUse.Stats),
+		duration,
+		SpellConfig{
+			ActionID: ActionID{ItemID: definition.ItemId},
+			Flags:    flags,
+		},
+		func(character *Character) Cooldown {
+			return Cooldown{
+				Timer:    character.NewTimer(),
+				Duration: cooldown,
+			}
+		},
+		sharedCDFunc,
+	)
+}
+
+func newDamageProcEffect(definition *proto.ItemEffectDefinition, damageProc *proto.DamageProcEffect) ApplyEffect {
+	minDamage := damageProc.MinDamage
+	damageRange := damageProc.MaxDamage - damageProc.MinDamage
+	label := itemEffectLabel(definition)
+
+	return func(agent Agent) {
+		character := agent.GetCharacter()
+
+		procSpell := character.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: damageProc.SpellId},
+			SpellSchool: SpellSchoolFromProto(damageProc.School),
+			DefenseType: DefenseTypeMagic,
+			ProcMask:    ProcMaskEmpty,
+			Flags:       SpellFlagNoOnCastComplete | SpellFlagPassiveSpell,
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: damageProc.BonusCoefficient,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				damage := minDamage
+				if damageRange > 0 {
+					damage += sim.RandomFloat(label) * damageRange
+				}
+				if damageProc.CanCrit {
+					spell.CalcAndDealDamage(sim, target, damage, spell.OutcomeMagicHitAndCrit)
+				} else {
+					spell.CalcAndDealDamage(sim, target, damage, spell.OutcomeAlwaysHit)
+				}
+			},
+		})
+
+		makeItemProcTrigger(character, definition, damageProc.Trigger, func(sim *Simulation, _ *Spell, result *SpellResult) {
+			target := result.Target
+			if target.Type != EnemyUnit {
+				target = character.CurrentTarget
+			}
+			procSpell.Cast(sim, target)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestLoadItemEffectDefinitionsValidation(t *testing.T) {
+	invalid := map[string]string{
+		"no target":      `{"effects": [{"name": "Test", "statProc": {"trigger": {"procChance": 0.1}, "durationSeconds": 10}}]}`,
+		"no effect":      `{"effects": [{"name": "Test", "itemId": 999001}]}`,
+		"no proc chance": `{"effects": [{"name": "Test", "itemId": 999001, "statProc": {"trigger": {}, "durationSeconds": 10}}]}`,
+		"enchant on-use": `{"effects": [{"name": "Test", "enchantEffectId": 999001, "onUseStats": {"durationSeconds": 20, "cooldownSeconds": 120}}]}`,
+		"damage range":   `{"effects": [{"name": "Test", "itemId": 999001, "damageProc": {"trigger": {"ppm": 1}, "spellId": 1, "minDamage": 10, "maxDamage": 5}}]}`,
+	}
+
+	for name, data := range invalid {
+		if err := LoadItemEffectDefinitions([]byte(data)); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+	if HasItemEffect(999001) || HasEnchantEffect(999001) {
+		t.Errorf("Invalid definitions should not be registered")
+	}
+}
+
+func TestItemEffectDefinitionStatProc(t *testing.T) {
+	const itemID = 999010
+
+	bonus := stats.Stats{}
+	bonus[stats.SpellPower] = 100
+	statsJSON, _ := json.Marshal(bonus[:])
+	data := fmt.Sprintf(`{"effects": [{"name": "Test Proc", "itemId": %d, "statProc": {"trigger": {"source": "ItemProcSourceSpell", "procChance": 1}, "stats": %s, "durationSeconds": 10}}]}`, itemID, statsJSON)
+	if err := LoadItemEffectDefinitions([]byte(data)); err != nil {
+		t.Fatalf("Failed to load definitions: %s", err)
+	}
+
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:     "Caster",
+							Class:    proto.Class_ClassShaman,
+							Consumes: &proto.Consumes{},
+							Buffs:    &proto.IndividualBuffs{},
+							Spec:     &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{
+								Items: []*proto.ItemSpec{{Id: itemID}},
+							},
+							Database: &proto.SimDatabase{
+								Items: []*proto.SimItem{{Id: itemID, Name: "Test Trinket", Type: proto.ItemType_ItemTypeTrinket}},
+							},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	attack := registerTestAttack(&character.Unit, SpellSchoolFire)
+	sim.Reset()
+
+	procAura := character.GetAura("Test Proc Proc")
+	if procAura == nil {
+		t.Fatalf("Expected the definition to register a proc aura")
+	}
+	spellPower := character.GetStat(stats.SpellPower)
+
+	dealTestDamage(sim, attack, sim.Encounter.TargetUnits[0], 100)
+	if !procAura.IsActive() {
+		t.Fatalf("Expected a spell hit to trigger the proc")
+	}
+	if gain := character.GetStat(stats.SpellPower) - spellPower; gain != 100 {
+		t.Errorf("Expected the proc to give 100 spell power, got %0.1f", gain)
+	}
+
+	sim.advance(sim.CurrentTime + time.Second*11)
+	if procAura.IsActive() || character.GetStat(stats.SpellPower) != spellPower {
+		t.Errorf("Expected the proc and its spell power to fade after 10s")
+	}
+}
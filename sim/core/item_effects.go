@@ -56,11 +56,12 @@ func NewItemEffect(id int32, itemEffect ApplyEffect) {
 		}
 	}
 
-	if HasItemEffect(id) {
+	if HasItemEffect(id) && !itemEffectsFromDefinitions[id] {
 		panic(fmt.Sprintf("Cannot add multiple effects for one item: %d, %#v", id, itemEffect))
 	}
 
 	itemEffects[id] = itemEffect
+	delete(itemEffectsFromDefinitions, id)
 	if AddEffectsToTest {
 		itemEffectsForTest = append(itemEffectsForTest, id)
 	}
@@ -73,11 +74,12 @@ func NewEnchantEffect(id int32, enchantEffect ApplyEffect) {
 		}
 	}
 
-	if HasEnchantEffect(id) {
+	if HasEnchantEffect(id) && !enchantEffectsFromDefinitions[id] {
 		panic(fmt.Sprintf("Cannot add multiple effects for one enchant: %d, %#v", id, enchantEffect))
 	}
 
 	enchantEffects[id] = enchantEffect
+	delete(enchantEffectsFromDefinitions, id)
 }
 
 func AddWeaponEffect(id int32, weaponEffect ApplyWeaponEffect) {
@@ -0,0 +1,597 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+const (
+	defaultItemRankingsResults    = 10
+	defaultItemRankingsMaxSims    = 200
+	defaultItemRankingsIterations = 1000
+)
+
+// itemRanker ranks every item in the database which the player can equip, for
+// each slot. Most items are scored by EP from the request's stat weights. Items
+// with effects or implemented set bonuses can't be valued by stat weights, so
+// they're quick simmed in place of the equipped item instead. These sims use
+// the same seed and test-level RNG as a sim of the current gear, so their DPS
+// change has little noise, and is converted back to EP.
+type itemRanker struct {
+	// SingleRaidSimRunner used to sim one item.
+	SingleRaidSimRunner raidSimRunner
+	// Request used for these rankings.
+	Request *proto.ItemRankingsRequest
+
+	baseSettings *proto.RaidSimRequest
+	equipment    []*proto.ItemSpec // One per slot.
+	filters      *proto.ItemRankingsFilters
+	faction      proto.Faction
+
+	epValues []float64 // Per UnitStat.
+	dpsPerEP float64   // 0 if there's no way to convert sims to EP.
+
+	simBatchProgress
+}
+
+// An item which could go in one slot.
+type itemCandidate struct {
+	slot proto.ItemSlot
+	spec *proto.ItemSpec
+	ep   float64 // From stat weights.
+
+	simIdx int // Index of its sim, or -1 if not simmed.
+}
+
+func ItemRankings(ctx context.Context, request *proto.ItemRankingsRequest, progress chan *proto.ProgressMetrics) *proto.ItemRankingsResult {
+	ranker := &itemRanker{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+
+	result, err := ranker.Run(ctx, progress)
+	if err != nil {
+		result = &proto.ItemRankingsResult{
+			ErrorResult: err.Error(),
+		}
+	}
+
+	sendFinalProgress(progress, &proto.ProgressMetrics{
+		FinalItemRankingsResult: result,
+	})
+
+	return result
+}
+
+func (r *itemRanker) Run(ctx context.Context, progress chan *proto.ProgressMetrics) (result *proto.ItemRankingsResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.ItemRankingsResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if err := r.init(progress); err != nil {
+		return nil, err
+	}
+
+	candidatesBySlot := make([][]*itemCandidate, len(r.equipment))
+	for slot := range r.equipment {
+		candidatesBySlot[slot] = r.candidates(proto.ItemSlot(slot))
+	}
+
+	// The current gear is simmed first, and every other sim is compared to it.
+	requests := []*proto.RaidSimRequest{googleProto.Clone(r.baseSettings).(*proto.RaidSimRequest)}
+	maxSims := TernaryInt32(r.Request.MaxSims > 0, r.Request.MaxSims, defaultItemRankingsMaxSims)
+	canSim := !r.Request.EpOnly && r.dpsPerEP != 0
+
+	// Equipped items with effects or set bonuses are valued by simming the
+	// best item which stat weights can value in their place instead.
+	anchors := make([]*itemCandidate, len(r.equipment))
+	if canSim {
+		for slot, spec := range r.equipment {
+			if spec.Id == 0 || !needsItemSim(ItemsByID[spec.Id]) {
+				continue
+			}
+			for _, candidate := range candidatesBySlot[slot] {
+				if !needsItemSim(ItemsByID[candidate.spec.Id]) {
+					anchors[slot] = candidate
+					break
+				}
+			}
+			if anchors[slot] != nil && int32(len(requests)) < maxSims {
+				anchors[slot].simIdx = len(requests)
+				requests = append(requests, r.raidSimRequest(anchors[slot]))
+			}
+		}
+	}
+
+	// Candidates with effects or set bonuses are simmed in order of their EP,
+	// until the sim budget runs out.
+	if canSim {
+		var simmed []*itemCandidate
+		for _, candidates := range candidatesBySlot {
+			for _, candidate := range candidates {
+				if needsItemSim(ItemsByID[candidate.spec.Id]) {
+					simmed = append(simmed, candidate)
+				}
+			}
+		}
+		slices.SortStableFunc(simmed, func(a, b *itemCandidate) int {
+			return compareFloat64Desc(a.ep, b.ep)
+		})
+		for _, candidate := range simmed {
+			if int32(len(requests)) >= maxSims {
+				break
+			}
+			candidate.simIdx = len(requests)
+			requests = append(requests, r.raidSimRequest(candidate))
+		}
+	}
+
+	r.totalSims = int32(len(requests))
+	results, err := runSimBatch(ctx, r.SingleRaidSimRunner, requests, r.onSimDone)
+	if err != nil {
+		return nil, err
+	}
+	baseline := r.metric(results[0])
+
+	equippedEPs := make([]float64, len(r.equipment))
+	for slot, spec := range r.equipment {
+		if anchor := anchors[slot]; anchor != nil && anchor.simIdx != -1 {
+			equippedEPs[slot] = anchor.ep + (baseline-r.metric(results[anchor.simIdx]))/r.dpsPerEP
+		} else if spec.Id != 0 {
+			equippedEPs[slot] = r.itemEP(spec, proto.ItemSlot(slot))
+		}
+	}
+
+	numResults := TernaryInt32(r.Request.NumResults > 0, r.Request.NumResults, defaultItemRankingsResults)
+	result = &proto.ItemRankingsResult{
+		ItemsSimmed: int32(len(requests) - 1),
+	}
+	for slot, candidates := range candidatesBySlot {
+		slotRankings := &proto.SlotRankings{
+			Slot:       proto.ItemSlot(slot),
+			EquippedEp: equippedEPs[slot],
+		}
+
+		for _, candidate := range candidates {
+			ranking := &proto.ItemRanking{
+				Item: candidate.spec,
+			}
+			if candidate.simIdx != -1 {
+				ranking.Simmed = true
+				ranking.DpsGain = r.metric(results[candidate.simIdx]) - baseline
+				ranking.EpGain = ranking.DpsGain / r.dpsPerEP
+				ranking.Ep = equippedEPs[slot] + ranking.EpGain
+			} else {
+				ranking.Ep = candidate.ep
+				ranking.EpGain = candidate.ep - equippedEPs[slot]
+				if r.replacesOffHand(candidate) {
+					ranking.EpGain -= equippedEPs[proto.ItemSlot_ItemSlotOffHand]
+				}
+				ranking.DpsGain = ranking.EpGain * r.dpsPerEP
+			}
+
+			if ranking.EpGain > 0 {
+				slotRankings.Items = append(slotRankings.Items, ranking)
+			}
+		}
+
+		slices.SortStableFunc(slotRankings.Items, func(a, b *proto.ItemRanking) int {
+			return compareFloat64Desc(a.EpGain, b.EpGain)
+		})
+		if len(slotRankings.Items) > int(numResults) {
+			slotRankings.Items = slotRankings.Items[:numResults]
+		}
+		result.Slots = append(result.Slots, slotRankings)
+	}
+
+	return result, nil
+}
+
+func (r *itemRanker) init(progress chan *proto.ProgressMetrics) error {
+	if r.Request.Player == nil {
+		return errors.New("item rankings: missing player")
+	}
+
+	weights := r.Request.StatWeights.GetDps()
+	if r.Request.UseHps {
+		weights = r.Request.StatWeights.GetHps()
+	}
+	if weights.GetEpValues() == nil {
+		return errors.New("item rankings: missing stat weights")
+	}
+
+	r.epValues = make([]float64, stats.UnitStatsLen)
+	copy(r.epValues[:stats.Len], weights.EpValues.Stats)
+	copy(r.epValues[stats.Len:], weights.EpValues.PseudoStats)
+
+	// EP values are weights relative to the reference stat, so any stat with
+	// both gives the DPS of 1 EP.
+	for i, epValue := range weights.EpValues.Stats {
+		if epValue != 0 && i < len(weights.GetWeights().GetStats()) && weights.Weights.Stats[i] != 0 {
+			r.dpsPerEP = weights.Weights.Stats[i] / epValue
+			break
+		}
+	}
+
+	player := googleProto.Clone(r.Request.Player).(*proto.Player)
+	if player.Equipment == nil {
+		player.Equipment = &proto.EquipmentSpec{}
+	}
+	for len(player.Equipment.Items) <= int(proto.ItemSlot_ItemSlotRanged) {
+		player.Equipment.Items = append(player.Equipment.Items, &proto.ItemSpec{})
+	}
+	r.equipment = player.Equipment.Items
+
+	r.filters = r.Request.Filters
+	if r.filters == nil {
+		r.filters = &proto.ItemRankingsFilters{}
+	}
+	r.faction = r.filters.Faction
+	if r.faction == proto.Faction_Unknown {
+		r.faction = raceFaction(player.Race)
+	}
+
+	raidProto := SinglePlayerRaidProto(player, r.Request.PartyBuffs, r.Request.RaidBuffs, r.Request.Debuffs)
+	raidProto.Tanks = r.Request.Tanks
+
+	simOptions := &proto.SimOptions{}
+	if r.Request.SimOptions != nil {
+		simOptions = googleProto.Clone(r.Request.SimOptions).(*proto.SimOptions)
+	}
+	if simOptions.Iterations <= 0 {
+		simOptions.Iterations = defaultItemRankingsIterations
+	}
+	// Every item uses the same seed and test-level RNG, so that its sim lines
+	// up with the sim of the current gear.
+	pinRandomSeed(simOptions)
+	simOptions.IsTest = true
+	simOptions.TargetError = 0
+	simOptions.Debug = false
+	simOptions.DebugFirstIteration = false
+	simOptions.BuffContributions = false
+
+	r.baseSettings = &proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  r.Request.Encounter,
+		SimOptions: simOptions,
+	}
+
+	r.progress = progress
+	return nil
+}
+
+// Items which the player could put in the slot in place of the equipped item,
+// highest EP first.
+func (r *itemRanker) candidates(slot proto.ItemSlot) []*itemCandidate {
+	player := r.Request.Player
+	equipped := r.equipment[slot]
+
+	// Offhands can't be changed without also changing a two-hander.
+	if slot == proto.ItemSlot_ItemSlotOffHand && ItemsByID[r.equipment[proto.ItemSlot_ItemSlotMainHand].Id].HandType == proto.HandType_HandTypeTwoHand {
+		return nil
+	}
+
+	var candidates []*itemCandidate
+	for id, item := range ItemsByID {
+		if id == equipped.Id || !slices.Contains(eligibleSlotsForItem(item), slot) {
+			continue
+		}
+		if !canEquipItem(item, player.Class, player.Level, slot) || !r.matchesFilters(item) {
+			continue
+		}
+
+		candidate := &itemCandidate{
+			slot: slot,
+			spec: &proto.ItemSpec{
+				Id:           id,
+				RandomSuffix: r.bestRandomSuffix(item),
+				Enchant:      enchantForItem(equipped.Enchant, item),
+				Rune:         runeForItem(equipped.Rune, item),
+			},
+			simIdx: -1,
+		}
+		if !isValidEquipment(r.substitute(candidate)) {
+			continue
+		}
+		candidate.ep = r.itemEP(candidate.spec, slot)
+		candidates = append(candidates, candidate)
+	}
+
+	slices.SortFunc(candidates, func(a, b *itemCandidate) int {
+		if a.ep != b.ep {
+			return compareFloat64Desc(a.ep, b.ep)
+		}
+		return int(a.spec.Id - b.spec.Id)
+	})
+	return candidates
+}
+
+func (r *itemRanker) matchesFilters(item Item) bool {
+	if r.filters.Phase > 0 && item.Phase > r.filters.Phase {
+		return false
+	}
+	if item.Faction != proto.Faction_Unknown && item.Faction != r.faction {
+		return false
+	}
+	if r.filters.ExcludeRandomSuffix && len(item.RandomSuffixOptions) > 0 {
+		return false
+	}
+
+	// Items can be obtained from any of their sources.
+	return len(item.Sources) == 0 || slices.ContainsFunc(item.Sources, r.matchesSource)
+}
+
+func (r *itemRanker) matchesSource(source *proto.SimItemSource) bool {
+	if len(r.filters.Sources) > 0 && !slices.Contains(r.filters.Sources, source.Type) {
+		return false
+	}
+	if len(r.filters.ZoneIds) > 0 && source.Type == proto.ItemSourceType_ItemSourceTypeDrop && !slices.Contains(r.filters.ZoneIds, source.ZoneId) {
+		return false
+	}
+	return true
+}
+
+// The equipped enchant, if it can also be applied to the item.
+func enchantForItem(effectID int32, item Item) int32 {
+	if enchant, ok := EnchantsByEffectID[effectID]; ok && !enchant.AppliesToItem(item) {
+		return 0
+	}
+	return effectID
+}
+
+// The equipped rune, if it can also be engraved on the item.
+func runeForItem(runeID int32, item Item) int32 {
+	if knownRune, ok := RunesByID[runeID]; ok && knownRune.Type != item.Type {
+		return 0
+	}
+	return runeID
+}
+
+// Whether stat weights can't value the item, so it needs to be simmed.
+func needsItemSim(item Item) bool {
+	return HasItemEffect(item.ID) || (item.SetName != "" && HasItemSet(item.SetID, item.SetName))
+}
+
+// Whether the candidate is a two-hander which also replaces the offhand.
+func (r *itemRanker) replacesOffHand(candidate *itemCandidate) bool {
+	return candidate.slot == proto.ItemSlot_ItemSlotMainHand &&
+		ItemsByID[candidate.spec.Id].HandType == proto.HandType_HandTypeTwoHand &&
+		r.equipment[proto.ItemSlot_ItemSlotOffHand].Id != 0
+}
+
+// The player's equipment with the candidate in its slot.
+func (r *itemRanker) substitute(candidate *itemCandidate) *proto.EquipmentSpec {
+	items := slices.Clone(r.equipment)
+	items[candidate.slot] = candidate.spec
+	if r.replacesOffHand(candidate) {
+		items[proto.ItemSlot_ItemSlotOffHand] = &proto.ItemSpec{}
+	}
+	return &proto.EquipmentSpec{Items: items}
+}
+
+func (r *itemRanker) raidSimRequest(candidate *itemCandidate) *proto.RaidSimRequest {
+	request := googleProto.Clone(r.baseSettings).(*proto.RaidSimRequest)
+	request.Raid.Parties[0].Players[0].Equipment = googleProto.Clone(r.substitute(candidate)).(*proto.EquipmentSpec)
+	return request
+}
+
+func (r *itemRanker) metric(result *proto.RaidSimResult) float64 {
+	player := result.RaidMetrics.Parties[0].Players[0]
+	if r.Request.UseHps {
+		return player.Hps.Avg
+	}
+	return player.Dps.Avg
+}
+
+func (r *itemRanker) statsEP(itemStats stats.Stats) float64 {
+	ep := 0.0
+	for i, value := range itemStats {
+		ep += value * r.epValues[i]
+	}
+	return ep
+}
+
+// EP of an item in a slot, including its random suffix, enchant and weapon DPS.
+// See computeItemEP in player.ts.
+func (r *itemRanker) itemEP(spec *proto.ItemSpec, slot proto.ItemSlot) float64 {
+	item := NewItem(ItemSpec{ID: spec.Id, RandomSuffix: spec.RandomSuffix, Enchant: spec.Enchant})
+	ep := r.statsEP(item.Stats.Add(item.RandomSuffix.Stats).Add(item.Enchant.Stats))
+
+	if item.SwingSpeed > 0 {
+		weaponDps := (item.WeaponDamageMin + item.WeaponDamageMax) / 2 / item.SwingSpeed
+		switch slot {
+		case proto.ItemSlot_ItemSlotMainHand:
+			ep += weaponDps * r.epValues[stats.UnitStatFromPseudoStat(proto.PseudoStat_PseudoStatMainHandDps)]
+		case proto.ItemSlot_ItemSlotOffHand:
+			ep += weaponDps * r.epValues[stats.UnitStatFromPseudoStat(proto.PseudoStat_PseudoStatOffHandDps)]
+		case proto.ItemSlot_ItemSlotRanged:
+			ep += weaponDps * r.epValues[stats.UnitStatFromPseudoStat(proto.PseudoStat_PseudoStatRangedDps)]
+		}
+	}
+	return ep
+}
+
+// The random suffix with the highest EP, or 0 if the item has none.
+func (r *itemRanker) bestRandomSuffix(item Item) int32 {
+	bestSuffix, bestEP := int32(0), math.Inf(-1)
+	for _, id := range item.RandomSuffixOptions {
+		if suffix, ok := RandomSuffixesByID[id]; ok {
+			if ep := r.statsEP(suffix.Stats); ep > bestEP {
+				bestSuffix, bestEP = id, ep
+			}
+		}
+	}
+	return bestSuffix
+}
+
+func compareFloat64Desc(a, b float64) int {
+	if a > b {
+		return -1
+	} else if a < b {
+		return 1
+	}
+	return 0
+}
+
+// See canEquipItem in proto_utils/utils.ts.
+func canEquipItem(item Item, class proto.Class, level int32, slot proto.ItemSlot) bool {
+	if len(item.ClassAllowlist) > 0 && !slices.Contains(item.ClassAllowlist, class) {
+		return false
+	}
+	if level > 0 && item.RequiresLevel > level {
+		return false
+	}
+
+	switch item.Type {
+	case proto.ItemType_ItemTypeFinger, proto.ItemType_ItemTypeTrinket:
+		return true
+	case proto.ItemType_ItemTypeWeapon:
+		canUseTwoHand, ok := classToEligibleWeaponTypes[class][item.WeaponType]
+		if !ok {
+			return false
+		}
+		if item.HandType == proto.HandType_HandTypeTwoHand && (!canUseTwoHand || slot == proto.ItemSlot_ItemSlotOffHand) {
+			return false
+		}
+		if slot == proto.ItemSlot_ItemSlotMainHand && item.HandType == proto.HandType_HandTypeOffHand {
+			return false
+		}
+		if slot == proto.ItemSlot_ItemSlotOffHand {
+			if item.HandType == proto.HandType_HandTypeMainHand {
+				return false
+			}
+			if !slices.Contains(dualWieldClasses, class) && item.WeaponType != proto.WeaponType_WeaponTypeShield && item.WeaponType != proto.WeaponType_WeaponTypeOffHand {
+				return false
+			}
+		}
+		return true
+	case proto.ItemType_ItemTypeRanged:
+		return slices.Contains(classToEligibleRangedWeaponTypes[class], item.RangedWeaponType)
+	}
+
+	return classToMaxArmorType[class] >= item.ArmorType
+}
+
+var dualWieldClasses = []proto.Class{proto.Class_ClassHunter, proto.Class_ClassRogue, proto.Class_ClassShaman, proto.Class_ClassWarrior}
+
+var classToMaxArmorType = map[proto.Class]proto.ArmorType{
+	proto.Class_ClassDruid:   proto.ArmorType_ArmorTypeLeather,
+	proto.Class_ClassHunter:  proto.ArmorType_ArmorTypeMail,
+	proto.Class_ClassMage:    proto.ArmorType_ArmorTypeCloth,
+	proto.Class_ClassPaladin: proto.ArmorType_ArmorTypePlate,
+	proto.Class_ClassPriest:  proto.ArmorType_ArmorTypeCloth,
+	proto.Class_ClassRogue:   proto.ArmorType_ArmorTypeLeather,
+	proto.Class_ClassShaman:  proto.ArmorType_ArmorTypeMail,
+	proto.Class_ClassWarlock: proto.ArmorType_ArmorTypeCloth,
+	proto.Class_ClassWarrior: proto.ArmorType_ArmorTypePlate,
+}
+
+var classToEligibleRangedWeaponTypes = map[proto.Class][]proto.RangedWeaponType{
+	proto.Class_ClassDruid:   {proto.RangedWeaponType_RangedWeaponTypeIdol},
+	proto.Class_ClassHunter:  {proto.RangedWeaponType_RangedWeaponTypeBow, proto.RangedWeaponType_RangedWeaponTypeCrossbow, proto.RangedWeaponType_RangedWeaponTypeGun},
+	proto.Class_ClassMage:    {proto.RangedWeaponType_RangedWeaponTypeWand},
+	proto.Class_ClassPaladin: {proto.RangedWeaponType_RangedWeaponTypeLibram},
+	proto.Class_ClassPriest:  {proto.RangedWeaponType_RangedWeaponTypeWand},
+	proto.Class_ClassRogue: {
+		proto.RangedWeaponType_RangedWeaponTypeBow,
+		proto.RangedWeaponType_RangedWeaponTypeCrossbow,
+		proto.RangedWeaponType_RangedWeaponTypeGun,
+		proto.RangedWeaponType_RangedWeaponTypeThrown,
+	},
+	proto.Class_ClassShaman:  {proto.RangedWeaponType_RangedWeaponTypeTotem},
+	proto.Class_ClassWarlock: {proto.RangedWeaponType_RangedWeaponTypeWand},
+	proto.Class_ClassWarrior: {
+		proto.RangedWeaponType_RangedWeaponTypeBow,
+		proto.RangedWeaponType_RangedWeaponTypeCrossbow,
+		proto.RangedWeaponType_RangedWeaponTypeGun,
+		proto.RangedWeaponType_RangedWeaponTypeThrown,
+	},
+}
+
+// Weapon types each class can use, and whether they can use two-handers of
+// that type.
+var classToEligibleWeaponTypes = map[proto.Class]map[proto.WeaponType]bool{
+	proto.Class_ClassDruid: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypePolearm: true,
+	},
+	proto.Class_ClassHunter: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypePolearm: true,
+		proto.WeaponType_WeaponTypeSword:   true,
+		proto.WeaponType_WeaponTypeStaff:   true,
+	},
+	proto.Class_ClassMage: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypeSword:   false,
+	},
+	proto.Class_ClassPaladin: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypePolearm: true,
+		proto.WeaponType_WeaponTypeShield:  false,
+		proto.WeaponType_WeaponTypeSword:   true,
+	},
+	proto.Class_ClassPriest: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeMace:    false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+	},
+	proto.Class_ClassRogue: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeSword:   false,
+	},
+	proto.Class_ClassShaman: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeShield:  false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+	},
+	proto.Class_ClassWarlock: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypeSword:   false,
+	},
+	proto.Class_ClassWarrior: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypePolearm: true,
+		proto.WeaponType_WeaponTypeShield:  false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypeSword:   true,
+	},
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestItemRankingsByStatWeights(t *testing.T) {
+	const (
+		equippedNeck = 900001 + iota
+		betterNeck
+		hordeNeck
+		worseNeck
+	)
+	strength := func(value float64) []float64 {
+		itemStats := make([]float64, stats.Len)
+		itemStats[stats.Strength] = value
+		return itemStats
+	}
+	addToTestDatabase(t, &proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: equippedNeck, Type: proto.ItemType_ItemTypeNeck, Stats: strength(10)},
+			{Id: betterNeck, Type: proto.ItemType_ItemTypeNeck, Stats: strength(2000)},
+			{Id: hordeNeck, Type: proto.ItemType_ItemTypeNeck, Stats: strength(3000), Faction: proto.Faction_Horde},
+			{Id: worseNeck, Type: proto.ItemType_ItemTypeNeck, Stats: strength(5)},
+		},
+	})
+
+	equipment := make([]*proto.ItemSpec, proto.ItemSlot_ItemSlotRanged+1)
+	for i := range equipment {
+		equipment[i] = &proto.ItemSpec{}
+	}
+	equipment[proto.ItemSlot_ItemSlotNeck] = &proto.ItemSpec{Id: equippedNeck}
+
+	var numSims int32
+	ranker := &itemRanker{
+		SingleRaidSimRunner: fakeRaidSimRunner(func(_ *proto.RaidSimRequest) *proto.DistributionMetrics {
+			atomic.AddInt32(&numSims, 1)
+			return &proto.DistributionMetrics{Avg: 1000}
+		}),
+		Request: &proto.ItemRankingsRequest{
+			Player: &proto.Player{
+				Race:      proto.Race_RaceHuman,
+				Class:     proto.Class_ClassWarrior,
+				Level:     60,
+				Equipment: &proto.EquipmentSpec{Items: equipment},
+			},
+			StatWeights: &proto.StatWeightsResult{
+				Dps: &proto.StatWeightValues{
+					Weights:  &proto.UnitStats{Stats: strength(1)},
+					EpValues: &proto.UnitStats{Stats: strength(2)},
+				},
+			},
+			NumResults: 1,
+		},
+	}
+
+	result, err := ranker.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if result.ErrorResult != "" {
+		t.Fatalf("Run() failed: %s", result.ErrorResult)
+	}
+	if numSims != 1 || result.ItemsSimmed != 0 {
+		t.Errorf("Expected only the current gear to be simmed, got %d sims", numSims)
+	}
+
+	neck := result.Slots[proto.ItemSlot_ItemSlotNeck]
+	if neck.EquippedEp != 20 {
+		t.Errorf("Expected the equipped neck to be worth 20 EP, got %0.1f", neck.EquippedEp)
+	}
+	if len(neck.Items) != 1 {
+		t.Fatalf("Expected 1 upgrade, got %d", len(neck.Items))
+	}
+	upgrade := neck.Items[0]
+	if upgrade.Item.Id != betterNeck || upgrade.Ep != 4000 || upgrade.EpGain != 3980 || upgrade.DpsGain != 1990 || upgrade.Simmed {
+		t.Errorf("Expected the better neck to gain 3980 EP and 1990 DPS, got %v", upgrade)
+	}
+}
+
+func TestCanEquipItem(t *testing.T) {
+	twoHandSword := Item{Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeSword, HandType: proto.HandType_HandTypeTwoHand}
+	oneHandSword := Item{Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeSword, HandType: proto.HandType_HandTypeOneHand}
+	plateChest := Item{Type: proto.ItemType_ItemTypeChest, ArmorType: proto.ArmorType_ArmorTypePlate}
+
+	for _, tc := range []struct {
+		comment string
+		item    Item
+		class   proto.Class
+		slot    proto.ItemSlot
+		want    bool
+	}{
+		{"warriors can use two-handed swords", twoHandSword, proto.Class_ClassWarrior, proto.ItemSlot_ItemSlotMainHand, true},
+		{"two-handers can't go in the offhand", twoHandSword, proto.Class_ClassWarrior, proto.ItemSlot_ItemSlotOffHand, false},
+		{"rogues can't use two-handed swords", twoHandSword, proto.Class_ClassRogue, proto.ItemSlot_ItemSlotMainHand, false},
+		{"rogues can dual wield", oneHandSword, proto.Class_ClassRogue, proto.ItemSlot_ItemSlotOffHand, true},
+		{"mages can't dual wield", oneHandSword, proto.Class_ClassMage, proto.ItemSlot_ItemSlotOffHand, false},
+		{"paladins can wear plate", plateChest, proto.Class_ClassPaladin, proto.ItemSlot_ItemSlotChest, true},
+		{"druids can't wear plate", plateChest, proto.Class_ClassDruid, proto.ItemSlot_ItemSlotChest, false},
+	} {
+		if got := canEquipItem(tc.item, tc.class, 60, tc.slot); got != tc.want {
+			t.Errorf("%s: canEquipItem() = %v, want %v", tc.comment, got, tc.want)
+		}
+	}
+}
+
+func TestItemRankingsMatchesFilters(t *testing.T) {
+	const zoneID = 1977
+	drop := &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeDrop, ZoneId: zoneID}
+	otherDrop := &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeDrop, ZoneId: zoneID + 1}
+	crafted := &proto.SimItemSource{Type: proto.ItemSourceType_ItemSourceTypeCrafted}
+
+	for _, tc := range []struct {
+		comment string
+		item    Item
+		filters *proto.ItemRankingsFilters
+		want    bool
+	}{
+		{"items without sources pass", Item{}, &proto.ItemRankingsFilters{Sources: []proto.ItemSourceType{proto.ItemSourceType_ItemSourceTypeDrop}}, true},
+		{"any matching source passes", Item{Sources: []*proto.SimItemSource{crafted, drop}}, &proto.ItemRankingsFilters{Sources: []proto.ItemSourceType{proto.ItemSourceType_ItemSourceTypeDrop}}, true},
+		{"no matching source fails", Item{Sources: []*proto.SimItemSource{crafted}}, &proto.ItemRankingsFilters{Sources: []proto.ItemSourceType{proto.ItemSourceType_ItemSourceTypeDrop}}, false},
+		{"drops from any filtered zone pass", Item{Sources: []*proto.SimItemSource{otherDrop, drop}}, &proto.ItemRankingsFilters{ZoneIds: []int32{zoneID}}, true},
+		{"drops from other zones fail", Item{Sources: []*proto.SimItemSource{otherDrop}}, &proto.ItemRankingsFilters{ZoneIds: []int32{zoneID}}, false},
+	} {
+		ranker := &itemRanker{filters: tc.filters}
+		if got := ranker.matchesFilters(tc.item); got != tc.want {
+			t.Errorf("%s: matchesFilters() = %v, want %v", tc.comment, got, tc.want)
+		}
+	}
+}
+
+func TestItemRankingsEnchantsAndRunes(t *testing.T) {
+	const (
+		twoHandEnchant = 900011 + iota
+		weaponEnchant
+		legsRune
+	)
+	addToTestDatabase(t, &proto.SimDatabase{
+		Enchants: []*proto.SimEnchant{
+			{EffectId: twoHandEnchant, Type: proto.ItemType_ItemTypeWeapon, EnchantType: proto.EnchantType_EnchantTypeTwoHand},
+			{EffectId: weaponEnchant, Type: proto.ItemType_ItemTypeWeapon},
+		},
+		Runes: []*proto.SimRune{
+			{Id: legsRune, Type: proto.ItemType_ItemTypeLegs},
+		},
+	})
+	twoHandSword := Item{Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeSword, HandType: proto.HandType_HandTypeTwoHand}
+	oneHandSword := Item{Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeSword, HandType: proto.HandType_HandTypeOneHand}
+	shield := Item{Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeShield, HandType: proto.HandType_HandTypeOffHand}
+
+	if enchantForItem(twoHandEnchant, twoHandSword) != twoHandEnchant {
+		t.Errorf("Expected a two-hand enchant to be kept on a two-hander")
+	}
+	if enchantForItem(twoHandEnchant, oneHandSword) != 0 {
+		t.Errorf("Expected a two-hand enchant to be dropped from a one-hander")
+	}
+	if enchantForItem(weaponEnchant, shield) != 0 {
+		t.Errorf("Expected a weapon enchant to be dropped from a shield")
+	}
+	if runeForItem(legsRune, Item{Type: proto.ItemType_ItemTypeLegs}) != legsRune {
+		t.Errorf("Expected a legs rune to be kept on legs")
+	}
+	if runeForItem(legsRune, Item{Type: proto.ItemType_ItemTypeChest}) != 0 {
+		t.Errorf("Expected a legs rune to be dropped from a chest")
+	}
+}
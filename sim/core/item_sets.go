@@ -69,6 +69,16 @@ func NewItemSet(set ItemSet) *ItemSet {
 	return &set
 }
 
+// Returns whether a set with the given ID or name has bonuses implemented.
+func HasItemSet(id int32, name string) bool {
+	for _, set := range sets {
+		if (id > 0 && set.ID == id) || set.Name == name || set.AlternativeName == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (character *Character) HasSetBonus(set *ItemSet, numItems int32) bool {
 	if character.Env != nil && character.Env.IsFinalized() {
 		panic("HasSetBonus is very slow and should never be called after finalization. Try caching the value during construction instead!")
@@ -107,10 +117,14 @@ type ActiveSetBonus struct {
 
 // Returns a list describing all active set bonuses.
 func (character *Character) GetActiveSetBonuses() []ActiveSetBonus {
+	return getActiveSetBonuses(&character.Equipment)
+}
+
+func getActiveSetBonuses(equipment *Equipment) []ActiveSetBonus {
 	var activeBonuses []ActiveSetBonus
 
 	setItemCount := make(map[*ItemSet]int32)
-	for _, item := range character.Equipment {
+	for _, item := range equipment {
 		if item.SetName == "" {
 			continue
 		}
@@ -153,6 +167,11 @@ func (character *Character) GetActiveSetBonuses() []ActiveSetBonus {
 
 // Apply effects from item set bonuses.
 func (character *Character) applyItemSetBonusEffects(agent Agent) {
+	if character.ItemSwap.IsEnabled() {
+		character.ItemSwap.applySetBonusEffects(agent)
+		return
+	}
+
 	activeSetBonuses := character.GetActiveSetBonuses()
 
 	for _, activeSetBonus := range activeSetBonuses {
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
@@ -9,67 +11,124 @@ import (
 
 type OnSwapItem func(*Simulation)
 
-const offset = proto.ItemSlot_ItemSlotMainHand
+// Equipping an item with an on-use effect puts that effect on cooldown.
+const ItemSwapEquipCooldown = time.Second * 30
+
+type ItemSwapSet struct {
+	Name string
+
+	// Full equipment worn while this set is equipped.
+	equipment Equipment
+}
+
+// Bookkeeping for an item, enchant or set bonus effect which is only active in
+// some of the swap sets. Stats, auras and spells added while applying the
+// effect are toggled whenever the effect becomes active or inactive.
+//
+// Changes made by the effect to PseudoStats or stat dependencies are not tracked.
+type itemSwapEffect struct {
+	activeInSet []bool
+	isActive    bool
+
+	// Whether this effect comes from an item, whose on-use spells go on
+	// cooldown when it is equipped.
+	isItem bool
+
+	// Cooldown started by equipping the item. Spells with their own cooldown
+	// are also put on cooldown, but on-use effects which only have a shared
+	// trinket cooldown can't be, without also locking out the other trinket.
+	equipCD Cooldown
+
+	stats          stats.Stats
+	auras          []*Aura
+	permanentAuras []*Aura
+	spells         []*Spell
+}
 
 type ItemSwap struct {
 	character       *Character
 	onSwapCallbacks []OnSwapItem
 
-	// Which slots to actually swap.
-	slots []proto.ItemSlot
+	// Equipment for each set, starting with the main equipment.
+	sets        []ItemSwapSet
+	equippedSet int
 
-	// Holds items that are currently not equipped
-	unEquippedItems [3]Item
-	swapped         bool
+	effects []*itemSwapEffect
 }
 
 // TODO All the extra parameters here and the code in multiple places for handling the Weapon struct is really messy,
 //  we'll need to figure out something cleaner as this will be quite error-prone
 
-func (character *Character) enableItemSwap(itemSwap *proto.ItemSwap) {
-	var slots []proto.ItemSlot
-	hasMhSwap := itemSwap.MhItem != nil && itemSwap.MhItem.Id != 0
-	hasOhSwap := itemSwap.OhItem != nil && itemSwap.OhItem.Id != 0
-	hasRangedSwap := itemSwap.RangedItem != nil && itemSwap.RangedItem.Id != 0
+func (character *Character) enableItemSwap(itemSwaps []*proto.ItemSwap) {
+	sets := []ItemSwapSet{{
+		Name:      "Main",
+		equipment: character.Equipment,
+	}}
 
-	mainItems := [3]Item{
-		character.Equipment[proto.ItemSlot_ItemSlotMainHand],
-		character.Equipment[proto.ItemSlot_ItemSlotOffHand],
-		character.Equipment[proto.ItemSlot_ItemSlotRanged],
-	}
-	swapItems := [3]Item{
-		toItem(itemSwap.MhItem),
-		toItem(itemSwap.OhItem),
-		toItem(itemSwap.RangedItem),
-	}
+	for _, itemSwap := range itemSwaps {
+		if itemSwap == nil {
+			continue
+		}
 
-	// Handle MH and OH together, because present MH + empty OH --> swap MH and unequip OH
-	if hasMhSwap || hasOhSwap {
-		if swapItems[0].ID != mainItems[0].ID {
-			slots = append(slots, proto.ItemSlot_ItemSlotMainHand)
+		set := ItemSwapSet{
+			Name:      itemSwap.Name,
+			equipment: character.Equipment,
 		}
-		if swapItems[1].ID != mainItems[1].ID {
-			slots = append(slots, proto.ItemSlot_ItemSlotOffHand)
+		if set.Name == "" {
+			set.Name = fmt.Sprintf("Swap %d", len(sets))
 		}
-	}
-	if hasRangedSwap {
-		if swapItems[2].ID != mainItems[2].ID {
-			slots = append(slots, proto.ItemSlot_ItemSlotRanged)
+
+		hasSwap := false
+		for slot, itemSpec := range getItemSwapSpecs(itemSwap) {
+			if itemSpec == nil || itemSpec.Id == 0 {
+				continue
+			}
+			set.equipment[slot] = toItem(itemSpec)
+			hasSwap = hasSwap || !isSameItem(set.equipment[slot], character.Equipment[slot])
+		}
+
+		// 2H will swap out the offhand also.
+		if set.equipment.MainHand().HandType == proto.HandType_HandTypeTwoHand {
+			hasSwap = hasSwap || character.Equipment.OffHand().ID != 0
+			*set.equipment.OffHand() = Item{}
+		}
+
+		if hasSwap {
+			sets = append(sets, set)
 		}
 	}
 
-	if len(slots) == 0 {
+	if len(sets) == 1 {
 		return
 	}
 
 	character.ItemSwap = ItemSwap{
-		character:       character,
-		slots:           slots,
-		unEquippedItems: swapItems,
-		swapped:         false,
+		character: character,
+		sets:      sets,
 	}
 }
 
+// Returns the items of a swap set indexed by slot, including the deprecated
+// weapon-only fields.
+func getItemSwapSpecs(itemSwap *proto.ItemSwap) []*proto.ItemSpec {
+	itemSpecs := make([]*proto.ItemSpec, len(Equipment{}))
+	if itemSwap.Equipment != nil {
+		copy(itemSpecs, itemSwap.Equipment.Items)
+	}
+
+	if itemSwap.MhItem != nil && itemSwap.MhItem.Id != 0 {
+		itemSpecs[proto.ItemSlot_ItemSlotMainHand] = itemSwap.MhItem
+	}
+	if itemSwap.OhItem != nil && itemSwap.OhItem.Id != 0 {
+		itemSpecs[proto.ItemSlot_ItemSlotOffHand] = itemSwap.OhItem
+	}
+	if itemSwap.RangedItem != nil && itemSwap.RangedItem.Id != 0 {
+		itemSpecs[proto.ItemSlot_ItemSlotRanged] = itemSwap.RangedItem
+	}
+
+	return itemSpecs
+}
+
 func (swap *ItemSwap) initialize(character *Character) {
 	swap.character = character
 }
@@ -113,105 +172,339 @@ func (swap *ItemSwap) RegisterOnSwapItemForEffect(effectID int32, aura *Aura) {
 }
 
 func (swap *ItemSwap) IsEnabled() bool {
-	return swap.character != nil && len(swap.slots) > 0
+	return swap.character != nil && len(swap.sets) > 1
 }
 
 func (swap *ItemSwap) IsSwapped() bool {
-	return swap.swapped
+	return swap.equippedSet != 0
 }
 
-func (swap *ItemSwap) GetItem(slot proto.ItemSlot) *Item {
-	if slot-offset < 0 {
-		panic("Not able to swap Item " + slot.String() + " not supported")
+// Returns the index of the swap set with the given name, or -1 if there is no
+// such set. An empty name selects the first swap set.
+func (swap *ItemSwap) GetSetIndex(name string) int {
+	if !swap.IsEnabled() {
+		return -1
 	}
-	return &swap.unEquippedItems[slot-offset]
+	if name == "" {
+		return 1
+	}
+
+	return slices.IndexFunc(swap.sets, func(set ItemSwapSet) bool {
+		return set.Name == name
+	})
+}
+
+// Index of the currently equipped set, 0 for the main equipment.
+func (swap *ItemSwap) EquippedSet() int {
+	return swap.equippedSet
+}
+
+func (swap *ItemSwap) GetSetName(index int) string {
+	return swap.sets[index].Name
 }
 
-func (swap *ItemSwap) CalcStatChanges(slots []proto.ItemSlot) stats.Stats {
+// Returns the item in the given slot for a set.
+func (swap *ItemSwap) GetItem(index int, slot proto.ItemSlot) *Item {
+	return &swap.sets[index].equipment[slot]
+}
+
+func (swap *ItemSwap) CalcStatChanges(index int) stats.Stats {
 	newStats := stats.Stats{}
-	for _, slot := range slots {
-		oldItemStats := swap.getItemStats(swap.character.Equipment[slot])
-		newItemStats := swap.getItemStats(*swap.GetItem(slot))
+	for slot := range swap.character.Equipment {
+		oldItemStats := getItemSwapItemStats(swap.character.Equipment[slot])
+		newItemStats := getItemSwapItemStats(swap.sets[index].equipment[slot])
 		newStats = newStats.Add(newItemStats.Subtract(oldItemStats))
 	}
 
 	return newStats
 }
 
-func (swap *ItemSwap) SwapItems(sim *Simulation, slots []proto.ItemSlot) {
-	if !swap.IsEnabled() {
+// Equips the set with the given index. Index 0 is the main equipment.
+func (swap *ItemSwap) EquipSet(sim *Simulation, index int) {
+	if !swap.IsEnabled() || index == swap.equippedSet {
 		return
 	}
 
 	character := swap.character
+	meleeWeaponSwapped := swap.equipSet(sim, index, false)
+
+	if character.AutoAttacks.AutoSwingMelee && meleeWeaponSwapped && sim.CurrentTime > 0 {
+		character.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime, false)
+	}
+
+	// If GCD is ready then use the GCD, otherwise we assume it's being used along side a spell.
+	if character.GCD.IsReady(sim) {
+		newGCD := sim.CurrentTime + 1500*time.Millisecond
+		character.SetGCDTimer(sim, newGCD)
+	}
+}
+
+// Swaps every differing slot to the items of the given set and toggles
+// the item and set bonus effects. Stats are left alone on reset, since they
+// have already been restored for the main equipment, but weapon skills aren't.
+//
+// Returns whether a melee weapon was swapped.
+func (swap *ItemSwap) equipSet(sim *Simulation, index int, isReset bool) bool {
+	character := swap.character
+	newEquipment := &swap.sets[index].equipment
 
 	meleeWeaponSwapped := false
 	newStats := stats.Stats{}
-	has2H := swap.GetItem(proto.ItemSlot_ItemSlotMainHand).HandType == proto.HandType_HandTypeTwoHand
-	for _, slot := range slots {
-		//will swap both on the MainHand Slot for 2H.
-		if slot == proto.ItemSlot_ItemSlotOffHand && has2H {
+	for i := range character.Equipment {
+		slot := proto.ItemSlot(i)
+		oldItem := character.Equipment[slot]
+		newItem := newEquipment[slot]
+		if isSameItem(oldItem, newItem) {
 			continue
 		}
 
-		if ok, swapStats := swap.swapItem(slot, has2H); ok {
-			newStats = newStats.Add(swapStats)
-			meleeWeaponSwapped = slot == proto.ItemSlot_ItemSlotMainHand || slot == proto.ItemSlot_ItemSlotOffHand || meleeWeaponSwapped
+		if !isReset {
+			newStats = newStats.Add(getItemSwapItemStats(newItem).Subtract(getItemSwapItemStats(oldItem)))
 		}
+		// Weapon skills are pseudo stats, which aren't restored between iterations.
+		character.addItemWeaponSkills(oldItem, -1)
+		character.addItemWeaponSkills(newItem, 1)
+
+		character.Equipment[slot] = newItem
+		swap.swapWeapon(slot)
+		meleeWeaponSwapped = slot == proto.ItemSlot_ItemSlotMainHand || slot == proto.ItemSlot_ItemSlotOffHand || meleeWeaponSwapped
 	}
 
-	character.AddStatsDynamic(sim, newStats)
+	swap.equippedSet = index
+	effectStats := swap.updateEffects(sim, isReset)
+
+	if !isReset {
+		newStats = newStats.Add(effectStats)
+		character.AddStatsDynamic(sim, newStats)
 
-	if sim.Log != nil {
-		sim.Log("Item Swap Stats: %v", newStats)
+		if sim.Log != nil {
+			sim.Log("Item Swap Stats: %v", newStats)
+		}
 	}
 
 	for _, onSwap := range swap.onSwapCallbacks {
 		onSwap(sim)
 	}
 
-	if character.AutoAttacks.AutoSwingMelee && meleeWeaponSwapped && sim.CurrentTime > 0 {
-		character.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime, false)
+	return meleeWeaponSwapped
+}
+
+// Toggles effects for the currently equipped set, returning the stat changes.
+func (swap *ItemSwap) updateEffects(sim *Simulation, isReset bool) stats.Stats {
+	newStats := stats.Stats{}
+	for _, effect := range swap.effects {
+		isActive := effect.activeInSet[swap.equippedSet]
+		if isActive == effect.isActive {
+			continue
+		}
+		effect.isActive = isActive
+
+		if !isActive {
+			newStats = newStats.Subtract(effect.stats)
+			for _, aura := range effect.auras {
+				aura.Deactivate(sim)
+			}
+			continue
+		}
+
+		newStats = newStats.Add(effect.stats)
+		for _, aura := range effect.permanentAuras {
+			aura.Activate(sim)
+		}
+		if effect.isItem && !isReset {
+			effect.equipCD.Use(sim)
+			for _, spell := range effect.spells {
+				if spell.CD.Timer != nil {
+					spell.CD.Set(max(spell.CD.ReadyAt(), sim.CurrentTime+ItemSwapEquipCooldown))
+				}
+			}
+		}
 	}
 
-	// If GCD is ready then use the GCD, otherwise we assume it's being used along side a spell.
-	if character.GCD.IsReady(sim) {
-		newGCD := sim.CurrentTime + 1500*time.Millisecond
-		character.SetGCDTimer(sim, newGCD)
+	return newStats
+}
+
+// Applies an effect which is active in the sets flagged by activeInSet,
+// tracking what it adds to the character so it can be toggled on swap.
+func (swap *ItemSwap) applyEffect(activeInSet []bool, isItem bool, applyEffect func()) {
+	if !slices.Contains(activeInSet, false) {
+		applyEffect()
+		return
+	}
+
+	character := swap.character
+	numAuras := len(character.auras)
+	numSpells := len(character.Spellbook)
+	oldStats := character.stats
+
+	applyEffect()
+
+	effect := &itemSwapEffect{
+		activeInSet: activeInSet,
+		isActive:    true,
+		isItem:      isItem,
+		stats:       character.stats.Subtract(oldStats),
+		auras:       slices.Clone(character.auras[numAuras:]),
+		spells:      slices.Clone(character.Spellbook[numSpells:]),
+	}
+	if isItem {
+		effect.equipCD = Cooldown{
+			Timer:    character.NewTimer(),
+			Duration: ItemSwapEquipCooldown,
+		}
+	}
+
+	for _, aura := range effect.auras {
+		if aura.Duration != NeverExpires || aura.OnReset == nil {
+			continue
+		}
+
+		onReset := aura.OnReset
+		aura.OnReset = func(aura *Aura, sim *Simulation) {
+			if effect.isActive {
+				onReset(aura, sim)
+			}
+		}
+		effect.permanentAuras = append(effect.permanentAuras, aura)
+	}
+
+	for _, spell := range effect.spells {
+		// Only on-use spells share the equip cooldown, procs work straight away.
+		isOnUse := spell.CD.Timer != nil || spell.SharedCD.Timer != nil
+		extraCastCondition := spell.ExtraCastCondition
+		spell.ExtraCastCondition = func(sim *Simulation, target *Unit) bool {
+			return effect.isActive && (!isOnUse || effect.equipCD.Timer == nil || effect.equipCD.IsReady(sim)) &&
+				(extraCastCondition == nil || extraCastCondition(sim, target))
+		}
+	}
+
+	swap.effects = append(swap.effects, effect)
+}
+
+// Removes the stats of effects which are inactive in the main equipment.
+// Should be called once all item and set bonus effects have been applied.
+func (swap *ItemSwap) applyMainSetEffects() {
+	for _, effect := range swap.effects {
+		if !effect.activeInSet[0] {
+			effect.isActive = false
+			swap.character.AddStats(effect.stats.Invert())
+		}
+	}
+}
+
+// Returns which sets have the item equipped, or nil if item swapping is disabled.
+func (swap *ItemSwap) getItemActiveInSets(itemID int32) []bool {
+	if !swap.IsEnabled() {
+		return nil
+	}
+
+	activeInSet := make([]bool, len(swap.sets))
+	for i := range swap.sets {
+		activeInSet[i] = slices.ContainsFunc(swap.sets[i].equipment[:], func(item Item) bool {
+			return item.ID == itemID
+		})
+	}
+	return activeInSet
+}
+
+// Returns which sets have the enchant in the given slot, or nil if item swapping
+// is disabled.
+func (swap *ItemSwap) getEnchantActiveInSets(slot proto.ItemSlot, effectID int32) []bool {
+	if !swap.IsEnabled() {
+		return nil
 	}
-	swap.swapped = !swap.swapped
+
+	activeInSet := make([]bool, len(swap.sets))
+	for i := range swap.sets {
+		activeInSet[i] = swap.sets[i].equipment[slot].Enchant.EffectID == effectID
+	}
+	return activeInSet
 }
 
-func (swap *ItemSwap) swapItem(slot proto.ItemSlot, has2H bool) (bool, stats.Stats) {
-	oldItem := swap.character.Equipment[slot]
-	newItem := swap.GetItem(slot)
+// Applies the effects of an enchant in the given slot, toggling them with the
+// sets which have it.
+func (swap *ItemSwap) applyEnchantEffects(agent Agent, slot proto.ItemSlot, effectID int32) {
+	if applyEnchantEffect, ok := enchantEffects[effectID]; ok {
+		swap.applyEffect(swap.getEnchantActiveInSets(slot, effectID), false, func() {
+			applyEnchantEffect(agent)
+		})
+	}
 
-	if newItem.ID == 0 && !(has2H && slot == proto.ItemSlot_ItemSlotOffHand) {
-		return false, stats.Stats{}
+	if applyWeaponEffect, ok := weaponEffects[effectID]; ok {
+		swap.applyEffect(swap.getEnchantActiveInSets(slot, effectID), false, func() {
+			applyWeaponEffect(agent, slot)
+		})
 	}
+}
 
-	swap.character.Equipment[slot] = *newItem
-	oldItemStats := swap.getItemStats(oldItem)
-	newItemStats := swap.getItemStats(*newItem)
-	newStats := newItemStats.Subtract(oldItemStats)
+// Applies effects of items which are only equipped by swap sets.
+func (swap *ItemSwap) applySwapItemEffects(agent Agent) {
+	character := swap.character
 
-	//2H will swap out the offhand also.
-	if has2H && slot == proto.ItemSlot_ItemSlotMainHand {
-		_, ohStats := swap.swapItem(proto.ItemSlot_ItemSlotOffHand, has2H)
-		newStats = newStats.Add(ohStats)
+	appliedItemIDs := make(map[int32]bool)
+	appliedEnchants := make(map[proto.ItemSlot][]int32)
+	for slot, item := range character.Equipment {
+		appliedItemIDs[item.ID] = true
+		appliedEnchants[proto.ItemSlot(slot)] = append(appliedEnchants[proto.ItemSlot(slot)], item.Enchant.EffectID)
 	}
 
-	swap.unEquippedItems[slot-offset] = oldItem
-	swap.swapWeapon(slot)
+	for _, set := range swap.sets[1:] {
+		for i, item := range set.equipment {
+			slot := proto.ItemSlot(i)
+
+			if applyItemEffect, ok := itemEffects[item.ID]; ok && !appliedItemIDs[item.ID] {
+				swap.applyEffect(swap.getItemActiveInSets(item.ID), true, func() {
+					applyItemEffect(agent)
+				})
+			}
+			appliedItemIDs[item.ID] = true
 
-	return true, newStats
+			if slices.Contains(appliedEnchants[slot], item.Enchant.EffectID) {
+				continue
+			}
+			appliedEnchants[slot] = append(appliedEnchants[slot], item.Enchant.EffectID)
+
+			swap.applyEnchantEffects(agent, slot, item.Enchant.EffectID)
+		}
+	}
 }
 
-func (swap *ItemSwap) getItemStats(item Item) stats.Stats {
-	itemStats := item.Stats
-	itemStats = itemStats.Add(item.Enchant.Stats)
+// Applies the set bonuses of every swap set, toggling them with the set.
+func (swap *ItemSwap) applySetBonusEffects(agent Agent) {
+	type setBonusKey struct {
+		name      string
+		numPieces int32
+	}
+
+	var keys []setBonusKey
+	bonuses := make(map[setBonusKey]ActiveSetBonus)
+	activeInSet := make(map[setBonusKey][]bool)
+	for i := range swap.sets {
+		for _, bonus := range getActiveSetBonuses(&swap.sets[i].equipment) {
+			key := setBonusKey{name: bonus.Name, numPieces: bonus.NumPieces}
+			if _, ok := activeInSet[key]; !ok {
+				keys = append(keys, key)
+				bonuses[key] = bonus
+				activeInSet[key] = make([]bool, len(swap.sets))
+			}
+			activeInSet[key][i] = true
+		}
+	}
+
+	for _, key := range keys {
+		bonus := bonuses[key]
+		swap.applyEffect(activeInSet[key], false, func() {
+			bonus.BonusEffect(agent)
+		})
+	}
+}
 
-	return itemStats
+func isSameItem(a Item, b Item) bool {
+	return a.ID == b.ID && a.Enchant.EffectID == b.Enchant.EffectID && a.RandomSuffix.ID == b.RandomSuffix.ID && a.Rune == b.Rune
+}
+
+func getItemSwapItemStats(item Item) stats.Stats {
+	return item.Stats.Add(item.RandomSuffix.Stats).Add(item.Enchant.Stats)
 }
 
 func (swap *ItemSwap) swapWeapon(slot proto.ItemSlot) {
@@ -237,22 +530,14 @@ func (swap *ItemSwap) swapWeapon(slot proto.ItemSlot) {
 	}
 }
 
+// Stats and auras have already been reset for the main equipment, so this only
+// restores the equipped items and the state of the tracked effects.
 func (swap *ItemSwap) reset(sim *Simulation) {
 	if !swap.IsEnabled() || !swap.IsSwapped() {
 		return
 	}
 
-	swap.SwapItems(sim, swap.slots)
-}
-
-func getInitialEquippedItems(character *Character) [3]Item {
-	var items [3]Item
-
-	for i := range items {
-		items[i] = character.Equipment[i+int(offset)]
-	}
-
-	return items
+	swap.equipSet(sim, 0, true)
 }
 
 func toItem(itemSpec *proto.ItemSpec) Item {
@@ -261,7 +546,8 @@ func toItem(itemSpec *proto.ItemSpec) Item {
 	}
 
 	return NewItem(ItemSpec{
-		ID: itemSpec.Id,
+		ID:           itemSpec.Id,
+		RandomSuffix: itemSpec.RandomSuffix,
 
 		Enchant: itemSpec.Enchant,
 		Rune:    itemSpec.Rune,
@@ -0,0 +1,227 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+// Returns a started sim for a player with a main and a "Swap" item swap set,
+// each with the given items and nothing else.
+func newItemSwapTestSim(mainItems map[proto.ItemSlot]*proto.ItemSpec, swapItems map[proto.ItemSlot]*proto.ItemSpec) *Simulation {
+	mainEquipment := make([]*proto.ItemSpec, proto.ItemSlot_ItemSlotRanged+1)
+	swapEquipment := make([]*proto.ItemSpec, proto.ItemSlot_ItemSlotRanged+1)
+	for i := range mainEquipment {
+		mainEquipment[i] = &proto.ItemSpec{}
+		swapEquipment[i] = &proto.ItemSpec{}
+	}
+	for slot, item := range mainItems {
+		mainEquipment[slot] = item
+	}
+	for slot, item := range swapItems {
+		swapEquipment[slot] = item
+	}
+
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:           "Caster",
+			Class:          proto.Class_ClassShaman,
+			Consumes:       &proto.Consumes{},
+			Buffs:          &proto.IndividualBuffs{},
+			Spec:           &proto.Player_ElementalShaman{},
+			Equipment:      &proto.EquipmentSpec{Items: mainEquipment},
+			EnableItemSwap: true,
+			ItemSwapSets: []*proto.ItemSwap{
+				{Name: "Swap", Equipment: &proto.EquipmentSpec{Items: swapEquipment}},
+			},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	})
+	sim.Reset()
+	return sim
+}
+
+func TestItemSwapSetEffects(t *testing.T) {
+	const (
+		mainTrinket = 900201 + iota
+		swapTrinket
+		swapEnchant
+	)
+	itemStats := func(stat stats.Stat, value float64) []float64 {
+		itemStats := make([]float64, stats.Len)
+		itemStats[stat] = value
+		return itemStats
+	}
+	addToTestDatabase(t, &proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: mainTrinket, Type: proto.ItemType_ItemTypeTrinket, Stats: itemStats(stats.Strength, 5)},
+			{Id: swapTrinket, Type: proto.ItemType_ItemTypeTrinket, Stats: itemStats(stats.Agility, 7)},
+		},
+		Enchants: []*proto.SimEnchant{
+			{EffectId: swapEnchant, Type: proto.ItemType_ItemTypeTrinket, Stats: itemStats(stats.Stamina, 3)},
+		},
+	})
+	registerEffect := func(label string, stat stats.Stat) ApplyEffect {
+		return func(agent Agent) {
+			character := agent.GetCharacter()
+			character.AddStat(stat, 100)
+			MakePermanent(character.RegisterAura(Aura{
+				Label:    label,
+				ActionID: ActionID{SpellID: 1},
+			}))
+		}
+	}
+	NewItemEffect(mainTrinket, registerEffect("Main Trinket", stats.Strength))
+	NewItemEffect(swapTrinket, registerEffect("Swap Trinket", stats.Agility))
+	NewEnchantEffect(swapEnchant, registerEffect("Swap Enchant", stats.Spirit))
+	t.Cleanup(func() {
+		for _, id := range []int32{mainTrinket, swapTrinket} {
+			delete(itemEffects, id)
+		}
+		delete(enchantEffects, swapEnchant)
+	})
+
+	sim := newItemSwapTestSim(map[proto.ItemSlot]*proto.ItemSpec{
+		proto.ItemSlot_ItemSlotTrinket1: {Id: mainTrinket},
+	}, map[proto.ItemSlot]*proto.ItemSpec{
+		proto.ItemSlot_ItemSlotTrinket1: {Id: swapTrinket, Enchant: swapEnchant},
+	})
+
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	swapIndex := character.ItemSwap.GetSetIndex("Swap")
+	if swapIndex != 1 {
+		t.Fatalf("Expected the swap set at index 1, got %d", swapIndex)
+	}
+
+	expectActive := func(comment string, mainActive bool, swapActive bool) {
+		t.Helper()
+		if character.GetAura("Main Trinket").IsActive() != mainActive {
+			t.Errorf("%s: expected the main trinket aura active = %v", comment, mainActive)
+		}
+		if character.GetAura("Swap Trinket").IsActive() != swapActive {
+			t.Errorf("%s: expected the swap trinket aura active = %v", comment, swapActive)
+		}
+		if character.GetAura("Swap Enchant").IsActive() != swapActive {
+			t.Errorf("%s: expected the swap enchant aura active = %v", comment, swapActive)
+		}
+	}
+	expectStats := func(comment string, expected stats.Stats) {
+		t.Helper()
+		for _, stat := range []stats.Stat{stats.Strength, stats.Agility, stats.Stamina, stats.Spirit} {
+			if actual := character.GetStat(stat); !WithinToleranceFloat64(expected[stat], actual, 0.001) {
+				t.Errorf("%s: expected %s = %0.1f, got %0.1f", comment, stat.StatName(), expected[stat], actual)
+			}
+		}
+	}
+
+	mainStats := character.GetStats()
+	expectActive("Main set", true, false)
+
+	character.ItemSwap.EquipSet(sim, swapIndex)
+	expectActive("Swap set", false, true)
+	swapStats := mainStats.Add(stats.Stats{
+		stats.Strength: -105,
+		stats.Agility:  107,
+		stats.Stamina:  3,
+		stats.Spirit:   100,
+	})
+	expectStats("Swap set", swapStats)
+
+	character.ItemSwap.EquipSet(sim, 0)
+	expectActive("Back to the main set", true, false)
+	expectStats("Back to the main set", mainStats)
+}
+
+func TestItemSwapWeaponSkillsReset(t *testing.T) {
+	const (
+		mainSword = 900211 + iota
+		swapSword
+	)
+	swordSkill := func(value float64) []float64 {
+		skills := make([]float64, proto.WeaponSkill_WeaponSkillFeralCombat+1)
+		skills[proto.WeaponSkill_WeaponSkillSwords] = value
+		return skills
+	}
+	addToTestDatabase(t, &proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: mainSword, Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeMainHand, WeaponType: proto.WeaponType_WeaponTypeSword, WeaponSpeed: 2},
+			{Id: swapSword, Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeMainHand, WeaponType: proto.WeaponType_WeaponTypeSword, WeaponSpeed: 2, WeaponSkills: swordSkill(5)},
+		},
+	})
+
+	sim := newItemSwapTestSim(map[proto.ItemSlot]*proto.ItemSpec{
+		proto.ItemSlot_ItemSlotMainHand: {Id: mainSword},
+	}, map[proto.ItemSlot]*proto.ItemSpec{
+		proto.ItemSlot_ItemSlotMainHand: {Id: swapSword},
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	baseSkill := character.PseudoStats.SwordsSkill
+
+	character.ItemSwap.EquipSet(sim, character.ItemSwap.GetSetIndex("Swap"))
+	if skill := character.PseudoStats.SwordsSkill; skill != baseSkill+5 {
+		t.Fatalf("Expected %0.0f sword skill with the swapped weapon, got %0.0f", baseSkill+5, skill)
+	}
+
+	// The next iteration starts with the main equipment again.
+	sim.Reset()
+	if skill := character.PseudoStats.SwordsSkill; skill != baseSkill {
+		t.Errorf("Expected the next iteration to start with %0.0f sword skill, got %0.0f", baseSkill, skill)
+	}
+}
+
+func TestItemSwapEquipCooldown(t *testing.T) {
+	const swapTrinket = 900221
+
+	addToTestDatabase(t, &proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: swapTrinket, Type: proto.ItemType_ItemTypeTrinket},
+		},
+	})
+	var onUseSpell, procSpell *Spell
+	NewItemEffect(swapTrinket, func(agent Agent) {
+		character := agent.GetCharacter()
+		onUseSpell = character.RegisterSpell(SpellConfig{
+			ActionID: ActionID{ItemID: swapTrinket},
+			Cast: CastConfig{
+				CD: Cooldown{
+					Timer:    character.NewTimer(),
+					Duration: time.Minute * 2,
+				},
+			},
+		})
+		procSpell = character.RegisterSpell(SpellConfig{
+			ActionID: ActionID{SpellID: swapTrinket},
+		})
+	})
+	t.Cleanup(func() {
+		delete(itemEffects, swapTrinket)
+	})
+
+	sim := newItemSwapTestSim(nil, map[proto.ItemSlot]*proto.ItemSpec{
+		proto.ItemSlot_ItemSlotTrinket1: {Id: swapTrinket},
+	})
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	target := sim.Encounter.TargetUnits[0]
+
+	if onUseSpell.ExtraCastCondition(sim, target) || procSpell.ExtraCastCondition(sim, target) {
+		t.Errorf("Expected the trinket's spells to be unusable while it isn't equipped")
+	}
+
+	character.ItemSwap.EquipSet(sim, character.ItemSwap.GetSetIndex("Swap"))
+	if onUseSpell.ExtraCastCondition(sim, target) {
+		t.Errorf("Expected the on-use to wait for the equip cooldown")
+	}
+	if !procSpell.ExtraCastCondition(sim, target) {
+		t.Errorf("Expected procs to work straight after equipping the trinket")
+	}
+}
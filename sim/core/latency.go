@@ -0,0 +1,79 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Returns the delay between the end of a GCD or cast and when the next action
+// reaches the server. Actions queued within the spell queue window go through
+// right away, otherwise the rest of the ping is paid.
+func (unit *Unit) ActionDelay() time.Duration {
+	return max(0, unit.Ping-unit.SpellQueueWindow)
+}
+
+// Runs the rotation once the action delay has passed, for actions taken in
+// response to a hardcast completing or an event such as a swing or proc. Actions
+// taken when the GCD ends don't need this, the GCD timer already includes the delay.
+func (unit *Unit) doNextActionAfterDelay(sim *Simulation) {
+	delay := unit.ActionDelay()
+	if delay == 0 {
+		unit.Rotation.DoNextAction(sim)
+		return
+	}
+
+	// An earlier event already scheduled the next action.
+	if unit.nextActionAt > sim.CurrentTime {
+		return
+	}
+
+	unit.nextActionAt = sim.CurrentTime + delay
+	StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     unit.nextActionAt,
+		Priority: ActionPriorityGCD,
+		OnAction: unit.Rotation.DoNextAction,
+	})
+}
+
+// Returns a random delay for the human agent to notice an in-game event and
+// for their response to reach the server. The response can be queued like any
+// other action, so only the action delay is added rather than the full ping.
+func (unit *Unit) SampleReactionTime(sim *Simulation) time.Duration {
+	mean := unit.ReactionTime.Seconds()
+	stdev := unit.ReactionTimeStdev.Seconds()
+
+	reactionTime := mean
+	if stdev > 0 && mean > 0 {
+		switch unit.ReactionTimeDistribution {
+		case proto.ReactionTimeDistribution_ReactionTimeNormal:
+			reactionTime = mean + stdev*sim.RandomNormFloat("Reaction Time")
+		case proto.ReactionTimeDistribution_ReactionTimeLognormal:
+			sigmaSq := math.Log(1 + (stdev*stdev)/(mean*mean))
+			mu := math.Log(mean) - sigmaSq/2
+			reactionTime = math.Exp(mu + math.Sqrt(sigmaSq)*sim.RandomNormFloat("Reaction Time"))
+		}
+	}
+
+	return max(0, DurationFromSeconds(reactionTime)) + unit.ActionDelay()
+}
+
+// Reaction time to an aura, drawn once per activation so repeated checks of the
+// same aura agree with each other.
+type auraReaction struct {
+	appliedAt    time.Duration
+	reactionTime time.Duration
+}
+
+func (reaction *auraReaction) get(sim *Simulation, unit *Unit, aura *Aura) time.Duration {
+	if appliedAt := aura.StartedAt(); appliedAt != reaction.appliedAt || reaction.reactionTime < 0 {
+		reaction.appliedAt = appliedAt
+		reaction.reactionTime = unit.SampleReactionTime(sim)
+	}
+	return reaction.reactionTime
+}
+
+func newAuraReaction() auraReaction {
+	return auraReaction{reactionTime: -1}
+}
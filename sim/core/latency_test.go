@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestActionDelay(t *testing.T) {
+	unit := &Unit{Ping: time.Millisecond * 100, SpellQueueWindow: time.Millisecond * 40}
+	if delay := unit.ActionDelay(); delay != time.Millisecond*60 {
+		t.Errorf("Expected 60ms action delay, got %s", delay)
+	}
+
+	unit.SpellQueueWindow = time.Millisecond * 400
+	if delay := unit.ActionDelay(); delay != 0 {
+		t.Errorf("Expected no action delay within the spell queue window, got %s", delay)
+	}
+}
+
+func TestSampleReactionTimeMean(t *testing.T) {
+	sim := &Simulation{rand: NewSplitMix(1)}
+
+	for _, distribution := range []proto.ReactionTimeDistribution{
+		proto.ReactionTimeDistribution_ReactionTimeFixed,
+		proto.ReactionTimeDistribution_ReactionTimeNormal,
+		proto.ReactionTimeDistribution_ReactionTimeLognormal,
+	} {
+		unit := &Unit{
+			ReactionTime:             time.Millisecond * 200,
+			ReactionTimeStdev:        time.Millisecond * 50,
+			ReactionTimeDistribution: distribution,
+			Ping:                     time.Millisecond * 50,
+		}
+
+		const numSamples = 10000
+		total := time.Duration(0)
+		for i := 0; i < numSamples; i++ {
+			total += unit.SampleReactionTime(sim)
+		}
+
+		mean := total.Seconds() / numSamples
+		if !WithinToleranceFloat64(0.25, mean, 0.005) {
+			t.Errorf("Expected mean reaction time of 250ms for %s, got %0.1fms", distribution, mean*1000)
+		}
+	}
+}
+
+func TestSampleReactionTimeActionDelay(t *testing.T) {
+	sim := &Simulation{rand: NewSplitMix(1)}
+	unit := &Unit{
+		ReactionTime:     time.Millisecond * 200,
+		Ping:             time.Millisecond * 100,
+		SpellQueueWindow: time.Millisecond * 40,
+	}
+
+	if reactionTime := unit.SampleReactionTime(sim); reactionTime != time.Millisecond*260 {
+		t.Errorf("Expected 260ms reaction time including the action delay, got %s", reactionTime)
+	}
+}
+
+func TestDoNextActionAfterDelay(t *testing.T) {
+	sim := SetupFakeSim()
+	unit := &sim.Raid.Parties[0].Players[0].(*FakeAgent).Unit
+	unit.Ping = time.Millisecond * 100
+	unit.SpellQueueWindow = time.Millisecond * 40
+	sim.CurrentTime = time.Second
+
+	numPending := len(sim.pendingActions)
+	unit.doNextActionAfterDelay(sim)
+	if len(sim.pendingActions) != numPending+1 {
+		t.Fatalf("Expected the next action to be scheduled")
+	}
+	if unit.nextActionAt != time.Second+time.Millisecond*60 {
+		t.Errorf("Expected the next action at 1.06s, got %s", unit.nextActionAt)
+	}
+
+	// A second event before the next action runs shouldn't schedule another one.
+	sim.CurrentTime += time.Millisecond * 30
+	unit.doNextActionAfterDelay(sim)
+	if len(sim.pendingActions) != numPending+1 {
+		t.Errorf("Expected a single scheduled next action, got %d", len(sim.pendingActions)-numPending)
+	}
+}
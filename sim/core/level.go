@@ -0,0 +1,126 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Returns the highest rank learnable at the given level. rankLevels holds the
+// level each rank is learned at, indexed by rank with index 0 unused, matching
+// the rank tables used by the class packages.
+//
+// If no rank is learnable at the given level, returns the lowest rank and false,
+// so callers never end up with the unused zero rank.
+func GetRankForLevel(rankLevels []int, level int32) (int, bool) {
+	for rank := len(rankLevels) - 1; rank > 0; rank-- {
+		if rankLevels[rank] <= int(level) {
+			return rank, true
+		}
+	}
+	return 1, false
+}
+
+// Returns the highest rank of a spell learnable at the unit's level, like
+// GetRankForLevel. rankSpellIDs holds the spell ID of each rank, indexed the same
+// way as rankLevels.
+//
+// If no rank is learnable yet, the spell is remembered so validateLevel can
+// reject rotations that use it, and callers should skip registering the spell.
+func (unit *Unit) GetSpellRankForLevel(rankLevels []int, rankSpellIDs []int32) (int, bool) {
+	rank, ok := GetRankForLevel(rankLevels, unit.Level)
+	if !ok {
+		if unit.unlearnedSpells == nil {
+			unit.unlearnedSpells = make(map[int32]int)
+		}
+		for _, spellID := range rankSpellIDs[1:] {
+			unit.unlearnedSpells[spellID] = rankLevels[1]
+		}
+	}
+	return rank, ok
+}
+
+// Returns the levels present in a table keyed by level, in ascending order.
+func getTableLevels[T any](table map[int]T) []int {
+	levels := make([]int, 0, len(table))
+	for level := range table {
+		levels = append(levels, level)
+	}
+	slices.Sort(levels)
+	return levels
+}
+
+// Makes sure the player can actually use everything they have equipped and
+// every spell their rotation casts at their level, so misconfigured sims fail
+// loudly instead of silently using gear or ranks the player wouldn't have.
+//
+// Spell ranks are only known once the class has registered its spells, so this
+// runs during finalization rather than when the character is created.
+func (character *Character) validateLevel(rotation *proto.APLRotation) {
+	if character.Level < 1 || character.Level > CharacterMaxLevel {
+		panic(fmt.Sprintf("Invalid level %d for %s, must be between 1 and %d.", character.Level, character.Name, CharacterMaxLevel))
+	}
+
+	equipments := []*Equipment{&character.Equipment}
+	for i := 1; i < len(character.ItemSwap.sets); i++ {
+		equipments = append(equipments, &character.ItemSwap.sets[i].equipment)
+	}
+
+	for _, equipment := range equipments {
+		for _, item := range equipment {
+			if item.RequiresLevel > character.Level {
+				panic(fmt.Sprintf("%s requires level %d, but %s is level %d.", item.Name, item.RequiresLevel, character.Name, character.Level))
+			}
+
+			if itemRune, ok := RunesByID[item.Rune]; ok && itemRune.RequiresLevel > character.Level {
+				panic(fmt.Sprintf("Rune %d on %s requires level %d, but %s is level %d.", item.Rune, item.Name, itemRune.RequiresLevel, character.Name, character.Level))
+			}
+		}
+	}
+
+	if rotation == nil || len(character.unlearnedSpells) == 0 {
+		return
+	}
+	rotationSpellIDs := make(map[int32]bool)
+	for _, prepullAction := range rotation.PrepullActions {
+		if !prepullAction.Hide {
+			collectSpellIDs(prepullAction.ProtoReflect(), rotationSpellIDs)
+		}
+	}
+	for _, listItem := range rotation.PriorityList {
+		if !listItem.Hide {
+			collectSpellIDs(listItem.ProtoReflect(), rotationSpellIDs)
+		}
+	}
+	for spellID, level := range character.unlearnedSpells {
+		if rotationSpellIDs[spellID] {
+			panic(fmt.Sprintf("Rotation uses spell %d, which %s can't learn until level %d.", spellID, character.Name, level))
+		}
+	}
+}
+
+// Collects the spell ID of every action ID within a message, e.g. the spells a
+// rotation casts or checks.
+func collectSpellIDs(message protoreflect.Message, spellIDs map[int32]bool) {
+	if actionID, ok := message.Interface().(*proto.ActionID); ok {
+		if spellID := actionID.GetSpellId(); spellID != 0 {
+			spellIDs[spellID] = true
+		}
+		return
+	}
+
+	message.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case fd.Message() == nil || fd.IsMap():
+		case fd.IsList():
+			for i := 0; i < value.List().Len(); i++ {
+				collectSpellIDs(value.List().Get(i).Message(), spellIDs)
+			}
+		default:
+			collectSpellIDs(value.Message(), spellIDs)
+		}
+		return true
+	})
+}
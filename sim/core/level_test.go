@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestGetRankForLevel(t *testing.T) {
+	rankLevels := []int{0, 4, 8, 14, 20, 26, 32, 38, 44, 50, 56, 60}
+
+	cases := map[int32]int{
+		4:  1,
+		25: 4,
+		29: 5,
+		40: 7,
+		59: 10,
+		60: 11,
+	}
+
+	for level, expectedRank := range cases {
+		if rank, ok := GetRankForLevel(rankLevels, level); !ok || rank != expectedRank {
+			t.Errorf("Expected rank %d at level %d, got %d", expectedRank, level, rank)
+		}
+	}
+
+	if rank, ok := GetRankForLevel(rankLevels, 1); ok || rank != 1 {
+		t.Errorf("Expected the lowest rank and false below every rank's level, got rank %d and %v", rank, ok)
+	}
+}
+
+func TestGetClassBaseStatsInterpolates(t *testing.T) {
+	class := proto.Class_ClassMage
+
+	lower := getClassBaseStats(class, 40)
+	upper := getClassBaseStats(class, 50)
+	expected := lower.Add(upper).Multiply(0.5)
+
+	if result := getClassBaseStats(class, 45); !result.EqualsWithTolerance(expected, 0.0001) {
+		t.Fatalf("Expected interpolated base stats %s, got %s", expected, result)
+	}
+}
+
+func TestValidateLevelRejectsUnlearnedSpells(t *testing.T) {
+	character := &Character{Unit: Unit{Level: 10}, Name: "Test"}
+
+	if _, ok := character.GetSpellRankForLevel([]int{0, 20, 30}, []int32{0, 845, 7369}); ok {
+		t.Fatalf("Expected no learnable rank at level 10")
+	}
+
+	castSpell := func(spellID int32, hide bool) *proto.APLListItem {
+		return &proto.APLListItem{
+			Hide: hide,
+			Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+				SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: spellID}},
+			}}},
+		}
+	}
+
+	// Hidden actions and learned spells are fine.
+	character.validateLevel(&proto.APLRotation{PriorityList: []*proto.APLListItem{castSpell(7369, true), castSpell(78, false)}})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a rotation casting an unlearned spell to fail validation")
+		}
+	}()
+	character.validateLevel(&proto.APLRotation{PriorityList: []*proto.APLListItem{castSpell(7369, false)}})
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"math"
+	"slices"
 	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
@@ -24,8 +25,17 @@ type DistributionMetrics struct {
 	minSeed int64
 	hist    map[int32]int32 // rounded DPS to count
 	sample  []float64
+
+	targetError float64
 }
 
+// Z-score for a two-sided 95% confidence interval.
+const ConfidenceInterval95Z = 1.96
+
+// Number of iterations needed before convergence is checked, so the standard
+// deviation is meaningful.
+const MinConvergenceIterations = 100
+
 func (distMetrics *DistributionMetrics) reset() {
 	distMetrics.Total = 0
 }
@@ -34,6 +44,7 @@ func (distMetrics *DistributionMetrics) reset() {
 func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 	dps := distMetrics.Total / sim.Duration.Seconds()
 	distMetrics.add(dps)
+	distMetrics.targetError = sim.Options.TargetError
 
 	if sim.Options.SaveAllValues {
 		if cap(distMetrics.sample) < int(sim.Options.Iterations) {
@@ -55,8 +66,69 @@ func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 	distMetrics.hist[dpsRounded]++
 }
 
+// Returns the standard error of the mean, and the half-width of its 95% confidence interval.
+func (distMetrics *DistributionMetrics) confidenceInterval95() (float64, float64) {
+	_, stdev := distMetrics.meanAndStdDev()
+	stdError := stdev / math.Sqrt(float64(distMetrics.n))
+	return stdError, stdError * ConfidenceInterval95Z
+}
+
+// Whether the mean is known to within the given relative error, at 95% confidence.
+func (distMetrics *DistributionMetrics) hasConverged(targetError float64) bool {
+	if targetError <= 0 || distMetrics.n < MinConvergenceIterations {
+		return false
+	}
+
+	mean, _ := distMetrics.meanAndStdDev()
+	_, halfWidth := distMetrics.confidenceInterval95()
+	return halfWidth <= targetError*math.Abs(mean)
+}
+
+// Returns the given percentiles (0-1) of the per-iteration values.
+func (distMetrics *DistributionMetrics) percentiles(ps ...float64) []float64 {
+	results := make([]float64, len(ps))
+	if distMetrics.n == 0 {
+		return results
+	}
+
+	if len(distMetrics.sample) == distMetrics.n {
+		sorted := slices.Clone(distMetrics.sample)
+		slices.Sort(sorted)
+		for i, p := range ps {
+			pos := p * float64(len(sorted)-1)
+			lower := int(math.Floor(pos))
+			upper := min(lower+1, len(sorted)-1)
+			results[i] = sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+		}
+		return results
+	}
+
+	// Without all values, interpolate within the histogram buckets instead.
+	buckets := make([]int32, 0, len(distMetrics.hist))
+	for bucket := range distMetrics.hist {
+		buckets = append(buckets, bucket)
+	}
+	slices.Sort(buckets)
+
+	for i, p := range ps {
+		target := p * float64(distMetrics.n)
+		cumulative := 0.0
+		for _, bucket := range buckets {
+			count := float64(distMetrics.hist[bucket])
+			if cumulative+count >= target {
+				results[i] = float64(bucket) - 5 + 10*(target-cumulative)/count
+				break
+			}
+			cumulative += count
+		}
+	}
+	return results
+}
+
 func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 	mean, stdev := distMetrics.meanAndStdDev()
+	stdError, halfWidth := distMetrics.confidenceInterval95()
+	percentiles := distMetrics.percentiles(0.05, 0.25, 0.5, 0.75, 0.95)
 
 	return &proto.DistributionMetrics{
 		Avg:       mean,
@@ -67,6 +139,17 @@ func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 		MinSeed:   distMetrics.minSeed,
 		Hist:      distMetrics.hist,
 		AllValues: distMetrics.sample,
+
+		P5:  percentiles[0],
+		P25: percentiles[1],
+		P50: percentiles[2],
+		P75: percentiles[3],
+		P95: percentiles[4],
+
+		StdError:  stdError,
+		Ci95Lower: mean - halfWidth,
+		Ci95Upper: mean + halfWidth,
+		Converged: distMetrics.hasConverged(distMetrics.targetError),
 	}
 }
 
@@ -84,19 +167,28 @@ type UnitMetrics struct {
 	dtps   DistributionMetrics
 	tmi    DistributionMetrics
 	hps    DistributionMetrics
+	ehps   DistributionMetrics // Effective healing per second, excluding overhealing.
+	ohps   DistributionMetrics // Overhealing per second.
 	tto    DistributionMetrics
 
 	tmiList   []tmiListItem
 	isTanking bool
 	tmiBin    int32
 
+	timeline *timelineMetrics // Only set when SimOptions.TimelineBinSeconds is.
+
+	breakdown    breakdownMetrics // Values for the current iteration.
+	breakdownSum breakdownMetrics // Summed over all iterations.
+
 	CharacterIterationMetrics
 
 	// Aggregate values. These are updated after each iteration.
-	numItersDead int32
-	oomTimeSum   float64
-	actions      map[ActionID]*ActionMetrics
-	resources    []*ResourceMetrics
+	numItersDead        int32
+	numItersPulledAggro int32
+	aggroPullTimeSum    float64
+	oomTimeSum          float64
+	actions             map[ActionID]*ActionMetrics
+	resources           []*ResourceMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -111,6 +203,9 @@ type CharacterIterationMetrics struct {
 	OOMTime time.Duration // time spent not casting and waiting for regen.
 
 	FirstOOMTimestamp time.Duration // Timestamp at which unit first went OOM.
+
+	PulledAggro             bool          // Whether this unit pulled aggro from a tank in the current iteration.
+	FirstAggroPullTimestamp time.Duration // Timestamp at which unit first pulled aggro.
 }
 
 type ActionMetrics struct {
@@ -171,10 +266,14 @@ type SpellMetrics struct {
 	TotalResistedCritTickDamage float64 // Damage done by all resisted critical dots of this spell.
 	TotalGlanceDamage           float64 // Damage done by all glance casts of this spell.
 	TotalBlockDamage            float64 // Damage done by all block casts of this spell.
+	TotalAbsorbedDamage         float64 // Damage done by all casts of this spell that was absorbed by shields.
 	TotalThreat                 float64 // Threat generated by all casts of this spell.
 	TotalHealing                float64 // Healing done by all casts of this spell.
 	TotalCritHealing            float64 // Healing done by all critical casts of this spell.
-	TotalShielding              float64 // Shielding done by all casts of this spell.
+	TotalEffectiveHealing       float64 // Healing done by all casts of this spell, excluding overhealing.
+	TotalOverhealing            float64 // Healing done by all casts of this spell beyond the target's max health.
+	TotalShielding              float64 // Damage absorbed by shields from all casts of this spell.
+	TotalShieldingWasted        float64 // Shielding from all casts of this spell that expired or was overwritten unused.
 	TotalCastTime               time.Duration
 }
 
@@ -204,10 +303,14 @@ type TargetedActionMetrics struct {
 	ResistedCritTickDamage float64
 	GlanceDamage           float64
 	BlockDamage            float64
+	AbsorbedDamage         float64
 	Threat                 float64
 	Healing                float64
 	CritHealing            float64
+	EffectiveHealing       float64
+	Overhealing            float64
 	Shielding              float64
+	ShieldingWasted        float64
 	CastTime               time.Duration
 }
 
@@ -239,10 +342,14 @@ func (tam *TargetedActionMetrics) ToProto(unitIndex int32) *proto.TargetedAction
 		ResistedCritTickDamage: tam.ResistedCritTickDamage,
 		GlanceDamage:           tam.GlanceDamage,
 		BlockDamage:            tam.BlockDamage,
+		AbsorbedDamage:         tam.AbsorbedDamage,
 		Threat:                 tam.Threat,
 		Healing:                tam.Healing,
 		CritHealing:            tam.CritHealing,
+		EffectiveHealing:       tam.EffectiveHealing,
+		Overhealing:            tam.Overhealing,
 		Shielding:              tam.Shielding,
+		ShieldingWasted:        tam.ShieldingWasted,
 		CastTimeMs:             float64(tam.CastTime.Milliseconds()),
 	}
 }
@@ -255,6 +362,8 @@ func NewUnitMetrics() UnitMetrics {
 		dtps:    NewDistributionMetrics(),
 		tmi:     NewDistributionMetrics(),
 		hps:     NewDistributionMetrics(),
+		ehps:    NewDistributionMetrics(),
+		ohps:    NewDistributionMetrics(),
 		tto:     NewDistributionMetrics(),
 		actions: make(map[ActionID]*ActionMetrics),
 	}
@@ -395,10 +504,14 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.ResistedCritTickDamage += spellTargetMetrics.TotalResistedCritTickDamage
 		tam.GlanceDamage += spellTargetMetrics.TotalGlanceDamage
 		tam.BlockDamage += spellTargetMetrics.TotalBlockDamage
+		tam.AbsorbedDamage += spellTargetMetrics.TotalAbsorbedDamage
 		tam.Threat += spellTargetMetrics.TotalThreat
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.CritHealing += spellTargetMetrics.TotalCritHealing
+		tam.EffectiveHealing += spellTargetMetrics.TotalEffectiveHealing
+		tam.Overhealing += spellTargetMetrics.TotalOverhealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
+		tam.ShieldingWasted += spellTargetMetrics.TotalShieldingWasted
 		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
 			tam.CastTime += spellTargetMetrics.TotalCastTime
 		}
@@ -411,6 +524,8 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 			unitMetrics.threat.Total += spellTargetMetrics.TotalThreat
 		} else {
 			unitMetrics.hps.Total += spellTargetMetrics.TotalHealing + spellTargetMetrics.TotalShielding
+			unitMetrics.ehps.Total += spellTargetMetrics.TotalEffectiveHealing + spellTargetMetrics.TotalShielding
+			unitMetrics.ohps.Total += spellTargetMetrics.TotalOverhealing
 		}
 	}
 }
@@ -420,6 +535,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 // Assumes that doneIteration() has already been called on the pet metrics.
 func (unitMetrics *UnitMetrics) AddFinalPetMetrics(petMetrics *UnitMetrics) {
 	unitMetrics.dps.Total += petMetrics.dps.Total
+	unitMetrics.breakdown.add(&petMetrics.breakdown)
 }
 
 func (unitMetrics *UnitMetrics) AddOOMTime(sim *Simulation, dur time.Duration) {
@@ -448,8 +564,11 @@ func (unitMetrics *UnitMetrics) reset() {
 	unitMetrics.tmi.reset()
 	unitMetrics.tmiList = nil
 	unitMetrics.hps.reset()
+	unitMetrics.ehps.reset()
+	unitMetrics.ohps.reset()
 	unitMetrics.tto.reset()
 	unitMetrics.CharacterIterationMetrics = CharacterIterationMetrics{}
+	unitMetrics.breakdown = breakdownMetrics{}
 
 	for _, resourceMetrics := range unitMetrics.resources {
 		resourceMetrics.reset()
@@ -492,12 +611,21 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.dtps.doneIteration(sim)
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
+	unitMetrics.ehps.doneIteration(sim)
+	unitMetrics.ohps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
 
+	unitMetrics.breakdown.phaseSeconds = sim.executePhaseSeconds()
+	unitMetrics.breakdownSum.add(&unitMetrics.breakdown)
+
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
+	if unitMetrics.PulledAggro {
+		unitMetrics.numItersPulledAggro++
+		unitMetrics.aggroPullTimeSum += unitMetrics.FirstAggroPullTimestamp.Seconds()
+	}
 }
 
 func (unitMetrics *UnitMetrics) calculateTMI(unit *Unit, sim *Simulation) float64 {
@@ -581,9 +709,22 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Dtps:          unitMetrics.dtps.ToProto(),
 		Tmi:           unitMetrics.tmi.ToProto(),
 		Hps:           unitMetrics.hps.ToProto(),
+		Ehps:          unitMetrics.ehps.ToProto(),
+		Ohps:          unitMetrics.ohps.ToProto(),
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+
+		ChanceOfAggroPull: float64(unitMetrics.numItersPulledAggro) / n,
+	}
+	if unitMetrics.numItersPulledAggro > 0 {
+		protoMetrics.AggroPullTimeAvgSeconds = unitMetrics.aggroPullTimeSum / float64(unitMetrics.numItersPulledAggro)
+	}
+
+	unitMetrics.breakdownSum.fillProto(protoMetrics, n)
+
+	if unitMetrics.timeline != nil {
+		protoMetrics.Timeline = unitMetrics.timeline.ToProto()
 	}
 
 	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(unitMetrics.actions))
@@ -635,3 +776,41 @@ func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
 		ProcsAvg:           float64(auraMetrics.procsSum) / float64(auraMetrics.n),
 	}
 }
+
+type WeaponImbueMetrics struct {
+	ID   ActionID
+	IsMH bool
+
+	// Metrics for the current iteration.
+	ChargesUsed    int32
+	Reapplications int32
+
+	// Aggregate values. These are updated after each iteration.
+	n                 int32
+	chargesUsedSum    int32
+	reapplicationsSum int32
+}
+
+func (imbueMetrics *WeaponImbueMetrics) reset() {
+	imbueMetrics.ChargesUsed = 0
+	imbueMetrics.Reapplications = 0
+}
+
+// This should be called when a Sim iteration is complete.
+func (imbueMetrics *WeaponImbueMetrics) doneIteration() {
+	imbueMetrics.n++
+	imbueMetrics.chargesUsedSum += imbueMetrics.ChargesUsed
+	imbueMetrics.reapplicationsSum += imbueMetrics.Reapplications
+}
+
+func (imbueMetrics *WeaponImbueMetrics) ToProto() *proto.WeaponImbueMetrics {
+	n := float64(max(imbueMetrics.n, 1))
+
+	return &proto.WeaponImbueMetrics{
+		Id:      imbueMetrics.ID.ToProto(),
+		OffHand: !imbueMetrics.IsMH,
+
+		ChargesUsedAvg:    float64(imbueMetrics.chargesUsedSum) / n,
+		ReapplicationsAvg: float64(imbueMetrics.reapplicationsSum) / n,
+	}
+}
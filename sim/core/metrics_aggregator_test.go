@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestDistributionMetricsPercentiles(t *testing.T) {
+	fromSample := NewDistributionMetrics()
+	fromHist := NewDistributionMetrics()
+	for i := 1; i <= 101; i++ {
+		value := float64(i * 10)
+		fromSample.add(value)
+		fromSample.sample = append(fromSample.sample, value)
+
+		fromHist.add(value)
+		fromHist.hist[int32(value)]++
+	}
+
+	expected := []float64{60, 260, 510, 760, 960}
+	for name, distMetrics := range map[string]*DistributionMetrics{"sample": &fromSample, "hist": &fromHist} {
+		percentiles := distMetrics.percentiles(0.05, 0.25, 0.5, 0.75, 0.95)
+		for i := range expected {
+			if !WithinToleranceFloat64(expected[i], percentiles[i], 10) {
+				t.Errorf("Expected percentile %d from %s to be %0.1f, got %0.1f", i, name, expected[i], percentiles[i])
+			}
+		}
+	}
+}
+
+func TestDistributionMetricsConvergence(t *testing.T) {
+	distMetrics := NewDistributionMetrics()
+	for i := 0; i < MinConvergenceIterations; i++ {
+		distMetrics.add(1000 + float64(i%2)*20)
+	}
+
+	// Stdev is 10, so the 95% CI half-width is 1.96.
+	if !distMetrics.hasConverged(0.002) {
+		t.Errorf("Expected convergence within 0.2%%")
+	}
+	if distMetrics.hasConverged(0.001) {
+		t.Errorf("Expected no convergence within 0.1%%")
+	}
+}
@@ -150,6 +150,10 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 	//reset current mana after applying stats
 	pet.manaBar.reset()
 
+	if pet.PositionsEnabled() {
+		pet.moveBehindTarget()
+	}
+
 	// Call onEnable callbacks before enabling auto swing
 	// to not have to reorder PAs multiple times
 	pet.enabled = true
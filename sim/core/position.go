@@ -0,0 +1,257 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A point or direction on the encounter's 2D plane, in yards.
+type Vector2 struct {
+	X float64
+	Y float64
+}
+
+func Vector2FromProto(vector *proto.Vector2) Vector2 {
+	return Vector2{X: vector.GetX(), Y: vector.GetY()}
+}
+
+// Returns the unit vector pointing at the given angle, counterclockwise from the X axis.
+func Vector2FromDegrees(degrees float64) Vector2 {
+	radians := degrees * math.Pi / 180
+	return Vector2{X: math.Cos(radians), Y: math.Sin(radians)}
+}
+
+func (v Vector2) Add(other Vector2) Vector2 {
+	return Vector2{X: v.X + other.X, Y: v.Y + other.Y}
+}
+
+func (v Vector2) Sub(other Vector2) Vector2 {
+	return Vector2{X: v.X - other.X, Y: v.Y - other.Y}
+}
+
+func (v Vector2) Scale(factor float64) Vector2 {
+	return Vector2{X: v.X * factor, Y: v.Y * factor}
+}
+
+func (v Vector2) Dot(other Vector2) float64 {
+	return v.X*other.X + v.Y*other.Y
+}
+
+func (v Vector2) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+func (v Vector2) DistanceTo(other Vector2) float64 {
+	return v.Sub(other).Length()
+}
+
+// Returns the unit vector in the same direction, or the zero vector.
+func (v Vector2) Normalize() Vector2 {
+	length := v.Length()
+	if length == 0 {
+		return Vector2{}
+	}
+	return v.Scale(1 / length)
+}
+
+// Whether units have positions in this encounter. If not, all targets are in
+// range of every spell and AoE, and InFrontOfTarget decides which side of a
+// target a unit is on.
+func (unit *Unit) PositionsEnabled() bool {
+	return unit.Env != nil && unit.Env.Encounter.UsePositions
+}
+
+// Returns the direction this unit is facing. Units face their current target,
+// if they have one.
+func (unit *Unit) Facing() Vector2 {
+	if unit.CurrentTarget != nil && unit.CurrentTarget != unit {
+		if direction := unit.CurrentTarget.Position.Sub(unit.Position).Normalize(); direction != (Vector2{}) {
+			return direction
+		}
+	}
+	return unit.defaultFacing
+}
+
+// Returns whether this unit is within the front arc of the target, where its
+// attacks can be parried and blocked. Units standing on the target's position,
+// e.g. when no positions were entered, use InFrontOfTarget instead.
+func (unit *Unit) IsInFrontOf(target *Unit) bool {
+	if !unit.PositionsEnabled() || target == nil || target == unit {
+		return unit.PseudoStats.InFrontOfTarget
+	}
+	offset := unit.Position.Sub(target.Position)
+	if offset == (Vector2{}) {
+		return unit.PseudoStats.InFrontOfTarget
+	}
+	return target.Facing().Dot(offset) >= 0
+}
+
+func (unit *Unit) DistanceTo(other *Unit) float64 {
+	return unit.Position.DistanceTo(other.Position)
+}
+
+func (unit *Unit) updateDistanceFromTarget() {
+	if unit.PositionsEnabled() && unit.CurrentTarget != nil {
+		unit.DistanceFromTarget = unit.DistanceTo(unit.CurrentTarget)
+	}
+}
+
+// Returns the point on the line between this unit and its current target, at
+// the given distance from the target.
+func (unit *Unit) pointAtRangeFromTarget(moveRange float64) Vector2 {
+	target := unit.CurrentTarget
+	direction := unit.Position.Sub(target.Position).Normalize()
+	if direction == (Vector2{}) {
+		direction = target.Facing()
+	}
+	if direction == (Vector2{}) {
+		direction = Vector2{X: 1}
+	}
+	return target.Position.Add(direction.Scale(moveRange))
+}
+
+// Moves this unit in a straight line toward the given point, one yard per tick.
+func (unit *Unit) MoveToPoint(point Vector2, sim *Simulation) {
+	distance := unit.Position.DistanceTo(point)
+	if distance == 0 {
+		return
+	}
+
+	numTicks := int(math.Ceil(distance))
+	step := point.Sub(unit.Position).Scale(1 / float64(numTicks))
+	tick := 0
+
+	unit.moveSpell.Cast(sim, unit.CurrentTarget)
+
+	sim.AddPendingAction(NewPeriodicAction(sim, PeriodicActionOptions{
+		Period:          time.Millisecond * 1000 / time.Duration(unit.MoveSpeed),
+		NumTicks:        numTicks,
+		TickImmediately: false,
+
+		OnAction: func(sim *Simulation) {
+			tick++
+			if tick == numTicks {
+				unit.Position = point
+			} else {
+				unit.Position = unit.Position.Add(step)
+			}
+			unit.updateDistanceFromTarget()
+			unit.moveAura.SetStacks(sim, int32(unit.DistanceFromTarget))
+
+			if tick == numTicks {
+				unit.moveAura.Deactivate(sim)
+			}
+		},
+	}))
+}
+
+// Pets attack from behind their target, regardless of where their owner is.
+func (pet *Pet) moveBehindTarget() {
+	target := pet.CurrentTarget
+	if target == nil {
+		pet.Position = pet.Owner.Position
+		return
+	}
+	pet.Position = target.Position.Sub(target.Facing().Scale(MaxMeleeAttackDistance / 2))
+	pet.updateDistanceFromTarget()
+}
+
+// Area hit by an AoE spell, when positions are enabled.
+type AoeConfig struct {
+	// Radius in yards. If 0, every target is hit.
+	Radius float64
+
+	// Full angle in degrees of a cone in front of the caster, e.g. for cleaves.
+	// If 0, the area is a circle.
+	ConeAngle float64
+
+	// Whether the area is centered on the spell's target, e.g. Blizzard. Otherwise
+	// it is centered on the caster, e.g. Whirlwind or Consecration.
+	CenteredOnTarget bool
+}
+
+// Returns the spell's max range, if it didn't set one: melee range for melee
+// attacks, and typical ranges for other harmful spells in the rotation. Helpful
+// spells, circles around the caster and enemy spells have no max range.
+func (unit *Unit) defaultMaxRange(config *SpellConfig) float64 {
+	if config.MaxRange != 0 {
+		return config.MaxRange
+	}
+	if unit.Type == EnemyUnit || config.Flags.Matches(SpellFlagHelpful) {
+		return 0
+	}
+	if config.Aoe.Radius > 0 && config.Aoe.ConeAngle == 0 && !config.Aoe.CenteredOnTarget {
+		return 0
+	}
+
+	switch config.DefenseType {
+	case DefenseTypeMelee:
+		return MaxMeleeAttackDistance
+	case DefenseTypeRanged:
+		return MaxRangedAttackDistance
+	case DefenseTypeMagic:
+		if config.Flags.Matches(SpellFlagAPL) {
+			return DefaultSpellRange
+		}
+	}
+	return 0
+}
+
+// Returns whether the target is within the spell's max range.
+func (spell *Spell) InRange(target *Unit) bool {
+	if spell.MaxRange == 0 || target == nil || !spell.Unit.PositionsEnabled() {
+		return true
+	}
+	return spell.Unit.DistanceTo(target) <= spell.MaxRange
+}
+
+// Returns the center of the area hit when this spell is cast at the target.
+func (spell *Spell) AoeCenter(target *Unit) Vector2 {
+	if spell.Aoe.CenteredOnTarget && target != nil {
+		return target.Position
+	}
+	return spell.Unit.Position
+}
+
+// Returns the targets hit by this spell when cast at the target. The returned
+// slice is reused by later calls.
+func (spell *Spell) AoeTargets(target *Unit) []*Unit {
+	return spell.AoeTargetsAt(spell.AoeCenter(target))
+}
+
+// Returns the targets within the spell's area, centered at the given point.
+// Ground effects should use the center from when they were cast. The returned
+// slice is reused by later calls.
+func (spell *Spell) AoeTargetsAt(center Vector2) []*Unit {
+	targets := spell.Unit.Env.Encounter.TargetUnits
+	if spell.Aoe.Radius == 0 || !spell.Unit.PositionsEnabled() {
+		return targets
+	}
+
+	spell.aoeTargets = spell.aoeTargets[:0]
+	for _, aoeTarget := range targets {
+		if spell.InAoe(center, aoeTarget) {
+			spell.aoeTargets = append(spell.aoeTargets, aoeTarget)
+		}
+	}
+	return spell.aoeTargets
+}
+
+// Returns whether the target is within the spell's area, centered at the given point.
+func (spell *Spell) InAoe(center Vector2, target *Unit) bool {
+	if spell.Aoe.Radius == 0 || !spell.Unit.PositionsEnabled() {
+		return true
+	}
+
+	offset := target.Position.Sub(center)
+	if offset.Length() > spell.Aoe.Radius {
+		return false
+	}
+	if spell.Aoe.ConeAngle > 0 && offset != (Vector2{}) {
+		minConeCos := math.Cos(spell.Aoe.ConeAngle / 2 * math.Pi / 180)
+		return offset.Normalize().Dot(spell.Unit.Facing()) >= minConeCos
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestPositionalChecks(t *testing.T) {
+	env := &Environment{Encounter: Encounter{UsePositions: true}}
+	target := &Unit{Env: env, Position: Vector2{}, defaultFacing: Vector2{X: 1}}
+	front := &Unit{Env: env, Position: Vector2{X: 5}, CurrentTarget: target}
+	behind := &Unit{Env: env, Position: Vector2{X: -5}, CurrentTarget: target}
+	behind.PseudoStats.InFrontOfTarget = true
+
+	if !front.IsInFrontOf(target) {
+		t.Errorf("Unit at %v should be in front of the target", front.Position)
+	}
+	if behind.IsInFrontOf(target) {
+		t.Errorf("Unit at %v should be behind the target", behind.Position)
+	}
+
+	// Targets face whoever they are attacking.
+	target.CurrentTarget = behind
+	if !behind.IsInFrontOf(target) || front.IsInFrontOf(target) {
+		t.Errorf("Target should face its current target")
+	}
+
+	// Units without an entered position stand on the target.
+	onTarget := &Unit{Env: env, Position: target.Position, CurrentTarget: target}
+	if onTarget.IsInFrontOf(target) {
+		t.Errorf("Unit on the target's position should use InFrontOfTarget")
+	}
+
+	env.Encounter.UsePositions = false
+	if !behind.IsInFrontOf(target) || front.IsInFrontOf(target) {
+		t.Errorf("Without positions, InFrontOfTarget should be used")
+	}
+}
+
+func TestDefaultMaxRange(t *testing.T) {
+	player := &Unit{Type: PlayerUnit}
+	enemy := &Unit{Type: EnemyUnit}
+
+	for _, tc := range []struct {
+		comment string
+		unit    *Unit
+		config  SpellConfig
+		want    float64
+	}{
+		{"melee attacks need melee range", player, SpellConfig{DefenseType: DefenseTypeMelee}, MaxMeleeAttackDistance},
+		{"cleaves need melee range", player, SpellConfig{DefenseType: DefenseTypeMelee, Aoe: AoeConfig{Radius: 8, ConeAngle: 180}}, MaxMeleeAttackDistance},
+		{"whirlwinds have no range", player, SpellConfig{DefenseType: DefenseTypeMelee, Aoe: AoeConfig{Radius: 8}}, 0},
+		{"ranged attacks", player, SpellConfig{DefenseType: DefenseTypeRanged}, MaxRangedAttackDistance},
+		{"spells in the rotation", player, SpellConfig{DefenseType: DefenseTypeMagic, Flags: SpellFlagAPL}, DefaultSpellRange},
+		{"proc spells have no range", player, SpellConfig{DefenseType: DefenseTypeMagic}, 0},
+		{"helpful spells have no range", player, SpellConfig{DefenseType: DefenseTypeMagic, Flags: SpellFlagAPL | SpellFlagHelpful}, 0},
+		{"enemy spells have no range", enemy, SpellConfig{DefenseType: DefenseTypeMelee}, 0},
+		{"explicit ranges are kept", player, SpellConfig{DefenseType: DefenseTypeMagic, MaxRange: 40}, 40},
+	} {
+		if got := tc.unit.defaultMaxRange(&tc.config); got != tc.want {
+			t.Errorf("%s: defaultMaxRange() = %v, want %v", tc.comment, got, tc.want)
+		}
+	}
+}
+
+func TestAoeAreas(t *testing.T) {
+	env := &Environment{Encounter: Encounter{UsePositions: true}}
+	near := &Unit{Env: env, Position: Vector2{X: 5}}
+	far := &Unit{Env: env, Position: Vector2{X: 10}}
+	side := &Unit{Env: env, Position: Vector2{Y: 5}}
+	caster := &Unit{Env: env, CurrentTarget: near}
+
+	circle := &Spell{Unit: caster, Aoe: AoeConfig{Radius: 8}}
+	if !circle.InAoe(Vector2{}, near) || circle.InAoe(Vector2{}, far) || !circle.InAoe(Vector2{}, side) {
+		t.Errorf("Circle should only hit targets within its radius")
+	}
+
+	cone := &Spell{Unit: caster, Aoe: AoeConfig{Radius: 8, ConeAngle: 60}}
+	if !cone.InAoe(Vector2{}, near) || cone.InAoe(Vector2{}, side) {
+		t.Errorf("Cone should only hit targets in front of the caster")
+	}
+
+	ranged := &Spell{Unit: caster, MaxRange: 8}
+	if !ranged.InRange(near) || ranged.InRange(far) {
+		t.Errorf("Spell should only be in range of targets within its max range")
+	}
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A raid buff or debuff provided by a simulated player, which replaces the
+// matching toggle so its uptime comes from the player's actual casts.
+type ProvidedBuffConfig struct {
+	Label string
+
+	// Turn off the toggles for this buff. Any of these may be nil.
+	ClearRaidBuffs func(raidBuffs *proto.RaidBuffs)
+	ClearDebuffs   func(debuffs *proto.Debuffs)
+
+	// Turn off toggles set on a single player, like assigned Power Infusions or
+	// the Windfury imbue. Called once for each player in the raid.
+	ClearPlayerBuffs func(player *Character, individualBuffs *proto.IndividualBuffs, consumes *proto.Consumes)
+}
+
+// Registers a buff provided by this character. This must be called while the
+// character is being constructed, since toggles are cleared before talents
+// and runes are applied.
+func (character *Character) RegisterProvidedBuff(config ProvidedBuffConfig) {
+	for _, providedBuff := range character.providedBuffs {
+		if providedBuff.Label == config.Label {
+			panic(fmt.Sprintf("Provided buff already registered for %s: %s", character.Label, config.Label))
+		}
+	}
+	character.providedBuffs = append(character.providedBuffs, config)
+}
+
+// Whether this character should skip applying its provided buffs. The toggles
+// stay cleared, so the sim measures the raid without these buffs at all.
+func (character *Character) ProvidedBuffsSuppressed() bool {
+	return character.suppressProvidedBuffs
+}
+
+func (raid *Raid) clearProvidedRaidBuffs(raidBuffs *proto.RaidBuffs) {
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			for _, providedBuff := range player.GetCharacter().providedBuffs {
+				if providedBuff.ClearRaidBuffs != nil {
+					providedBuff.ClearRaidBuffs(raidBuffs)
+				}
+			}
+		}
+	}
+}
+
+// Returns a copy of debuffs without the toggles of debuffs provided by players.
+func (raid *Raid) clearProvidedDebuffs(debuffs *proto.Debuffs) *proto.Debuffs {
+	debuffs = googleProto.Clone(debuffs).(*proto.Debuffs)
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			for _, providedBuff := range player.GetCharacter().providedBuffs {
+				if providedBuff.ClearDebuffs != nil {
+					providedBuff.ClearDebuffs(debuffs)
+				}
+			}
+		}
+	}
+	return debuffs
+}
+
+// Returns a copy of a player's individual buffs without the toggles of buffs
+// provided by other players, and replaces the player's consumes the same way.
+func (raid *Raid) clearProvidedPlayerBuffs(player *Character, individualBuffs *proto.IndividualBuffs) *proto.IndividualBuffs {
+	individualBuffs = googleProto.Clone(individualBuffs).(*proto.IndividualBuffs)
+	consumes := googleProto.Clone(player.Consumes).(*proto.Consumes)
+	for _, party := range raid.Parties {
+		for _, provider := range party.Players {
+			for _, providedBuff := range provider.GetCharacter().providedBuffs {
+				if providedBuff.ClearPlayerBuffs != nil {
+					providedBuff.ClearPlayerBuffs(player, individualBuffs, consumes)
+				}
+			}
+		}
+	}
+	player.Consumes = consumes
+	return individualBuffs
+}
+
+type buffCounterfactual struct {
+	character *Character
+	raidDps   float64
+}
+
+// Pins the random seed, so the runs without each player's buffs are
+// comparable with the main run.
+func withBuffContributionSeed(rsr *proto.RaidSimRequest) *proto.RaidSimRequest {
+	if !rsr.SimOptions.BuffContributions || rsr.SimOptions.RandomSeed != 0 {
+		return rsr
+	}
+	rsr = googleProto.Clone(rsr).(*proto.RaidSimRequest)
+	rsr.SimOptions.RandomSeed = time.Now().UnixNano()
+	return rsr
+}
+
+// Reruns the sim once for each player providing buffs, with that player's
+// buffs suppressed. Returns an error result if any of these sims fail.
+func (sim *Simulation) runBuffCounterfactuals(rsr *proto.RaidSimRequest, skipPresim bool) *proto.RaidSimResult {
+	for _, party := range sim.Raid.Parties {
+		for _, player := range party.Players {
+			character := player.GetCharacter()
+			if len(character.providedBuffs) == 0 {
+				continue
+			}
+
+			request := googleProto.Clone(rsr).(*proto.RaidSimRequest)
+			request.SimOptions.BuffContributions = false
+			request.SimOptions.Debug = false
+			request.SimOptions.DebugFirstIteration = false
+			request.Raid.Parties[party.Index].Players[character.PartyIndex].SuppressProvidedBuffs = true
+
+			result := runSim(request, nil, skipPresim)
+			if result.ErrorResult != "" {
+				return &proto.RaidSimResult{
+					ErrorResult: fmt.Sprintf("Sim without buffs from %s failed: %s", character.Label, result.ErrorResult),
+				}
+			}
+
+			sim.buffCounterfactuals = append(sim.buffCounterfactuals, buffCounterfactual{
+				character: character,
+				raidDps:   result.RaidMetrics.Dps.Avg,
+			})
+		}
+	}
+	return nil
+}
+
+func (sim *Simulation) getBuffContributionsProto(raidDps float64) []*proto.BuffContributionMetrics {
+	var metrics []*proto.BuffContributionMetrics
+	for _, counterfactual := range sim.buffCounterfactuals {
+		labels := MapSlice(counterfactual.character.providedBuffs, func(providedBuff ProvidedBuffConfig) string {
+			return providedBuff.Label
+		})
+		metrics = append(metrics, &proto.BuffContributionMetrics{
+			Name:      counterfactual.character.Name,
+			UnitIndex: counterfactual.character.UnitIndex,
+			Buffs:     labels,
+			RaidDps:   raidDps - counterfactual.raidDps,
+		})
+	}
+	return metrics
+}
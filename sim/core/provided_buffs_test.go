@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestProvidedBuffsClearToggles(t *testing.T) {
+	raid := &Raid{}
+	party := &Party{Raid: raid}
+	raid.Parties = []*Party{party}
+
+	provider := NewTargetDummy(0, party, 0)
+	provider.RegisterProvidedBuff(ProvidedBuffConfig{
+		Label: "Demonic Pact",
+		ClearRaidBuffs: func(raidBuffs *proto.RaidBuffs) {
+			raidBuffs.DemonicPact = 0
+		},
+		ClearDebuffs: func(debuffs *proto.Debuffs) {
+			debuffs.ShadowWeaving = false
+		},
+	})
+	party.Players = []Agent{provider, NewTargetDummy(1, party, 1)}
+
+	raidBuffs := raid.GetRaidBuffs(&proto.RaidBuffs{DemonicPact: 100, ArcaneBrilliance: true})
+	if raidBuffs.DemonicPact != 0 {
+		t.Errorf("Demonic Pact toggle should be cleared when a player provides it")
+	}
+	if !raidBuffs.ArcaneBrilliance {
+		t.Errorf("Other toggles should be kept")
+	}
+
+	debuffs := &proto.Debuffs{ShadowWeaving: true}
+	if raid.clearProvidedDebuffs(debuffs).ShadowWeaving {
+		t.Errorf("Shadow Weaving toggle should be cleared when a player provides it")
+	}
+	if !debuffs.ShadowWeaving {
+		t.Errorf("The raid's debuffs shouldn't be modified")
+	}
+}
+
+func TestProvidedBuffsClearPlayerToggles(t *testing.T) {
+	raid := &Raid{}
+	party := &Party{Raid: raid}
+	raid.Parties = []*Party{party}
+
+	provider := NewTargetDummy(0, party, 0)
+	target := NewTargetDummy(1, party, 1)
+	provider.RegisterProvidedBuff(ProvidedBuffConfig{
+		Label: "Power Infusion",
+		ClearPlayerBuffs: func(player *Character, individualBuffs *proto.IndividualBuffs, consumes *proto.Consumes) {
+			if player == &target.Character {
+				individualBuffs.PowerInfusions--
+				consumes.MainHandImbue = proto.WeaponImbue_WeaponImbueUnknown
+			}
+		},
+	})
+	party.Players = []Agent{provider, target}
+
+	individualBuffs := &proto.IndividualBuffs{PowerInfusions: 2}
+	consumes := &proto.Consumes{MainHandImbue: proto.WeaponImbue_Windfury}
+	provider.Consumes = consumes
+	target.Consumes = consumes
+
+	if raid.clearProvidedPlayerBuffs(&provider.Character, individualBuffs).PowerInfusions != 2 {
+		t.Errorf("Other players' toggles should be kept")
+	}
+	if provider.Consumes.MainHandImbue != proto.WeaponImbue_Windfury {
+		t.Errorf("Other players' consumes should be kept")
+	}
+
+	if raid.clearProvidedPlayerBuffs(&target.Character, individualBuffs).PowerInfusions != 1 {
+		t.Errorf("Power Infusion toggle should be cleared for the target")
+	}
+	if target.Consumes.MainHandImbue != proto.WeaponImbue_WeaponImbueUnknown {
+		t.Errorf("Windfury imbue should be cleared for the target")
+	}
+	if individualBuffs.PowerInfusions != 2 || consumes.MainHandImbue != proto.WeaponImbue_Windfury {
+		t.Errorf("The player's buffs and consumes shouldn't be modified")
+	}
+}
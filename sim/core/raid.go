@@ -119,9 +119,13 @@ type Raid struct {
 	dpsMetrics DistributionMetrics
 	hpsMetrics DistributionMetrics
 
+	fightLengthDps *fightLengthMetrics // Only set when the fight length varies.
+
 	AllPlayerUnits []*Unit // Cached list of all Players in the raid.
 	AllUnits       []*Unit // Cached list of all Units (players and pets) in the raid.
 
+	ThreatCeiling *ThreatCeiling // Nil unless the raid limits its threat to that of the tanks.
+
 	nextPetIndex int32
 
 	replenishmentUnits         []*Unit   // All units who can receive replenishment.
@@ -147,9 +151,10 @@ func NewRaid(raidConfig *proto.Raid) *Raid {
 	}
 
 	raid := &Raid{
-		dpsMetrics:   NewDistributionMetrics(),
-		hpsMetrics:   NewDistributionMetrics(),
-		nextPetIndex: int32(numParties) * 5,
+		dpsMetrics:    NewDistributionMetrics(),
+		hpsMetrics:    NewDistributionMetrics(),
+		nextPetIndex:  int32(numParties) * 5,
+		ThreatCeiling: newThreatCeiling(raidConfig.ThreatCeiling),
 	}
 
 	for partyIndex, partyConfig := range raidConfig.Parties {
@@ -229,6 +234,7 @@ func (raid *Raid) GetRaidBuffs(baseRaidBuffs *proto.RaidBuffs) *proto.RaidBuffs
 			player.GetCharacter().AddRaidBuffs(raidBuffs)
 		}
 	}
+	raid.clearProvidedRaidBuffs(raidBuffs)
 	return raidBuffs
 }
 
@@ -290,8 +296,10 @@ func (raid *Raid) applyCharacterEffects(raidConfig *proto.Raid) *proto.RaidStats
 			}
 
 			char := player.GetCharacter()
+			individualBuffs = raid.clearProvidedPlayerBuffs(char, individualBuffs)
 			char.EnableHealthBar()
-			char.trackChanceOfDeath(playerConfig.HealingModel)
+			char.trackChanceOfDeath(playerConfig.HealingModel, playerConfig.DamageProfile)
+			char.applyDamageProfile(playerConfig.DamageProfile)
 			partyStats.Players[char.PartyIndex] = char.applyAllEffects(player, raidBuffs, partyBuffs, individualBuffs)
 
 			for _, pet := range char.Pets {
@@ -377,6 +385,22 @@ func (raid *Raid) doneIteration(sim *Simulation) {
 
 	raid.dpsMetrics.doneIteration(sim)
 	raid.hpsMetrics.doneIteration(sim)
+
+	if sim.Encounter.durationVaries() {
+		if raid.fightLengthDps == nil {
+			raid.fightLengthDps = newFightLengthMetrics(sim.Options.FightLengthBinSeconds)
+		}
+		raid.fightLengthDps.add(sim.Duration, raid.dpsMetrics.Total/sim.Duration.Seconds())
+	}
+}
+
+// Whether the raid's mean DPS, or HPS if it does no damage, is known to within
+// the given relative error.
+func (raid *Raid) hasConverged(targetError float64) bool {
+	if raid.dpsMetrics.sum == 0 {
+		return raid.hpsMetrics.hasConverged(targetError)
+	}
+	return raid.dpsMetrics.hasConverged(targetError)
 }
 
 func (raid *Raid) GetMetrics() *proto.RaidMetrics {
@@ -387,6 +411,9 @@ func (raid *Raid) GetMetrics() *proto.RaidMetrics {
 	for _, party := range raid.Parties {
 		metrics.Parties = append(metrics.Parties, party.GetMetrics())
 	}
+	if raid.fightLengthDps != nil {
+		metrics.DpsByFightLength = raid.fightLengthDps.ToProto()
+	}
 	return metrics
 }
 
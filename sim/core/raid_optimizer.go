@@ -0,0 +1,596 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const (
+	defaultRaidOptimizerSearchIterations = 100
+	defaultRaidOptimizerMaxArrangements  = 500
+	defaultRaidOptimizerNumResults       = 5
+	defaultRaidOptimizerIterations       = 1000
+
+	// Number of best arrangements whose neighbours are searched each round.
+	raidOptimizerBeamWidth = 3
+
+	// Party index of the bench in a raidSlot.
+	benchPartyIndex = -1
+
+	// Roster index of an empty slot in a party.
+	emptyRosterIndex = -1
+)
+
+func isTankSpec(player *proto.Player) bool {
+	switch player.Spec.(type) {
+	case *proto.Player_FeralTankDruid, *proto.Player_ProtectionPaladin, *proto.Player_TankRogue,
+		*proto.Player_WardenShaman, *proto.Player_TankWarlock, *proto.Player_TankWarrior:
+		return true
+	}
+	return false
+}
+
+func isHealerSpec(player *proto.Player) bool {
+	switch player.Spec.(type) {
+	case *proto.Player_RestorationDruid, *proto.Player_HolyPaladin, *proto.Player_HealingPriest, *proto.Player_RestorationShaman:
+		return true
+	}
+	return false
+}
+
+// Roster indices of the players in each party and on the bench. Parties
+// always have 5 slots, with emptyRosterIndex for empty ones.
+type raidArrangement struct {
+	parties [][]int
+	bench   []int
+}
+
+type raidSlot struct {
+	party int
+	index int
+}
+
+func (arrangement *raidArrangement) clone() *raidArrangement {
+	parties := make([][]int, len(arrangement.parties))
+	for i, party := range arrangement.parties {
+		parties[i] = slices.Clone(party)
+	}
+	return &raidArrangement{
+		parties: parties,
+		bench:   slices.Clone(arrangement.bench),
+	}
+}
+
+func (arrangement *raidArrangement) get(slot raidSlot) int {
+	if slot.party == benchPartyIndex {
+		return arrangement.bench[slot.index]
+	}
+	return arrangement.parties[slot.party][slot.index]
+}
+
+func (arrangement *raidArrangement) set(slot raidSlot, rosterIndex int) {
+	if slot.party == benchPartyIndex {
+		arrangement.bench[slot.index] = rosterIndex
+	} else {
+		arrangement.parties[slot.party][slot.index] = rosterIndex
+	}
+}
+
+func (arrangement *raidArrangement) slots() []raidSlot {
+	var slots []raidSlot
+	for partyIdx, party := range arrangement.parties {
+		for i := range party {
+			slots = append(slots, raidSlot{party: partyIdx, index: i})
+		}
+	}
+	for i := range arrangement.bench {
+		slots = append(slots, raidSlot{party: benchPartyIndex, index: i})
+	}
+	return slots
+}
+
+// The order of parties and of players within a party doesn't matter, so
+// equivalent arrangements share the same key.
+func (arrangement *raidArrangement) key() string {
+	parties := make([]string, len(arrangement.parties))
+	for i, party := range arrangement.parties {
+		sorted := slices.Clone(party)
+		slices.Sort(sorted)
+		parties[i] = fmt.Sprint(sorted)
+	}
+	slices.Sort(parties)
+
+	bench := slices.Clone(arrangement.bench)
+	slices.Sort(bench)
+	return strings.Join(parties, "|") + "/" + fmt.Sprint(bench)
+}
+
+func (arrangement *raidArrangement) ToProto() *proto.RaidArrangement {
+	arrangementProto := &proto.RaidArrangement{}
+	for _, party := range arrangement.parties {
+		group := &proto.RaidArrangement_Group{}
+		for _, rosterIdx := range party {
+			if rosterIdx != emptyRosterIndex {
+				group.RosterIndices = append(group.RosterIndices, int32(rosterIdx))
+			}
+		}
+		arrangementProto.Parties = append(arrangementProto.Parties, group)
+	}
+	for _, rosterIdx := range arrangement.bench {
+		arrangementProto.Bench = append(arrangementProto.Bench, int32(rosterIdx))
+	}
+	return arrangementProto
+}
+
+type raidArrangementResult struct {
+	arrangement *raidArrangement
+	result      *proto.RaidSimResult
+}
+
+func (r *raidArrangementResult) dps() float64 {
+	return r.result.GetRaidMetrics().GetDps().GetAvg()
+}
+
+func sortRaidArrangementResults(results []*raidArrangementResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].dps() > results[j].dps()
+	})
+}
+
+// raidOptimizer searches party assignments of a roster for the highest raid
+// DPS, using short sims to compare arrangements.
+type raidOptimizer struct {
+	// SingleRaidSimRunner used to sim one arrangement.
+	SingleRaidSimRunner raidSimRunner
+	// Request used for this optimization.
+	Request *proto.RaidOptimizerRequest
+
+	roster       []*proto.Player
+	specs        []string
+	baseSettings *proto.RaidSimRequest
+	numParties   int
+
+	searchIterations int32
+	iterations       int32
+	maxArrangements  int
+	numResults       int
+
+	rand *rand.Rand
+
+	simBatchProgress
+}
+
+func RaidOptimizer(ctx context.Context, request *proto.RaidOptimizerRequest, progress chan *proto.ProgressMetrics) *proto.RaidOptimizerResult {
+	optimizer := &raidOptimizer{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+
+	result, err := optimizer.Run(ctx, progress)
+	if err != nil {
+		result = &proto.RaidOptimizerResult{
+			ErrorResult: err.Error(),
+		}
+	}
+
+	sendFinalProgress(progress, &proto.ProgressMetrics{
+		FinalRaidOptimizerResult: result,
+	})
+
+	return result
+}
+
+func (o *raidOptimizer) Run(ctx context.Context, progress chan *proto.ProgressMetrics) (result *proto.RaidOptimizerResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.RaidOptimizerResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if err := o.init(progress); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var searched []*raidArrangementResult
+
+	candidates := o.initialArrangements()
+	for len(candidates) > 0 {
+		var toSim []*raidArrangement
+		for _, candidate := range candidates {
+			if len(searched)+len(toSim) >= o.maxArrangements {
+				break
+			}
+			key := candidate.key()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			toSim = append(toSim, candidate)
+		}
+		if len(toSim) == 0 {
+			break
+		}
+
+		results, err := o.simArrangements(ctx, toSim, o.searchIterations)
+		if err != nil {
+			return nil, err
+		}
+
+		bestDps := math.Inf(-1)
+		if len(searched) > 0 {
+			bestDps = searched[0].dps()
+		}
+		searched = append(searched, results...)
+		sortRaidArrangementResults(searched)
+		if searched[0].dps() <= bestDps {
+			break
+		}
+
+		// Search the neighbours of the best arrangements so far, in a random
+		// order so a limited budget isn't spent on the first parties only.
+		candidates = nil
+		for _, best := range searched[:min(raidOptimizerBeamWidth, len(searched))] {
+			candidates = append(candidates, o.neighbours(best.arrangement)...)
+		}
+		o.rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+	}
+
+	// Sim the best arrangements again with full iterations, for accurate
+	// results and confidence intervals.
+	top := MapSlice(searched[:min(o.numResults, len(searched))], func(r *raidArrangementResult) *raidArrangement {
+		return r.arrangement
+	})
+	// The search usually stops before the budget runs out.
+	o.totalSims = int32(len(searched) + len(top))
+	finalResults, err := o.simArrangements(ctx, top, o.iterations)
+	if err != nil {
+		return nil, err
+	}
+	sortRaidArrangementResults(finalResults)
+
+	result = &proto.RaidOptimizerResult{
+		ArrangementsSimmed: int32(len(searched)),
+	}
+	for _, r := range finalResults {
+		arrangementProto := r.arrangement.ToProto()
+		arrangementProto.Dps = r.result.RaidMetrics.Dps
+		result.Arrangements = append(result.Arrangements, arrangementProto)
+	}
+	return result, nil
+}
+
+func (o *raidOptimizer) init(progress chan *proto.ProgressMetrics) error {
+	if len(o.Request.Roster) == 0 {
+		return errors.New("raid optimizer: roster is empty")
+	}
+	if o.Request.BaseSettings == nil {
+		return errors.New("raid optimizer: missing base settings")
+	}
+
+	o.baseSettings = googleProto.Clone(o.Request.BaseSettings).(*proto.RaidSimRequest)
+	if o.baseSettings.Raid == nil {
+		o.baseSettings.Raid = &proto.Raid{}
+	}
+	if o.baseSettings.SimOptions == nil {
+		o.baseSettings.SimOptions = &proto.SimOptions{}
+	}
+	// All arrangements use the same seed, so they're compared on the same rolls.
+	pinRandomSeed(o.baseSettings.SimOptions)
+	o.baseSettings.SimOptions.Debug = false
+	o.baseSettings.SimOptions.DebugFirstIteration = false
+	o.baseSettings.SimOptions.BuffContributions = false
+
+	for i, player := range o.Request.Roster {
+		if player.GetClass() == proto.Class_ClassUnknown {
+			return fmt.Errorf("raid optimizer: roster player %d has no class", i)
+		}
+		if player.Database != nil {
+			addToDatabase(player.Database)
+		}
+		player = googleProto.Clone(player).(*proto.Player)
+		// clean to reduce memory
+		player.Database = nil
+
+		var refErr error
+		forEachUnitReference(player.ProtoReflect(), func(ref *proto.UnitReference) {
+			if ref.Type == proto.UnitReference_Player && (ref.Index < 0 || int(ref.Index) >= len(o.Request.Roster)) {
+				refErr = fmt.Errorf("raid optimizer: roster player %d refers to player %d, who isn't in the roster", i, ref.Index)
+			}
+		})
+		if refErr != nil {
+			return refErr
+		}
+		o.roster = append(o.roster, player)
+		o.specs = append(o.specs, fmt.Sprintf("%T", player.Spec))
+	}
+
+	o.numParties = int(o.baseSettings.Raid.NumActiveParties)
+	if o.numParties == 0 {
+		o.numParties = min(8, (len(o.roster)+4)/5)
+	}
+	if o.numParties > 8 {
+		return fmt.Errorf("raid optimizer: too many parties (%d > 8)", o.numParties)
+	}
+
+	settings := o.Request.Settings
+	if settings == nil {
+		settings = &proto.RaidOptimizerSettings{}
+	}
+	if settings.RequiredTanks+settings.RequiredHealers > int32(o.numParties*5) {
+		return fmt.Errorf("raid optimizer: %d tanks and %d healers don't fit in %d parties", settings.RequiredTanks, settings.RequiredHealers, o.numParties)
+	}
+	numTanks, numHealers := o.countRoles(o.allRosterIndices())
+	if numTanks < int(settings.RequiredTanks) || numHealers < int(settings.RequiredHealers) {
+		return fmt.Errorf("raid optimizer: roster has %d tanks and %d healers, need %d and %d", numTanks, numHealers, settings.RequiredTanks, settings.RequiredHealers)
+	}
+
+	o.searchIterations = TernaryInt32(settings.SearchIterations > 0, settings.SearchIterations, defaultRaidOptimizerSearchIterations)
+	o.iterations = TernaryInt32(o.baseSettings.SimOptions.Iterations > 0, o.baseSettings.SimOptions.Iterations, defaultRaidOptimizerIterations)
+	o.maxArrangements = int(TernaryInt32(settings.MaxArrangements > 0, settings.MaxArrangements, defaultRaidOptimizerMaxArrangements))
+	o.numResults = int(TernaryInt32(settings.NumResults > 0, settings.NumResults, defaultRaidOptimizerNumResults))
+
+	o.rand = rand.New(NewSplitMix(uint64(o.baseSettings.SimOptions.RandomSeed)))
+
+	o.progress = progress
+	o.totalSims = int32(o.maxArrangements + o.numResults)
+	return nil
+}
+
+func (o *raidOptimizer) allRosterIndices() []int {
+	indices := make([]int, len(o.roster))
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+func (o *raidOptimizer) countRoles(rosterIndices []int) (int, int) {
+	numTanks, numHealers := 0, 0
+	for _, rosterIdx := range rosterIndices {
+		if rosterIdx == emptyRosterIndex {
+			continue
+		}
+		if isTankSpec(o.roster[rosterIdx]) {
+			numTanks++
+		} else if isHealerSpec(o.roster[rosterIdx]) {
+			numHealers++
+		}
+	}
+	return numTanks, numHealers
+}
+
+func (o *raidOptimizer) meetsRoleRequirements(arrangement *raidArrangement) bool {
+	var members []int
+	for _, party := range arrangement.parties {
+		members = append(members, party...)
+	}
+	numTanks, numHealers := o.countRoles(members)
+	return numTanks >= int(o.Request.GetSettings().GetRequiredTanks()) && numHealers >= int(o.Request.GetSettings().GetRequiredHealers())
+}
+
+// Returns the arrangements the search starts from. The required tanks and
+// healers are placed first, then the rest of the roster in order until the
+// raid is full. These players are then either grouped or spread by spec.
+func (o *raidOptimizer) initialArrangements() []*raidArrangement {
+	raidSize := o.numParties * 5
+	picked := make([]bool, len(o.roster))
+	var members, bench []int
+
+	numTanks, numHealers := 0, 0
+	for i, player := range o.roster {
+		if isTankSpec(player) && numTanks < int(o.Request.GetSettings().GetRequiredTanks()) {
+			numTanks++
+		} else if isHealerSpec(player) && numHealers < int(o.Request.GetSettings().GetRequiredHealers()) {
+			numHealers++
+		} else {
+			continue
+		}
+		picked[i] = true
+		members = append(members, i)
+	}
+	for i := range o.roster {
+		if picked[i] {
+			continue
+		}
+		if len(members) < raidSize {
+			members = append(members, i)
+		} else {
+			bench = append(bench, i)
+		}
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return o.specs[members[i]] < o.specs[members[j]]
+	})
+
+	newArrangement := func(place func(memberIdx int) raidSlot) *raidArrangement {
+		arrangement := &raidArrangement{
+			parties: make([][]int, o.numParties),
+			bench:   slices.Clone(bench),
+		}
+		for i := range arrangement.parties {
+			arrangement.parties[i] = []int{emptyRosterIndex, emptyRosterIndex, emptyRosterIndex, emptyRosterIndex, emptyRosterIndex}
+		}
+		for i, rosterIdx := range members {
+			arrangement.set(place(i), rosterIdx)
+		}
+		return arrangement
+	}
+
+	return []*raidArrangement{
+		newArrangement(func(memberIdx int) raidSlot {
+			return raidSlot{party: memberIdx / 5, index: memberIdx % 5}
+		}),
+		newArrangement(func(memberIdx int) raidSlot {
+			return raidSlot{party: memberIdx % o.numParties, index: memberIdx / o.numParties}
+		}),
+	}
+}
+
+// Returns the arrangements which differ from the given one by swapping two
+// players between parties or with the bench, or by moving a player into an
+// empty slot.
+func (o *raidOptimizer) neighbours(arrangement *raidArrangement) []*raidArrangement {
+	var neighbours []*raidArrangement
+
+	slots := arrangement.slots()
+	for i, a := range slots {
+		for _, b := range slots[i+1:] {
+			if a.party == b.party {
+				continue
+			}
+
+			rosterA, rosterB := arrangement.get(a), arrangement.get(b)
+			// Swapping players of the same spec mostly moves gear around
+			// rather than changing party buffs, so isn't worth a sim.
+			if o.specOf(rosterA) == o.specOf(rosterB) {
+				continue
+			}
+
+			neighbour := arrangement.clone()
+			neighbour.set(a, rosterB)
+			neighbour.set(b, rosterA)
+			if (a.party == benchPartyIndex || b.party == benchPartyIndex) && !o.meetsRoleRequirements(neighbour) {
+				continue
+			}
+			neighbours = append(neighbours, neighbour)
+		}
+	}
+
+	return neighbours
+}
+
+func (o *raidOptimizer) specOf(rosterIdx int) string {
+	if rosterIdx == emptyRosterIndex {
+		return ""
+	}
+	return o.specs[rosterIdx]
+}
+
+func (o *raidOptimizer) raidSimRequest(arrangement *raidArrangement, iterations int32) *proto.RaidSimRequest {
+	request := googleProto.Clone(o.baseSettings).(*proto.RaidSimRequest)
+	request.SimOptions.Iterations = iterations
+
+	// Players are packed at the front of their party, so their raid index
+	// depends on who else is in the party.
+	raidIndices := make(map[int32]int32, len(o.roster))
+	for partyIdx, party := range arrangement.parties {
+		numPlayers := 0
+		for _, rosterIdx := range party {
+			if rosterIdx != emptyRosterIndex {
+				raidIndices[int32(rosterIdx)] = int32(partyIdx*5 + numPlayers)
+				numPlayers++
+			}
+		}
+	}
+
+	raid := request.Raid
+	parties := make([]*proto.Party, len(arrangement.parties))
+	raid.Tanks = nil
+	for partyIdx, party := range arrangement.parties {
+		parties[partyIdx] = &proto.Party{}
+		if partyIdx < len(raid.Parties) && raid.Parties[partyIdx] != nil {
+			parties[partyIdx].Buffs = raid.Parties[partyIdx].Buffs
+		}
+
+		for _, rosterIdx := range party {
+			if rosterIdx == emptyRosterIndex {
+				continue
+			}
+			player := googleProto.Clone(o.roster[rosterIdx]).(*proto.Player)
+			remapPlayerReferences(player, raidIndices)
+			if isTankSpec(player) {
+				raid.Tanks = append(raid.Tanks, &proto.UnitReference{
+					Type:  proto.UnitReference_Player,
+					Index: int32(partyIdx*5 + len(parties[partyIdx].Players)),
+				})
+			}
+			parties[partyIdx].Players = append(parties[partyIdx].Players, player)
+		}
+	}
+	raid.Parties = parties
+	raid.NumActiveParties = int32(len(parties))
+
+	return request
+}
+
+// Changes the player's references to other players, e.g. Innervate targets,
+// from roster indices to raid indices. References to benched players are
+// cleared.
+func remapPlayerReferences(player *proto.Player, raidIndices map[int32]int32) {
+	forEachUnitReference(player.ProtoReflect(), func(ref *proto.UnitReference) {
+		if ref.Type != proto.UnitReference_Player {
+			return
+		}
+		if raidIndex, ok := raidIndices[ref.Index]; ok {
+			ref.Index = raidIndex
+		} else {
+			ref.Type = proto.UnitReference_Unknown
+			ref.Index = 0
+		}
+	})
+}
+
+// Calls fn with every UnitReference in the message, including pet owners.
+func forEachUnitReference(message protoreflect.Message, fn func(ref *proto.UnitReference)) {
+	if ref, ok := message.Interface().(*proto.UnitReference); ok {
+		fn(ref)
+	}
+	message.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				value.Map().Range(func(_ protoreflect.MapKey, mapValue protoreflect.Value) bool {
+					forEachUnitReference(mapValue.Message(), fn)
+					return true
+				})
+			}
+		case fd.Kind() != protoreflect.MessageKind:
+		case fd.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				forEachUnitReference(list.Get(i).Message(), fn)
+			}
+		default:
+			forEachUnitReference(value.Message(), fn)
+		}
+		return true
+	})
+}
+
+func (o *raidOptimizer) simArrangements(ctx context.Context, arrangements []*raidArrangement, iterations int32) ([]*raidArrangementResult, error) {
+	requests := MapSlice(arrangements, func(arrangement *raidArrangement) *proto.RaidSimRequest {
+		return o.raidSimRequest(arrangement, iterations)
+	})
+
+	simResults, err := runSimBatch(ctx, o.SingleRaidSimRunner, requests, o.onSimDone)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*raidArrangementResult, len(arrangements))
+	for i, arrangement := range arrangements {
+		results[i] = &raidArrangementResult{
+			arrangement: arrangement,
+			result:      simResults[i],
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestRaidOptimizerGroupsPartyBuffs(t *testing.T) {
+	shaman := func(name string) *proto.Player {
+		return &proto.Player{Name: name, Class: proto.Class_ClassShaman, Spec: &proto.Player_EnhancementShaman{EnhancementShaman: &proto.EnhancementShaman{}}}
+	}
+	warrior := func(name string) *proto.Player {
+		return &proto.Player{Name: name, Class: proto.Class_ClassWarrior, Spec: &proto.Player_Warrior{Warrior: &proto.Warrior{}}}
+	}
+	mage := func(name string) *proto.Player {
+		return &proto.Player{Name: name, Class: proto.Class_ClassMage, Spec: &proto.Player_Mage{Mage: &proto.Mage{}}}
+	}
+	tank := &proto.Player{Name: "Tank", Class: proto.Class_ClassWarrior, Spec: &proto.Player_TankWarrior{TankWarrior: &proto.TankWarrior{}}}
+
+	request := &proto.RaidOptimizerRequest{
+		Roster: []*proto.Player{
+			mage("Mage 1"), warrior("Warrior 1"), mage("Mage 2"), warrior("Warrior 2"), shaman("Shaman"),
+			mage("Mage 3"), warrior("Warrior 3"), mage("Mage 4"), warrior("Warrior 4"), tank, mage("Mage 5"),
+		},
+		BaseSettings: &proto.RaidSimRequest{
+			Raid:       &proto.Raid{NumActiveParties: 2},
+			SimOptions: &proto.SimOptions{Iterations: 10, RandomSeed: 1},
+		},
+		Settings: &proto.RaidOptimizerSettings{
+			RequiredTanks: 1,
+			NumResults:    3,
+		},
+	}
+
+	// Fake sim where the shaman buffs the warriors in its party.
+	optimizer := &raidOptimizer{
+		SingleRaidSimRunner: fakeRaidSimRunner(func(rsr *proto.RaidSimRequest) *proto.DistributionMetrics {
+			dps := 0.0
+			for _, party := range rsr.Raid.Parties {
+				numShamans, numWarriors := 0, 0
+				for _, player := range party.Players {
+					dps += 100
+					switch player.Spec.(type) {
+					case *proto.Player_EnhancementShaman:
+						numShamans++
+					case *proto.Player_Warrior:
+						numWarriors++
+					}
+				}
+				dps += float64(10 * numShamans * numWarriors)
+			}
+			if len(rsr.Raid.Tanks) != 1 {
+				dps = 0
+			}
+			return &proto.DistributionMetrics{Avg: dps}
+		}),
+		Request: request,
+	}
+
+	result, err := optimizer.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if result.ErrorResult != "" {
+		t.Fatalf("Run() failed: %s", result.ErrorResult)
+	}
+	if len(result.Arrangements) != 3 {
+		t.Fatalf("Expected 3 arrangements, got %d", len(result.Arrangements))
+	}
+
+	best := result.Arrangements[0]
+	if best.Dps.Avg != 1040 {
+		t.Errorf("Expected the shaman grouped with all 4 warriors for 1040 DPS, got %0.0f", best.Dps.Avg)
+	}
+	if len(best.Bench) != 1 {
+		t.Errorf("Expected 1 benched player, got %d", len(best.Bench))
+	}
+	for _, rosterIdx := range best.Bench {
+		if rosterIdx == 9 {
+			t.Errorf("The required tank shouldn't be benched")
+		}
+	}
+}
+
+func TestRaidArrangementKey(t *testing.T) {
+	a := &raidArrangement{parties: [][]int{{0, 1, 2, 3, 4}, {5, 6, 7, 8, 9}}, bench: []int{10, 11}}
+	b := &raidArrangement{parties: [][]int{{9, 8, 7, 6, 5}, {4, 3, 2, 1, 0}}, bench: []int{11, 10}}
+	c := &raidArrangement{parties: [][]int{{0, 1, 2, 3, 5}, {4, 6, 7, 8, 9}}, bench: []int{10, 11}}
+
+	if a.key() != b.key() {
+		t.Errorf("Arrangements with the same groups should have the same key")
+	}
+	if a.key() == c.key() {
+		t.Errorf("Arrangements with different groups should have different keys")
+	}
+}
+
+func TestRaidOptimizerRemapsPlayerReferences(t *testing.T) {
+	druid := func(name string, innervateTarget int32) *proto.Player {
+		return &proto.Player{Name: name, Class: proto.Class_ClassDruid, Spec: &proto.Player_BalanceDruid{BalanceDruid: &proto.BalanceDruid{
+			Options: &proto.BalanceDruid_Options{
+				InnervateTarget: &proto.UnitReference{Type: proto.UnitReference_Player, Index: innervateTarget},
+			},
+		}}}
+	}
+	mage := &proto.Player{Name: "Mage", Class: proto.Class_ClassMage, Spec: &proto.Player_Mage{Mage: &proto.Mage{}}}
+
+	optimizer := &raidOptimizer{
+		baseSettings: &proto.RaidSimRequest{Raid: &proto.Raid{}, SimOptions: &proto.SimOptions{}},
+		roster:       []*proto.Player{druid("Druid 1", 2), druid("Druid 2", 3), mage, mage},
+	}
+	arrangement := &raidArrangement{
+		parties: [][]int{{emptyRosterIndex, 0, 1, emptyRosterIndex, emptyRosterIndex}, {emptyRosterIndex, 2, emptyRosterIndex, emptyRosterIndex, emptyRosterIndex}},
+		bench:   []int{3},
+	}
+
+	raid := optimizer.raidSimRequest(arrangement, 10).Raid
+	innervateTarget := func(player *proto.Player) *proto.UnitReference {
+		return player.Spec.(*proto.Player_BalanceDruid).BalanceDruid.Options.InnervateTarget
+	}
+	if target := innervateTarget(raid.Parties[0].Players[0]); target.Type != proto.UnitReference_Player || target.Index != 5 {
+		t.Errorf("Expected Innervate on raid index 5, got %v", target)
+	}
+	if target := innervateTarget(raid.Parties[0].Players[1]); target.Type != proto.UnitReference_Unknown {
+		t.Errorf("Expected Innervate on a benched player to be cleared, got %v", target)
+	}
+	if target := innervateTarget(optimizer.roster[0]); target.Index != 2 {
+		t.Errorf("Expected the roster to keep roster indices, got %v", target)
+	}
+
+	invalid := &raidOptimizer{
+		Request: &proto.RaidOptimizerRequest{
+			Roster:       []*proto.Player{druid("Druid", 1)},
+			BaseSettings: &proto.RaidSimRequest{},
+		},
+	}
+	if err := invalid.init(nil); err == nil {
+		t.Errorf("Expected an error for a reference to a player who isn't in the roster")
+	}
+}
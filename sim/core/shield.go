@@ -1,12 +1,21 @@
 package core
 
-import "strconv"
+import (
+	"slices"
+	"strconv"
+)
 
 type ShieldConfig struct {
 	SelfOnly bool // Set to true to only create the self-shield.
 
 	Spell *Spell
 
+	// Schools of damage this shield can absorb. SpellSchoolNone absorbs all schools.
+	School SpellSchool
+
+	// Order in which shields on the same unit are consumed, lowest first.
+	Priority int32
+
 	Aura
 }
 
@@ -14,6 +23,12 @@ type ShieldConfig struct {
 type Shield struct {
 	Spell *Spell
 
+	School   SpellSchool
+	Priority int32
+
+	// Amount of damage this shield can still absorb.
+	remaining float64
+
 	// Embed Aura so we can use IsActive/Refresh/etc directly.
 	*Aura
 }
@@ -21,22 +36,37 @@ type Shield struct {
 func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 	caster := shield.Spell.Unit
 	target := shield.Aura.Unit
-	//attackTable := caster.AttackTables[target.UnitIndex][shield.Spell.CastType]
 
 	// Shields are not affected by healing pseudostats the same way heals are.
 	// So we only apply the spell-specific multiplier.
 	shieldAmount *= shield.Spell.DamageMultiplier
 
+	// Whatever is left of an overwritten shield is wasted.
+	shield.wasteRemaining()
 	shield.Aura.Deactivate(sim)
+	shield.remaining = shieldAmount
 	shield.Aura.Activate(sim)
 
-	threat := 0.0 // TODO
-	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
-	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
 	shield.Spell.SpellMetrics[target.UnitIndex].Hits++
 
 	if sim.Log != nil {
-		caster.Log(sim, "%s %s Hit for %0.3f shielding. (Threat: %0.3f)", target.LogLabel(), shield.Spell.ActionID, shieldAmount, threat)
+		caster.Log(sim, "%s %s Hit for %0.3f shielding.", target.LogLabel(), shield.Spell.ActionID, shieldAmount)
+	}
+}
+
+// Returns the amount of damage this shield can still absorb.
+func (shield *Shield) Remaining() float64 {
+	return shield.remaining
+}
+
+func (shield *Shield) canAbsorb(spell *Spell) bool {
+	return shield.remaining > 0 && shield.Aura.IsActive() && (shield.School == SpellSchoolNone || spell.SpellSchool.Matches(shield.School))
+}
+
+func (shield *Shield) wasteRemaining() {
+	if shield.remaining > 0 {
+		shield.Spell.SpellMetrics[shield.Aura.Unit.UnitIndex].TotalShieldingWasted += shield.remaining
+		shield.remaining = 0
 	}
 }
 
@@ -44,6 +74,16 @@ func newShield(config Shield) *Shield {
 	shield := &Shield{}
 	*shield = config
 
+	shield.Aura.ApplyOnExpire(func(aura *Aura, sim *Simulation) {
+		shield.wasteRemaining()
+	})
+
+	unit := shield.Aura.Unit
+	unit.shields = append(unit.shields, shield)
+	slices.SortStableFunc(unit.shields, func(a, b *Shield) int {
+		return int(a.Priority - b.Priority)
+	})
+
 	return shield
 }
 
@@ -62,7 +102,9 @@ func (spell *Spell) createShields(config ShieldConfig) {
 		config.Spell = spell
 	}
 	shield := Shield{
-		Spell: config.Spell,
+		Spell:    config.Spell,
+		School:   config.School,
+		Priority: config.Priority,
 	}
 
 	auraConfig := config.Aura
@@ -87,3 +129,37 @@ func (spell *Spell) createShields(config ShieldConfig) {
 		}
 	}
 }
+
+// Consumes active shields on this unit, in priority order, to absorb as much of
+// the incoming damage as they can before it is applied.
+func (unit *Unit) absorbDamage(sim *Simulation, spell *Spell, result *SpellResult) {
+	for _, shield := range unit.shields {
+		if result.Damage <= 0 {
+			return
+		}
+		if !shield.canAbsorb(spell) {
+			continue
+		}
+
+		absorbed := min(shield.remaining, result.Damage)
+		shield.remaining -= absorbed
+		result.Damage -= absorbed
+		result.Absorbed += absorbed
+
+		// Absorbs generate threat for the caster of the shield like healing does.
+		caster := shield.Spell.Unit
+		threat := absorbed * shield.Spell.ThreatMultiplier * caster.PseudoStats.ThreatMultiplier
+		shield.Spell.SpellMetrics[unit.UnitIndex].TotalShielding += absorbed
+		shield.Spell.SpellMetrics[unit.UnitIndex].TotalThreat += threat
+		caster.addSplitThreat(sim, threat)
+		caster.Metrics.addBreakdownHealing(sim, shield.Spell, false, absorbed)
+
+		if sim.Log != nil {
+			caster.Log(sim, "%s %s absorbed %0.3f damage from %s, %0.3f remaining. (Threat: %0.3f)", unit.LogLabel(), shield.Spell.ActionID, absorbed, spell.ActionID, shield.remaining, threat)
+		}
+
+		if shield.remaining <= 0 {
+			shield.Aura.Deactivate(sim)
+		}
+	}
+}
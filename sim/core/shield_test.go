@@ -0,0 +1,137 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func registerTestShield(unit *Unit, spellID int32, school SpellSchool, priority int32) *Shield {
+	spell := unit.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: spellID},
+		SpellSchool:      SpellSchoolFrost,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: ShieldConfig{
+			SelfOnly: true,
+			School:   school,
+			Priority: priority,
+			Aura: Aura{
+				Label:    fmt.Sprintf("Test Shield %d", spellID),
+				Duration: time.Second * 10,
+			},
+		},
+	})
+	return spell.SelfShield()
+}
+
+func registerTestAttack(unit *Unit, school SpellSchool) *Spell {
+	return unit.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: 1000 + int32(school)},
+		SpellSchool:      school,
+		ProcMask:         ProcMaskSpellDamage,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+}
+
+func dealTestDamage(sim *Simulation, attack *Spell, target *Unit, damage float64) *SpellResult {
+	result := attack.NewResult(target)
+	result.Outcome = OutcomeHit
+	result.Damage = damage
+	attack.DealDamage(sim, result)
+	return result
+}
+
+func setupShieldTest() (*Simulation, *Unit, *Unit) {
+	sim := SetupFakeSim()
+	return sim, &sim.Raid.Parties[0].Players[0].GetCharacter().Unit, sim.Encounter.TargetUnits[0]
+}
+
+func TestShieldAbsorbOrder(t *testing.T) {
+	sim, unit, enemy := setupShieldTest()
+	second := registerTestShield(unit, 1, SpellSchoolNone, 2)
+	first := registerTestShield(unit, 2, SpellSchoolNone, 1)
+	attack := registerTestAttack(enemy, SpellSchoolShadow)
+	sim.Reset()
+
+	second.Apply(sim, 50)
+	first.Apply(sim, 50)
+
+	result := dealTestDamage(sim, attack, unit, 70)
+	if result.Damage != 0 || result.Absorbed != 70 {
+		t.Fatalf("Expected all 70 damage to be absorbed, got %0.1f damage and %0.1f absorbed", result.Damage, result.Absorbed)
+	}
+	if first.Remaining() != 0 || first.IsActive() {
+		t.Errorf("Expected the lower priority shield to be used up first, %0.1f remaining", first.Remaining())
+	}
+	if second.Remaining() != 30 || !second.IsActive() {
+		t.Errorf("Expected 30 remaining on the higher priority shield, got %0.1f", second.Remaining())
+	}
+}
+
+func TestShieldPartialAbsorb(t *testing.T) {
+	sim, unit, enemy := setupShieldTest()
+	shield := registerTestShield(unit, 1, SpellSchoolNone, 0)
+	attack := registerTestAttack(enemy, SpellSchoolShadow)
+	sim.Reset()
+
+	shield.Apply(sim, 50)
+
+	result := dealTestDamage(sim, attack, unit, 80)
+	if result.Damage != 30 || result.Absorbed != 50 {
+		t.Fatalf("Expected 30 damage and 50 absorbed, got %0.1f damage and %0.1f absorbed", result.Damage, result.Absorbed)
+	}
+	if shield.IsActive() {
+		t.Errorf("Expected the shield to fade once it's used up")
+	}
+
+	metrics := attack.SpellMetrics[unit.UnitIndex]
+	if metrics.TotalDamage != 80 || metrics.TotalAbsorbedDamage != 50 {
+		t.Errorf("Expected 80 damage with 50 absorbed for the attacker, got %0.1f damage with %0.1f absorbed", metrics.TotalDamage, metrics.TotalAbsorbedDamage)
+	}
+	if shielding := shield.Spell.SpellMetrics[unit.UnitIndex].TotalShielding; shielding != 50 {
+		t.Errorf("Expected 50 shielding, got %0.1f", shielding)
+	}
+}
+
+func TestShieldSchool(t *testing.T) {
+	sim, unit, enemy := setupShieldTest()
+	shield := registerTestShield(unit, 1, SpellSchoolFire, 0)
+	attack := registerTestAttack(enemy, SpellSchoolShadow)
+	sim.Reset()
+
+	shield.Apply(sim, 50)
+
+	if result := dealTestDamage(sim, attack, unit, 40); result.Absorbed != 0 {
+		t.Errorf("Expected a fire shield not to absorb shadow damage, %0.1f absorbed", result.Absorbed)
+	}
+	if shield.Remaining() != 50 {
+		t.Errorf("Expected the shield to be untouched, %0.1f remaining", shield.Remaining())
+	}
+}
+
+func TestShieldExpiry(t *testing.T) {
+	sim, unit, enemy := setupShieldTest()
+	shield := registerTestShield(unit, 1, SpellSchoolNone, 0)
+	attack := registerTestAttack(enemy, SpellSchoolShadow)
+	sim.Reset()
+
+	shield.Apply(sim, 50)
+	dealTestDamage(sim, attack, unit, 20)
+
+	sim.advance(sim.CurrentTime + time.Second*11)
+	if shield.IsActive() {
+		t.Fatalf("Expected the shield to expire")
+	}
+
+	metrics := shield.Spell.SpellMetrics[unit.UnitIndex]
+	if metrics.TotalShielding != 20 || metrics.TotalShieldingWasted != 30 {
+		t.Errorf("Expected 20 shielding and 30 wasted, got %0.1f and %0.1f", metrics.TotalShielding, metrics.TotalShieldingWasted)
+	}
+
+	if result := dealTestDamage(sim, attack, unit, 20); result.Absorbed != 0 {
+		t.Errorf("Expected an expired shield not to absorb damage, %0.1f absorbed", result.Absorbed)
+	}
+}
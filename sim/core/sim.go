@@ -43,6 +43,8 @@ type Simulation struct {
 
 	executePhase int32 // 20, 25, or 35 for the respective execute range, 100 otherwise
 
+	executePhaseStarts [numExecutePhases]time.Duration // When each execute phase was reached this iteration.
+
 	executePhaseCallbacks []func(*Simulation, int32) // 2nd parameter is 35 for 35%, 25 for 25% and 20 for 20%
 
 	nextExecuteDuration time.Duration
@@ -58,6 +60,9 @@ type Simulation struct {
 	weaponAttacks       []*WeaponAttack
 	extraAttacks int32
 
+	// Raid DPS without each player's provided buffs, if requested.
+	buffCounterfactuals []buffCounterfactual
+
 	minTaskTime time.Duration
 	tasks       []Task
 }
@@ -137,6 +142,19 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}()
 	}
 
+	if err := validateRaidSimRequest(rsr); err != nil {
+		result = &proto.RaidSimResult{
+			ErrorResult: err.Error(),
+		}
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{
+				FinalRaidResult: result,
+			}
+		}
+		return result
+	}
+
+	rsr = withBuffContributionSeed(rsr)
 	sim := NewSim(rsr)
 
 	if !skipPresim {
@@ -175,12 +193,41 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}
 	}
 
+	if rsr.SimOptions.BuffContributions {
+		if errorResult := sim.runBuffCounterfactuals(rsr, skipPresim); errorResult != nil {
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					TotalIterations: sim.Options.Iterations,
+					FinalRaidResult: errorResult,
+				}
+			}
+			return errorResult
+		}
+	}
+
 	// using a variable here allows us to mutate it in the deferred recover, sending out error info
 	result = sim.run()
 
 	return result
 }
 
+// Checks the parts of a request which would otherwise only fail deep inside the sim.
+func validateRaidSimRequest(rsr *proto.RaidSimRequest) error {
+	if rsr.Encounter != nil {
+		if err := validateDurationDistribution(rsr.Encounter); err != nil {
+			return err
+		}
+	}
+	for _, party := range rsr.GetRaid().GetParties() {
+		for _, player := range party.GetPlayers() {
+			if err := validateDamageProfile(player.GetDamageProfile()); err != nil {
+				return fmt.Errorf("%s: %w", player.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
 func NewSim(rsr *proto.RaidSimRequest) *Simulation {
 	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
 	return newSimWithEnv(env, rsr.SimOptions)
@@ -247,6 +294,11 @@ func (sim *Simulation) RandomExpFloat(label string) float64 {
 	return rand.New(sim.labelRand(label)).ExpFloat64()
 }
 
+// Returns a standard normally distributed float64.
+func (sim *Simulation) RandomNormFloat(label string) float64 {
+	return rand.New(sim.labelRand(label)).NormFloat64()
+}
+
 // Shorthand for commonly-used RNG behavior.
 // Returns a random number between min and max.
 func (sim *Simulation) Roll(min float64, max float64) float64 {
@@ -305,17 +357,18 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	}
 
 	var st time.Time
-	for i := int32(1); i < sim.Options.Iterations; i++ {
-		// fmt.Printf("Iteration: %d\n", i)
+	iterations := int32(1)
+	for ; iterations < sim.Options.Iterations; iterations++ {
+		// fmt.Printf("Iteration: %d\n", iterations)
 		if sim.ProgressReport != nil && time.Since(st) > time.Millisecond*100 {
 			metrics := sim.Raid.GetMetrics()
-			sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: i, Dps: metrics.Dps.Avg, Hps: metrics.Hps.Avg})
+			sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: iterations, Dps: metrics.Dps.Avg, Hps: metrics.Hps.Avg})
 			runtime.Gosched() // ensure that reporting threads are given time to report, mostly only important in wasm (only 1 thread)
 			st = time.Now()
 		}
 
 		// Before each iteration, reset state to seed+iterations
-		sim.reseedRands(int64(i))
+		sim.reseedRands(int64(iterations))
 
 		sim.runOnce()
 		iterDuration := sim.Duration
@@ -323,6 +376,11 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 			iterDuration = sim.CurrentTime
 		}
 		totalDuration += iterDuration
+
+		if sim.Options.TargetError > 0 && sim.Raid.hasConverged(sim.Options.TargetError) {
+			iterations++
+			break
+		}
 	}
 	result := &proto.RaidSimResult{
 		RaidMetrics:      sim.Raid.GetMetrics(),
@@ -330,15 +388,17 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 		Logs:                   logsBuffer.String(),
 		FirstIterationDuration: firstIterationDuration.Seconds(),
-		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
+		AvgIterationDuration:   totalDuration.Seconds() / float64(iterations),
+		Iterations:             iterations,
 	}
+	result.RaidMetrics.BuffContributions = sim.getBuffContributionsProto(result.RaidMetrics.Dps.Avg)
 
 	// Final progress report
 	if sim.ProgressReport != nil {
-		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
+		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: iterations, CompletedIterations: iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
 	}
 
-	if d := sim.Options.Iterations; d > 3000 {
+	if d := iterations; d > 3000 {
 		log.Printf("running %d iterations took %s", d, time.Since(t0))
 	}
 
@@ -369,11 +429,7 @@ func (sim *Simulation) reset() {
 		sim.BaseDuration = sim.CurrentTime
 		sim.Encounter.DurationIsEstimate = false
 	}
-	sim.Duration = sim.BaseDuration
-	if sim.DurationVariation != 0 {
-		variation := sim.DurationVariation * 2
-		sim.Duration += time.Duration(sim.RandomFloat("sim duration")*float64(variation)) - sim.DurationVariation
-	}
+	sim.Duration = sim.rollDuration()
 
 	sim.pendingActions = sim.pendingActions[:0]
 	sim.pendingActions = append(sim.pendingActions, sentinelPendingAction)
@@ -391,6 +447,7 @@ func (sim *Simulation) reset() {
 	}
 
 	sim.CurrentTime = 0
+	sim.resetExecutePhaseStarts()
 
 	sim.trackers = sim.trackers[:0]
 	sim.minTrackerTime = NeverExpires
@@ -404,6 +461,7 @@ func (sim *Simulation) reset() {
 	sim.Environment.reset(sim)
 
 	sim.initManaTickAction()
+	sim.initTimelineAction()
 }
 
 func (sim *Simulation) PrePull() {
@@ -433,6 +491,10 @@ func (sim *Simulation) PrePull() {
 }
 
 func (sim *Simulation) Cleanup() {
+	if sim.Options.TimelineBinSeconds > 0 {
+		sim.finishTimelines()
+	}
+
 	// The last event loop will leave CurrentTime at some value close to but not
 	// quite at the Duration. Explicitly set this so that accesses to CurrentTime
 	// during the doneIteration phase will return the Duration value, which is
@@ -535,6 +597,7 @@ func (sim *Simulation) advance(nextTime time.Duration) {
 	// execute phases 35%, 25%, and 20% in the first advance() call.
 	for sim.CurrentTime >= sim.nextExecuteDuration || sim.Encounter.DamageTaken >= sim.nextExecuteDamage {
 		sim.nextExecutePhase()
+		sim.executePhaseStarts[executePhaseIndex(sim.executePhase)] = sim.CurrentTime
 		for _, callback := range sim.executePhaseCallbacks {
 			callback(sim, sim.executePhase)
 		}
@@ -554,6 +617,8 @@ func (sim *Simulation) nextExecutePhase() {
 		sim.executePhase = phase
 		if sim.Encounter.EndFightAtHealth > 0 {
 			sim.nextExecuteDamage = (1 - damage) * sim.Encounter.EndFightAtHealth
+		} else if sim.Encounter.FixedExecuteDuration {
+			sim.nextExecuteDuration = max(sim.Duration-time.Duration(health*float64(sim.BaseDuration)), 0)
 		} else {
 			sim.nextExecuteDuration = time.Duration((1 - health) * float64(sim.Duration))
 		}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Runs one raid sim per request concurrently, and returns the results in the
+// same order as the requests. onDone, if set, is called after each sim.
+func runSimBatch(ctx context.Context, runner raidSimRunner, requests []*proto.RaidSimRequest, onDone func()) ([]*proto.RaidSimResult, error) {
+	concurrency := runtime.NumCPU()
+	if concurrency <= 0 {
+		concurrency = 2
+	}
+	tickets := make(chan struct{}, concurrency)
+
+	results := make([]*proto.RaidSimResult, len(requests))
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		if ctx.Err() != nil {
+			break
+		}
+
+		tickets <- struct{}{}
+		wg.Add(1)
+		go func(i int, request *proto.RaidSimRequest) {
+			defer func() {
+				if err := recover(); err != nil {
+					results[i] = &proto.RaidSimResult{
+						ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+					}
+				}
+				<-tickets
+				wg.Done()
+			}()
+
+			results[i] = runner(request, nil, false)
+			if onDone != nil {
+				onDone()
+			}
+		}(i, request)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result == nil || result.ErrorResult != "" {
+			return nil, errors.New("simulation failed: " + result.GetErrorResult())
+		}
+	}
+	return results, nil
+}
+
+// Progress of an optimizer which runs batches of raid sims. Optimizers embed
+// it, set progress and totalSims, and pass onSimDone to runSimBatch.
+type simBatchProgress struct {
+	progress      chan *proto.ProgressMetrics
+	completedSims int32
+	totalSims     int32
+}
+
+func (p *simBatchProgress) onSimDone() {
+	completedSims := atomic.AddInt32(&p.completedSims, 1)
+	if p.progress != nil {
+		p.progress <- &proto.ProgressMetrics{
+			TotalSims:     p.totalSims,
+			CompletedSims: completedSims,
+		}
+	}
+}
+
+// Sends an optimizer's final result, then closes the progress channel.
+func sendFinalProgress(progress chan *proto.ProgressMetrics, final *proto.ProgressMetrics) {
+	if progress != nil {
+		progress <- final
+		close(progress)
+	}
+}
+
+// Pins the seed, so that every sim in a batch uses the same rolls and can be
+// compared to the others.
+func pinRandomSeed(simOptions *proto.SimOptions) {
+	if simOptions.RandomSeed == 0 {
+		simOptions.RandomSeed = time.Now().UnixNano()
+	}
+}
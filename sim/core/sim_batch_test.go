@@ -0,0 +1,44 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Returns a SingleRaidSimRunner which doesn't sim, and reports the DPS from
+// the request as both the raid's DPS and the first player's DPS.
+func fakeRaidSimRunner(dps func(request *proto.RaidSimRequest) *proto.DistributionMetrics) raidSimRunner {
+	return func(request *proto.RaidSimRequest, _ chan *proto.ProgressMetrics, _ bool) *proto.RaidSimResult {
+		metrics := dps(request)
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{
+				Dps:     metrics,
+				Parties: []*proto.PartyMetrics{{Players: []*proto.UnitMetrics{{Dps: metrics}}}},
+			},
+		}
+	}
+}
+
+func TestSimBatchRecoversFromPanics(t *testing.T) {
+	runner := fakeRaidSimRunner(func(request *proto.RaidSimRequest) *proto.DistributionMetrics {
+		if request.SimOptions.RandomSeed == 2 {
+			panic("test panic")
+		}
+		return &proto.DistributionMetrics{Avg: 100}
+	})
+	requests := []*proto.RaidSimRequest{
+		{SimOptions: &proto.SimOptions{RandomSeed: 1}},
+		{SimOptions: &proto.SimOptions{RandomSeed: 2}},
+	}
+
+	results, err := runSimBatch(context.Background(), runner, requests, nil)
+	if err == nil || !strings.Contains(err.Error(), "test panic") {
+		t.Fatalf("Expected the panic to be returned as an error, got %v", err)
+	}
+	if results != nil {
+		t.Errorf("Expected no results, got %v", results)
+	}
+}
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// The ui directory, or at least each spec's presets.gen.json, gear_sets and apls.
+// Binaries which use presets set this (e.g. to ui.PresetFiles), so the files
+// aren't embedded in every build.
+var PresetFiles fs.FS
+
+// A named set of gear, rotation, talents, consumes and spec options for a spec,
+// matching one of the presets in the spec's UI. Gear, rotation, talents and
+// consumes are read from the spec's UI directory the first time they're used.
+type SpecPreset struct {
+	Name  string
+	Spec  proto.Spec
+	Class proto.Class
+	Race  proto.Race
+	Phase int32
+	Level int32
+
+	// File in gear_sets, without the .gear.json extension.
+	GearSet string
+	// File in apls, without the .apl.json extension.
+	Rotation string
+	// Names of the talents and consumes presets exported by presets.ts, as
+	// written to presets.gen.json by vite.build-presets.ts.
+	Talents  string
+	Consumes string
+
+	// One of the proto.Player_<Spec> types, as passed to WithSpec.
+	SpecOptions interface{}
+
+	loadOnce      sync.Once
+	gearSet       *proto.EquipmentSpec
+	rotation      *proto.APLRotation
+	talentsString string
+	consumes      *proto.Consumes
+}
+
+var specPresets []*SpecPreset
+
+// Adds presets to the registry, so they can be used from the CLI and API.
+func RegisterSpecPresets(presets ...*SpecPreset) {
+	for _, preset := range presets {
+		if preset.Name == "" || preset.GearSet == "" || preset.Rotation == "" || preset.Talents == "" || preset.Consumes == "" || preset.SpecOptions == nil {
+			panic(fmt.Sprintf("Spec preset %s for %s is missing gear, rotation, talents, consumes or spec options", preset.Name, SpecName(preset.Spec)))
+		}
+		if GetSpecPreset(preset.Spec, preset.Name) != nil {
+			panic(fmt.Sprintf("Already registered spec preset %s for %s", preset.Name, SpecName(preset.Spec)))
+		}
+		specPresets = append(specPresets, preset)
+	}
+}
+
+// Returns the registered presets for a spec, in registration order.
+func GetSpecPresets(spec proto.Spec) []*SpecPreset {
+	return FilterSlice(specPresets, func(preset *SpecPreset) bool {
+		return preset.Spec == spec
+	})
+}
+
+func GetSpecPreset(spec proto.Spec, name string) *SpecPreset {
+	for _, preset := range specPresets {
+		if preset.Spec == spec && preset.Name == name {
+			return preset
+		}
+	}
+	return nil
+}
+
+// Returns the snake case name of a spec, which is also the name of its UI
+// directory, e.g. feral_druid for SpecFeralDruid.
+func SpecName(spec proto.Spec) string {
+	var sb strings.Builder
+	for i, r := range strings.TrimPrefix(spec.String(), "Spec") {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Returns the spec with the given SpecName.
+func SpecFromName(name string) (proto.Spec, bool) {
+	for value := range proto.Spec_name {
+		if spec := proto.Spec(value); SpecName(spec) == name {
+			return spec, true
+		}
+	}
+	return 0, false
+}
+
+// Talents and consumes presets of a spec, as written by vite.build-presets.ts.
+type uiPresets struct {
+	Talents  map[string]string          `json:"talents"`
+	Consumes map[string]json.RawMessage `json:"consumes"`
+}
+
+func (preset *SpecPreset) load() {
+	preset.loadOnce.Do(func() {
+		if PresetFiles == nil {
+			panic("Preset files are not available in this build")
+		}
+		specDir := SpecName(preset.Spec)
+
+		preset.gearSet = EquipmentSpecFromJsonString(readPresetFile(specDir + "/gear_sets/" + preset.GearSet + ".gear.json"))
+		preset.rotation = APLRotationFromJsonString(readPresetFile(specDir + "/apls/" + preset.Rotation + ".apl.json"))
+
+		var presets uiPresets
+		if err := json.Unmarshal([]byte(readPresetFile(specDir+"/presets.gen.json")), &presets); err != nil {
+			panic(fmt.Sprintf("Failed to parse %s/presets.gen.json: %s", specDir, err))
+		}
+
+		talentsString, ok := presets.Talents[preset.Talents]
+		if !ok {
+			panic(fmt.Sprintf("Spec preset %s for %s: presets.ts has no talents preset named %s", preset.Name, specDir, preset.Talents))
+		}
+		preset.talentsString = talentsString
+
+		consumesJson, ok := presets.Consumes[preset.Consumes]
+		if !ok {
+			panic(fmt.Sprintf("Spec preset %s for %s: presets.ts has no consumes preset named %s", preset.Name, specDir, preset.Consumes))
+		}
+		preset.consumes = &proto.Consumes{}
+		if err := protojson.Unmarshal(consumesJson, preset.consumes); err != nil {
+			panic(fmt.Sprintf("Spec preset %s for %s: failed to parse consumes %s: %s", preset.Name, specDir, preset.Consumes, err))
+		}
+	})
+}
+
+func readPresetFile(filePath string) string {
+	data, err := fs.ReadFile(PresetFiles, filePath)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read preset file %s: %s", filePath, err))
+	}
+	return string(data)
+}
+
+// Returns the raid, party and individual buffs used for tests in the given phase.
+func FullBuffsForPhase(phase int32) BuffsCombo {
+	switch {
+	case phase <= 1:
+		return FullBuffsPhase1
+	case phase == 2:
+		return FullBuffsPhase2
+	case phase == 3:
+		return FullBuffsPhase3
+	default:
+		return FullBuffsPhase4
+	}
+}
+
+// Returns a new Player using this preset. Settings which aren't part of the
+// preset use the same values as the test suites.
+func (preset *SpecPreset) Player() *proto.Player {
+	preset.load()
+	return WithSpec(&proto.Player{
+		Name:          preset.Name,
+		Class:         preset.Class,
+		Race:          preset.Race,
+		Level:         preset.Level,
+		Equipment:     googleProto.Clone(preset.gearSet).(*proto.EquipmentSpec),
+		Rotation:      googleProto.Clone(preset.rotation).(*proto.APLRotation),
+		TalentsString: preset.talentsString,
+		Consumes:      googleProto.Clone(preset.consumes).(*proto.Consumes),
+		Buffs:         FullBuffsForPhase(preset.Phase).Player,
+		Profession1:   proto.Profession_Engineering,
+
+		DistanceFromTarget: 5,
+		ReactionTimeMs:     150,
+		ChannelClipDelayMs: 50,
+	}, preset.SpecOptions)
+}
+
+// Returns a request simulating this preset alone, with full buffs for its phase.
+// If encounter is nil, a single default target for the preset's level is used.
+func (preset *SpecPreset) RaidSimRequest(encounter *proto.Encounter, simOptions *proto.SimOptions) *proto.RaidSimRequest {
+	if encounter == nil {
+		encounter = MakeSingleTargetEncounter(preset.Level, 0)
+	}
+	buffs := FullBuffsForPhase(preset.Phase)
+	return &proto.RaidSimRequest{
+		Raid:       SinglePlayerRaidProto(preset.Player(), buffs.Party, buffs.Raid, buffs.Debuffs),
+		Encounter:  encounter,
+		SimOptions: simOptions,
+	}
+}
+
+func (preset *SpecPreset) ToProto() *proto.SpecPreset {
+	return &proto.SpecPreset{
+		Spec:   preset.Spec,
+		Name:   preset.Name,
+		Phase:  preset.Phase,
+		Player: preset.Player(),
+	}
+}
+
+// Returns the registered presets for the requested specs, ordered by spec.
+func SpecPresets(request *proto.SpecPresetsRequest) *proto.SpecPresetsResult {
+	presets := FilterSlice(specPresets, func(preset *SpecPreset) bool {
+		return len(request.Specs) == 0 || slices.Contains(request.Specs, preset.Spec)
+	})
+	slices.SortStableFunc(presets, func(a, b *SpecPreset) int {
+		return strings.Compare(SpecName(a.Spec), SpecName(b.Spec))
+	})
+
+	return &proto.SpecPresetsResult{
+		Presets: MapSlice(presets, (*SpecPreset).ToProto),
+	}
+}
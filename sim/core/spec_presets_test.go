@@ -0,0 +1,66 @@
+package core
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestSpecNames(t *testing.T) {
+	for value := range proto.Spec_name {
+		spec := proto.Spec(value)
+		if fromName, ok := SpecFromName(SpecName(spec)); !ok || fromName != spec {
+			t.Errorf("SpecFromName(%q) = %s, expected %s", SpecName(spec), fromName, spec)
+		}
+	}
+	if name := SpecName(proto.Spec_SpecFeralDruid); name != "feral_druid" {
+		t.Errorf("SpecName(SpecFeralDruid) = %q, expected feral_druid", name)
+	}
+}
+
+func TestSpecPresetLoadsFromUiFiles(t *testing.T) {
+	defer func(presetFiles fs.FS) { PresetFiles = presetFiles }(PresetFiles)
+	PresetFiles = fstest.MapFS{
+		"warrior/presets.gen.json": {Data: []byte(`{
+	"talents": {
+		"P4FuryTalents": "30305001302-05050005525010051"
+	},
+	"consumes": {
+		"DefaultConsumes": {
+			"agilityElixir": "ElixirOfTheMongoose",
+			"dragonBreathChili": true,
+			"miscConsumes": {
+				"jujuEmber": true
+			}
+		}
+	}
+}`)},
+		"warrior/gear_sets/phase_4.gear.json": {Data: []byte(`{"items": [{"id": 12640}]}`)},
+		"warrior/apls/phase_4.apl.json":       {Data: []byte(`{"type": "TypeAPL", "priorityList": [{"action": {"autocastOtherCooldowns": {}}}]}`)},
+	}
+
+	preset := &SpecPreset{
+		Name:     "p4_fury",
+		Spec:     proto.Spec_SpecWarrior,
+		GearSet:  "phase_4",
+		Rotation: "phase_4",
+		Talents:  "P4FuryTalents",
+		Consumes: "DefaultConsumes",
+	}
+	preset.load()
+
+	if len(preset.gearSet.Items) != 1 || preset.gearSet.Items[0].Id != 12640 {
+		t.Errorf("Expected the phase_4 gear set, got %v", preset.gearSet)
+	}
+	if len(preset.rotation.PriorityList) != 1 {
+		t.Errorf("Expected the phase_4 rotation, got %v", preset.rotation)
+	}
+	if preset.talentsString != "30305001302-05050005525010051" {
+		t.Errorf("Expected P4FuryTalents talents, got %q", preset.talentsString)
+	}
+	if preset.consumes.AgilityElixir != proto.AgilityElixir_ElixirOfTheMongoose || !preset.consumes.DragonBreathChili || !preset.consumes.MiscConsumes.GetJujuEmber() {
+		t.Errorf("Expected DefaultConsumes, got %v", preset.consumes)
+	}
+}
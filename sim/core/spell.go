@@ -23,6 +23,8 @@ type SpellConfig struct {
 	Flags         SpellFlag
 	CastType      proto.CastType
 	MissileSpeed  float64
+	MaxRange      float64
+	Aoe           AoeConfig
 	BaseCost      float64
 	MetricSplits  int
 	Rank          int
@@ -93,6 +95,11 @@ type Spell struct {
 	// Example: https://wow.tools/dbc/?dbc=spellmisc&build=3.4.0.44996
 	MissileSpeed float64
 
+	// Max range in yards and area hit, only used when positions are enabled.
+	MaxRange   float64
+	Aoe        AoeConfig
+	aoeTargets []*Unit
+
 	Rank          int
 	RequiredLevel int
 
@@ -173,6 +180,10 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		panic(fmt.Sprintf("Over 200 registered spells when registering %s! There is probably a spell being registered every iteration.", config.ActionID))
 	}
 
+	if config.RequiredLevel > int(unit.Level) {
+		panic(fmt.Sprintf("%s rank %d requires level %d, but %s is level %d.", config.ActionID, config.Rank, config.RequiredLevel, unit.Label, unit.Level))
+	}
+
 	// Default the other damage multiplier to 1 if only one or the other is set.
 	if config.DamageMultiplier != 0 && config.DamageMultiplierAdditive == 0 {
 		config.DamageMultiplierAdditive = 1
@@ -216,6 +227,8 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		Flags:        config.Flags,
 		CastType:     config.CastType,
 		MissileSpeed: config.MissileSpeed,
+		MaxRange:     unit.defaultMaxRange(&config),
+		Aoe:          config.Aoe,
 
 		SpellSchool:       config.SpellSchool,
 		SchoolIndex:       config.SpellSchool.GetSchoolIndex(),
@@ -479,6 +492,13 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	if !spell.InRange(target) {
+		//if sim.Log != nil {
+		//	sim.Log("Cant cast because out of range")
+		//}
+		return false
+	}
+
 	// While moving only instant casts are possible
 	if spell.DefaultCast.CastTime > 0 && spell.Unit.Moving {
 		//if sim.Log != nil {
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"time"
+)
+
+// Client data for a spell, imported from the SpellEffect, SpellMisc,
+// SpellLevels, SpellPower and SpellCastTimes DB2 tables by
+// `go run ./tools/database/gen_db -gen=spelldata`.
+type SpellData struct {
+	ID int32
+
+	LearnLevel int32 // SpellLevel in SpellLevels, the level at which the spell is learned.
+	BaseLevel  int32
+	MaxLevel   int32
+
+	SchoolMask   int32
+	CastTimeMs   int32   // Base in SpellCastTimes.
+	MissileSpeed float64 // Speed in SpellMisc, in yards per second.
+
+	PowerType       int32
+	PowerCost       float64 // ManaCost in SpellPower, used for all power types.
+	PowerCostPct    float64 // PowerCostPct in SpellPower, percent of base mana.
+	PowerCostPerLvl float64
+
+	Effects []SpellEffectData
+}
+
+type SpellEffectData struct {
+	Index  int32
+	Effect int32
+	Aura   int32
+
+	// Rolled base damage or healing. Ranks with no die have a fixed value.
+	BaseValueMin       float64
+	BaseValueMax       float64
+	RealPointsPerLevel float64
+
+	BonusCoefficient       float64 // EffectBonusCoefficient, the spell power coefficient.
+	BonusCoefficientFromAP float64
+
+	AuraPeriodMs int32
+	TriggerSpell int32
+}
+
+func (data *SpellData) CastTime() time.Duration {
+	return time.Millisecond * time.Duration(data.CastTimeMs)
+}
+
+// Returns the effect at the given index, or nil if there is none.
+func (data *SpellData) Effect(index int32) *SpellEffectData {
+	for i := range data.Effects {
+		if data.Effects[i].Index == index {
+			return &data.Effects[i]
+		}
+	}
+	return nil
+}
+
+// Returns the first effect with a spell power coefficient, if any.
+func (data *SpellData) BonusCoefficient() float64 {
+	for _, effect := range data.Effects {
+		if effect.BonusCoefficient != 0 {
+			return effect.BonusCoefficient
+		}
+	}
+	return 0
+}
+
+// Client spell data by spell ID. Filled in by spell_data_auto_gen.go once the
+// spell data has been generated, and empty otherwise.
+var spellDataByID = map[int32]*SpellData{}
+
+// Returns the client data for a spell, or nil if it hasn't been imported.
+func GetSpellData(spellID int32) *SpellData {
+	return spellDataByID[spellID]
+}
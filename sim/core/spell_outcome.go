@@ -250,7 +250,7 @@ func (spell *Spell) outcomeMeleeWhite(sim *Simulation, result *SpellResult, atta
 	glanceRoll := sim.RandomFloat("White Hit Glancing Penalty")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(result.Target) {
 		if !result.applyAttackTableMiss(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance) &&
@@ -280,7 +280,7 @@ func (spell *Spell) outcomeMeleeSpecialHit(sim *Simulation, result *SpellResult,
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(result.Target) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance) {
@@ -305,7 +305,7 @@ func (spell *Spell) outcomeMeleeSpecialHitAndCrit(sim *Simulation, result *Spell
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(result.Target) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance) {
@@ -335,7 +335,7 @@ func (spell *Spell) OutcomeMeleeWeaponSpecialHitAndCritNoHitCounter(sim *Simulat
 
 // Like OutcomeMeleeSpecialHitAndCrit, but blocks prevent crits (all weapon damage based attacks).
 func (spell *Spell) outcomeMeleeWeaponSpecialHitAndCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	if spell.Unit.PseudoStats.InFrontOfTarget {
+	if spell.Unit.IsInFrontOf(result.Target) {
 		roll := sim.RandomFloat("White Hit Table")
 		chance := 0.0
 
@@ -375,7 +375,7 @@ func (spell *Spell) outcomeMeleeWeaponSpecialNoCrit(sim *Simulation, result *Spe
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(result.Target) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance) &&
@@ -527,7 +527,7 @@ func (spell *Spell) outcomeRangedHitAndCrit(sim *Simulation, result *SpellResult
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if spell.Unit.PseudoStats.InFrontOfTarget {
+	if spell.Unit.IsInFrontOf(result.Target) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance) {
 			if result.applyAttackTableCritSeparateRoll(sim, spell, attackTable, countHits) {
 				result.applyAttackTableBlock(spell, attackTable, roll, &chance)
@@ -555,7 +555,7 @@ func (dot *Dot) outcomeRangedHitAndCritSnapshot(sim *Simulation, result *SpellRe
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if dot.Spell.Unit.PseudoStats.InFrontOfTarget {
+	if dot.Spell.Unit.IsInFrontOf(result.Target) {
 		if !result.applyAttackTableMissNoDWPenalty(dot.Spell, attackTable, roll, &chance) {
 			if result.applyAttackTableCritSeparateRollSnapshot(sim, dot, attackTable) {
 				result.applyAttackTableBlock(dot.Spell, attackTable, roll, &chance)
@@ -597,7 +597,7 @@ func (spell *Spell) OutcomeRangedCritOnlyNoHitCounter(sim *Simulation, result *S
 }
 func (spell *Spell) outcomeRangedCritOnly(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	// Block already checks for this, but we can skip the RNG roll which is expensive.
-	if spell.Unit.PseudoStats.InFrontOfTarget {
+	if spell.Unit.IsInFrontOf(result.Target) {
 		roll := sim.RandomFloat("White Hit Table")
 		chance := 0.0
 
@@ -11,9 +11,10 @@ type SpellResult struct {
 	Target *Unit
 
 	// Results
-	Outcome HitOutcome
-	Damage  float64 // Damage done by this cast.
-	Threat  float64 // The amount of threat generated by this cast.
+	Outcome  HitOutcome
+	Damage   float64 // Damage done by this cast.
+	Threat   float64 // The amount of threat generated by this cast.
+	Absorbed float64 // Damage absorbed by shields on the target.
 
 	ResistanceMultiplier float64 // Partial Resists / Armor multiplier
 	PreOutcomeDamage     float64 // Damage done by this cast before Outcome is applied
@@ -30,6 +31,7 @@ func (spell *Spell) NewResult(target *Unit) *SpellResult {
 	result.Target = target
 	result.Damage = 0
 	result.Threat = 0
+	result.Absorbed = 0
 	result.Outcome = OutcomeEmpty // for blocks
 	result.inUse = true
 
@@ -371,37 +373,50 @@ func (spell *Spell) CalcAndDealOutcome(sim *Simulation, target *Unit, outcomeApp
 func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
 	isPartialResist := result.DidResist()
 
+	if result.Damage > 0 && len(result.Target.shields) > 0 {
+		result.Target.absorbDamage(sim, spell, result)
+	}
+
 	if sim.CurrentTime >= 0 {
-		spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += result.Damage
+		// Absorbed damage still counts as damage done, it's only tracked separately.
+		damage := result.Damage + result.Absorbed
+		spell.SpellMetrics[result.Target.UnitIndex].TotalAbsorbedDamage += result.Absorbed
+		spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += damage
 		if isPartialResist {
-			spell.SpellMetrics[result.Target.UnitIndex].TotalResistedDamage += result.Damage
+			spell.SpellMetrics[result.Target.UnitIndex].TotalResistedDamage += damage
 		}
 		if isPeriodic {
-			spell.SpellMetrics[result.Target.UnitIndex].TotalTickDamage += result.Damage
+			spell.SpellMetrics[result.Target.UnitIndex].TotalTickDamage += damage
 			if isPartialResist {
-				spell.SpellMetrics[result.Target.UnitIndex].TotalResistedTickDamage += result.Damage
+				spell.SpellMetrics[result.Target.UnitIndex].TotalResistedTickDamage += damage
 			}
 		}
 
 		if result.DidCrit() {
-			spell.SpellMetrics[result.Target.UnitIndex].TotalCritDamage += result.Damage
+			spell.SpellMetrics[result.Target.UnitIndex].TotalCritDamage += damage
 			if isPartialResist {
-				spell.SpellMetrics[result.Target.UnitIndex].TotalResistedCritDamage += result.Damage
+				spell.SpellMetrics[result.Target.UnitIndex].TotalResistedCritDamage += damage
 			}
 			if isPeriodic {
-				spell.SpellMetrics[result.Target.UnitIndex].TotalCritTickDamage += result.Damage
+				spell.SpellMetrics[result.Target.UnitIndex].TotalCritTickDamage += damage
 				if isPartialResist {
-					spell.SpellMetrics[result.Target.UnitIndex].TotalResistedCritTickDamage += result.Damage
+					spell.SpellMetrics[result.Target.UnitIndex].TotalResistedCritTickDamage += damage
 				}
 			}
 		} else if result.DidGlance() {
-			spell.SpellMetrics[result.Target.UnitIndex].TotalGlanceDamage += result.Damage
+			spell.SpellMetrics[result.Target.UnitIndex].TotalGlanceDamage += damage
 		} else if result.DidBlock() {
-			spell.SpellMetrics[result.Target.UnitIndex].TotalBlockDamage += result.Damage
+			spell.SpellMetrics[result.Target.UnitIndex].TotalBlockDamage += damage
 		}
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+
+		if spell.Unit.IsOpponent(result.Target) {
+			spell.Unit.Metrics.addBreakdownDamage(sim, spell, isPeriodic, damage)
+		}
 	}
 
+	spell.Unit.addThreat(sim, result.Target, result.Threat)
+
 	// Mark total damage done in raid so far for health based fights.
 	// Don't include damage done by EnemyUnits to Players
 	if result.Target.Type == EnemyUnit {
@@ -512,8 +527,17 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 	}
 	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+	spell.Unit.addSplitThreat(sim, result.Threat)
+
+	// Without a health bar there's no way to tell overhealing apart, so all of it counts.
+	effectiveHealing := result.Damage
 	if result.Target.HasHealthBar() {
-		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
+		effectiveHealing = result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
+	}
+	spell.SpellMetrics[result.Target.UnitIndex].TotalEffectiveHealing += effectiveHealing
+	spell.SpellMetrics[result.Target.UnitIndex].TotalOverhealing += result.Damage - effectiveHealing
+	if !spell.Unit.IsOpponent(result.Target) {
+		spell.Unit.Metrics.addBreakdownHealing(sim, spell, isPeriodic, result.Damage)
 	}
 
 	if sim.Log != nil {
@@ -0,0 +1,377 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+
+	googleProto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+const (
+	defaultStatScalingSteps          = 11
+	defaultStatScalingIterations     = 1000
+	defaultStatScalingReferenceDelta = 20.0
+
+	// A change in a curve's slope must be this many standard errors, and this
+	// fraction of the steeper side, to be reported as a breakpoint.
+	statBreakpointZ         = 3.0
+	statBreakpointMinChange = 0.2
+)
+
+// statScaler sims a player's DPS over a range of amounts of each stat. All
+// sims use the same seed and test-level RNG, so the DPS of each iteration is
+// paired across amounts, and differences between points have little noise.
+type statScaler struct {
+	// SingleRaidSimRunner used to sim one point.
+	SingleRaidSimRunner raidSimRunner
+	// Request used for this sweep.
+	Request *proto.StatScalingRequest
+
+	baseSettings *proto.RaidSimRequest
+	curves       []*statScalingCurve
+	reference    *statScalingCurve // Also in curves, unless it wasn't requested.
+
+	simBatchProgress
+}
+
+// DPS of each iteration at each amount of one stat.
+type statScalingCurve struct {
+	statRange *proto.StatScalingRange
+	stat      stats.UnitStat
+	deltas    []float64   // Sorted, including 0.
+	values    [][]float64 // Per-iteration DPS at each delta.
+}
+
+func StatScaling(ctx context.Context, request *proto.StatScalingRequest, progress chan *proto.ProgressMetrics) *proto.StatScalingResult {
+	scaler := &statScaler{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+
+	result, err := scaler.Run(ctx, progress)
+	if err != nil {
+		result = &proto.StatScalingResult{
+			ErrorResult: err.Error(),
+		}
+	}
+
+	sendFinalProgress(progress, &proto.ProgressMetrics{
+		FinalStatScalingResult: result,
+	})
+
+	return result
+}
+
+func (o *statScaler) Run(ctx context.Context, progress chan *proto.ProgressMetrics) (result *proto.StatScalingResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.StatScalingResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if err := o.init(progress); err != nil {
+		return nil, err
+	}
+
+	curves := o.simmedCurves()
+
+	// The current gear is simmed once, and shared by every curve.
+	requests := []*proto.RaidSimRequest{googleProto.Clone(o.baseSettings).(*proto.RaidSimRequest)}
+	for _, curve := range curves {
+		for _, delta := range curve.deltas {
+			if delta != 0 {
+				requests = append(requests, o.raidSimRequest(curve.stat, delta))
+			}
+		}
+	}
+
+	results, err := runSimBatch(ctx, o.SingleRaidSimRunner, requests, o.onSimDone)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline := results[0].RaidMetrics.Parties[0].Players[0].Dps.AllValues
+	resultIdx := 1
+	for _, curve := range curves {
+		curve.values = make([][]float64, len(curve.deltas))
+		for i, delta := range curve.deltas {
+			if delta == 0 {
+				curve.values[i] = baseline
+			} else {
+				curve.values[i] = results[resultIdx].RaidMetrics.Parties[0].Players[0].Dps.AllValues
+				resultIdx++
+			}
+		}
+	}
+
+	refWeight, _ := o.reference.localWeight()
+	result = &proto.StatScalingResult{}
+	for _, curve := range o.curves {
+		result.Curves = append(result.Curves, curve.ToProto(refWeight))
+	}
+	return result, nil
+}
+
+func (o *statScaler) init(progress chan *proto.ProgressMetrics) error {
+	if o.Request.Player == nil {
+		return errors.New("stat scaling: missing player")
+	}
+	if len(o.Request.Ranges) == 0 {
+		return errors.New("stat scaling: no stats to sweep")
+	}
+
+	player := googleProto.Clone(o.Request.Player).(*proto.Player)
+	if player.BonusStats == nil {
+		player.BonusStats = &proto.UnitStats{}
+	}
+	if player.BonusStats.Stats == nil {
+		player.BonusStats.Stats = make([]float64, stats.Len)
+	}
+	if player.BonusStats.PseudoStats == nil {
+		player.BonusStats.PseudoStats = make([]float64, stats.PseudoStatsLen)
+	}
+
+	raidProto := SinglePlayerRaidProto(player, o.Request.PartyBuffs, o.Request.RaidBuffs, o.Request.Debuffs)
+	raidProto.Tanks = o.Request.Tanks
+
+	simOptions := &proto.SimOptions{}
+	if o.Request.SimOptions != nil {
+		simOptions = googleProto.Clone(o.Request.SimOptions).(*proto.SimOptions)
+	}
+	if simOptions.Iterations <= 0 {
+		simOptions.Iterations = defaultStatScalingIterations
+	}
+	// Every point uses the same seed and test-level RNG, so that the DPS of
+	// each iteration lines up across points.
+	pinRandomSeed(simOptions)
+	simOptions.IsTest = true
+	simOptions.SaveAllValues = true
+	simOptions.TargetError = 0
+	simOptions.Debug = false
+	simOptions.DebugFirstIteration = false
+	simOptions.BuffContributions = false
+
+	o.baseSettings = &proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  o.Request.Encounter,
+		SimOptions: simOptions,
+	}
+
+	for _, statRange := range o.Request.Ranges {
+		curve, err := newStatScalingCurve(statRange)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(o.curves, func(other *statScalingCurve) bool { return other.stat == curve.stat }) {
+			return fmt.Errorf("stat scaling: stat %d is swept more than once", curve.stat)
+		}
+		o.curves = append(o.curves, curve)
+	}
+
+	refStat := stats.UnitStatFromStat(stats.Stat(o.Request.EpReferenceStat))
+	if idx := slices.IndexFunc(o.curves, func(curve *statScalingCurve) bool { return curve.stat == refStat }); idx != -1 {
+		o.reference = o.curves[idx]
+	} else {
+		reference, err := newStatScalingCurve(&proto.StatScalingRange{
+			UnitStat: &proto.StatScalingRange_Stat{Stat: o.Request.EpReferenceStat},
+			MinDelta: -defaultStatScalingReferenceDelta,
+			MaxDelta: defaultStatScalingReferenceDelta,
+			NumSteps: 3,
+		})
+		if err != nil {
+			return err
+		}
+		o.reference = reference
+	}
+
+	o.progress = progress
+	o.totalSims = 1
+	for _, curve := range o.simmedCurves() {
+		o.totalSims += int32(len(curve.deltas) - 1)
+	}
+	return nil
+}
+
+// The requested curves, and the reference stat's if it wasn't requested.
+func (o *statScaler) simmedCurves() []*statScalingCurve {
+	if slices.Contains(o.curves, o.reference) {
+		return o.curves
+	}
+	return append(slices.Clone(o.curves), o.reference)
+}
+
+func newStatScalingCurve(statRange *proto.StatScalingRange) (*statScalingCurve, error) {
+	curve := &statScalingCurve{
+		statRange: statRange,
+	}
+
+	switch unitStat := statRange.UnitStat.(type) {
+	case *proto.StatScalingRange_Stat:
+		if unitStat.Stat < 0 || int(unitStat.Stat) >= int(stats.Len) {
+			return nil, fmt.Errorf("stat scaling: invalid stat %d", unitStat.Stat)
+		}
+		curve.stat = stats.UnitStatFromStat(stats.Stat(unitStat.Stat))
+	case *proto.StatScalingRange_PseudoStat:
+		if unitStat.PseudoStat < 0 || int(unitStat.PseudoStat) >= stats.PseudoStatsLen {
+			return nil, fmt.Errorf("stat scaling: invalid pseudo stat %d", unitStat.PseudoStat)
+		}
+		curve.stat = stats.UnitStatFromPseudoStat(unitStat.PseudoStat)
+	default:
+		return nil, errors.New("stat scaling: missing stat")
+	}
+
+	numSteps := TernaryInt32(statRange.NumSteps > 0, statRange.NumSteps, defaultStatScalingSteps)
+	if numSteps < 2 || statRange.MaxDelta <= statRange.MinDelta {
+		return nil, fmt.Errorf("stat scaling: invalid range for stat %d", curve.stat)
+	}
+
+	curve.deltas = []float64{0}
+	for i := int32(0); i < numSteps; i++ {
+		delta := statRange.MinDelta + (statRange.MaxDelta-statRange.MinDelta)*float64(i)/float64(numSteps-1)
+		if math.Abs(delta) > 1e-9 {
+			curve.deltas = append(curve.deltas, delta)
+		}
+	}
+	slices.Sort(curve.deltas)
+	return curve, nil
+}
+
+func (o *statScaler) raidSimRequest(stat stats.UnitStat, delta float64) *proto.RaidSimRequest {
+	request := googleProto.Clone(o.baseSettings).(*proto.RaidSimRequest)
+	stat.AddToStatsProto(request.Raid.Parties[0].Players[0].BonusStats, delta)
+	return request
+}
+
+// Mean and standard error of a per-iteration value.
+func (curve *statScalingCurve) iterationMean(value func(iteration int) float64) (float64, float64) {
+	var x aggregator
+	for iteration := range curve.values[0] {
+		x.add(value(iteration))
+	}
+	if x.n == 0 {
+		return 0, 0
+	}
+	mean, stdev := x.meanAndStdDevOrZero()
+	return mean, stdev / math.Sqrt(float64(x.n))
+}
+
+// Slope of the segment between points i and i+1, in a single iteration.
+func (curve *statScalingCurve) iterationSlope(i int, iteration int) float64 {
+	return (curve.values[i+1][iteration] - curve.values[i][iteration]) / (curve.deltas[i+1] - curve.deltas[i])
+}
+
+// DPS per point of the stat at the current gear, from the points either side
+// of it, and its standard error.
+func (curve *statScalingCurve) localWeight() (float64, float64) {
+	zeroIdx := slices.Index(curve.deltas, 0)
+	lo, hi := max(zeroIdx-1, 0), min(zeroIdx+1, len(curve.deltas)-1)
+	return curve.iterationMean(func(iteration int) float64 {
+		return (curve.values[hi][iteration] - curve.values[lo][iteration]) / (curve.deltas[hi] - curve.deltas[lo])
+	})
+}
+
+// Finds the points where the curve's slope changes significantly. A change
+// which falls between points shows as a run of changes in the same direction,
+// which are reported as one breakpoint.
+func (curve *statScalingCurve) breakpoints() []*proto.StatBreakpoint {
+	numSegments := len(curve.deltas) - 1
+	slopes := make([]float64, numSegments)
+	slopeErrs := make([]float64, numSegments)
+	for i := range slopes {
+		slopes[i], slopeErrs[i] = curve.iterationMean(func(iteration int) float64 { return curve.iterationSlope(i, iteration) })
+	}
+
+	// Direction of the significant change in slope at each interior point.
+	changes := make([]float64, len(curve.deltas))
+	for i := 1; i < numSegments; i++ {
+		change, changeErr := curve.iterationMean(func(iteration int) float64 {
+			return curve.iterationSlope(i, iteration) - curve.iterationSlope(i-1, iteration)
+		})
+		if math.Abs(change) > statBreakpointZ*changeErr && math.Abs(change) > statBreakpointMinChange*max(math.Abs(slopes[i-1]), math.Abs(slopes[i])) {
+			changes[i] = math.Copysign(1, change)
+		}
+	}
+
+	dps := make([]float64, len(curve.deltas))
+	for i := range dps {
+		dps[i], _ = curve.iterationMean(func(iteration int) float64 { return curve.values[i][iteration] })
+	}
+
+	var breakpoints []*proto.StatBreakpoint
+	for first := 1; first < numSegments; first++ {
+		if changes[first] == 0 {
+			continue
+		}
+		last := first
+		for last+1 < numSegments && changes[last+1] == changes[first] {
+			last++
+		}
+
+		before, after := first-1, last
+		breakpoint := &proto.StatBreakpoint{
+			MinDelta:    curve.deltas[first],
+			MaxDelta:    curve.deltas[last],
+			SlopeBefore: slopes[before],
+			SlopeAfter:  slopes[after],
+			IsCap:       math.Abs(slopes[after]) <= statBreakpointZ*slopeErrs[after] && math.Abs(slopes[after]) < statBreakpointMinChange*math.Abs(slopes[before]),
+		}
+		if first == last {
+			breakpoint.MinDelta, breakpoint.MaxDelta = curve.deltas[first-1], curve.deltas[first+1]
+		}
+
+		// Where the lines through the segments either side cross.
+		breakpoint.Delta = (dps[after] - dps[before] + slopes[before]*curve.deltas[before] - slopes[after]*curve.deltas[after]) / (slopes[before] - slopes[after])
+		breakpoint.Delta = max(min(breakpoint.Delta, breakpoint.MaxDelta), breakpoint.MinDelta)
+
+		breakpoints = append(breakpoints, breakpoint)
+		first = last
+	}
+	return breakpoints
+}
+
+func (curve *statScalingCurve) ToProto(refWeight float64) *proto.StatScalingCurve {
+	zeroIdx := slices.Index(curve.deltas, 0)
+	protoCurve := &proto.StatScalingCurve{
+		Range:         curve.statRange,
+		Breakpoints:   curve.breakpoints(),
+		ValidMinDelta: curve.deltas[0],
+		ValidMaxDelta: curve.deltas[len(curve.deltas)-1],
+	}
+
+	for i, delta := range curve.deltas {
+		dps, _ := curve.iterationMean(func(iteration int) float64 { return curve.values[i][iteration] })
+		gain, gainErr := curve.iterationMean(func(iteration int) float64 { return curve.values[i][iteration] - curve.values[zeroIdx][iteration] })
+		protoCurve.Points = append(protoCurve.Points, &proto.StatScalingPoint{
+			Delta:       delta,
+			Dps:         dps,
+			DpsGain:     gain,
+			DpsGainCi95: gainErr * ConfidenceInterval95Z,
+		})
+	}
+
+	for _, breakpoint := range protoCurve.Breakpoints {
+		if breakpoint.Delta <= 0 {
+			protoCurve.ValidMinDelta = max(protoCurve.ValidMinDelta, breakpoint.Delta)
+		} else {
+			protoCurve.ValidMaxDelta = min(protoCurve.ValidMaxDelta, breakpoint.Delta)
+		}
+	}
+
+	weight, weightErr := curve.localWeight()
+	protoCurve.Weight = weight
+	protoCurve.WeightCi95 = weightErr * ConfidenceInterval95Z
+	if refWeight != 0 {
+		protoCurve.EpValue = weight / refWeight
+		protoCurve.EpValueCi95 = protoCurve.WeightCi95 / math.Abs(refWeight)
+	}
+	return protoCurve
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestStatScalingDetectsCap(t *testing.T) {
+	// Fake sim where hit stops adding DPS after 5 points, and every point of
+	// attack power adds 0.5 DPS.
+	scaler := &statScaler{
+		SingleRaidSimRunner: fakeRaidSimRunner(func(rsr *proto.RaidSimRequest) *proto.DistributionMetrics {
+			bonusStats := rsr.Raid.Parties[0].Players[0].BonusStats.Stats
+			dps := &proto.DistributionMetrics{}
+			for i := 0; i < int(rsr.SimOptions.Iterations); i++ {
+				dps.AllValues = append(dps.AllValues, 1000+float64(i%7)*25+2*min(bonusStats[stats.MeleeHit], 5)+0.5*bonusStats[stats.AttackPower])
+			}
+			return dps
+		}),
+		Request: &proto.StatScalingRequest{
+			Player:     &proto.Player{},
+			SimOptions: &proto.SimOptions{Iterations: 20, RandomSeed: 1},
+			Ranges: []*proto.StatScalingRange{
+				{UnitStat: &proto.StatScalingRange_Stat{Stat: proto.Stat_StatMeleeHit}, MinDelta: -20, MaxDelta: 20, NumSteps: 5},
+			},
+			EpReferenceStat: proto.Stat_StatAttackPower,
+		},
+	}
+
+	result, err := scaler.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if result.ErrorResult != "" {
+		t.Fatalf("Run() failed: %s", result.ErrorResult)
+	}
+	if len(result.Curves) != 1 {
+		t.Fatalf("Expected only the requested curve, got %d", len(result.Curves))
+	}
+
+	curve := result.Curves[0]
+	expectedGains := []float64{-40, -20, 0, 10, 10}
+	for i, point := range curve.Points {
+		if !WithinToleranceFloat64(expectedGains[i], point.DpsGain, 0.0001) || point.DpsGainCi95 > 0.0001 {
+			t.Errorf("Expected a gain of %0.1f at %0.0f hit, got %0.3f +/- %0.3f", expectedGains[i], point.Delta, point.DpsGain, point.DpsGainCi95)
+		}
+	}
+
+	if len(curve.Breakpoints) != 1 {
+		t.Fatalf("Expected 1 breakpoint, got %d", len(curve.Breakpoints))
+	}
+	breakpoint := curve.Breakpoints[0]
+	if !breakpoint.IsCap || !WithinToleranceFloat64(5, breakpoint.Delta, 0.0001) || breakpoint.MinDelta != 0 || breakpoint.MaxDelta != 10 {
+		t.Errorf("Expected a cap at 5 hit between 0 and 10, got %v", breakpoint)
+	}
+	if curve.ValidMinDelta != -20 || !WithinToleranceFloat64(5, curve.ValidMaxDelta, 0.0001) {
+		t.Errorf("Expected EP values to be valid from -20 to 5 hit, got %0.1f to %0.1f", curve.ValidMinDelta, curve.ValidMaxDelta)
+	}
+
+	// Central difference from -10 to 10 hit, relative to 0.5 DPS per attack power.
+	if !WithinToleranceFloat64(1.5, curve.Weight, 0.0001) || !WithinToleranceFloat64(3, curve.EpValue, 0.0001) {
+		t.Errorf("Expected a weight of 1.5 and EP of 3, got %0.3f and %0.3f", curve.Weight, curve.EpValue)
+	}
+}
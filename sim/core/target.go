@@ -9,15 +9,21 @@ import (
 )
 
 type Encounter struct {
-	Duration          time.Duration
-	DurationVariation time.Duration
-	Targets           []*Target
-	TargetUnits       []*Unit
+	Duration             time.Duration
+	DurationVariation    time.Duration
+	DurationDistribution proto.DurationDistribution
+	KillTimes            []time.Duration // Only set for DurationEmpirical.
+	Targets              []*Target
+	TargetUnits          []*Unit
 
 	ExecuteProportion_20 float64
 	ExecuteProportion_25 float64
 	ExecuteProportion_35 float64
 
+	// Whether the execute proportions are of the base duration, rather than of
+	// each iteration's duration.
+	FixedExecuteDuration bool
+
 	EndFightAtHealth float64
 	// DamageTaken is used to track health fights instead of duration fights.
 	//  Once primary target has taken its health worth of damage, fight ends.
@@ -25,6 +31,9 @@ type Encounter struct {
 	// In health fight: set to true until we get something to base on
 	DurationIsEstimate bool
 
+	// Whether units have 2D positions, see Unit.PositionsEnabled().
+	UsePositions bool
+
 	// Value to multiply by, for damage spells which are subject to the aoe cap.
 	aoeCapMultiplier float64
 }
@@ -39,8 +48,14 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		ExecuteProportion_20: max(options.ExecuteProportion_20, 0),
 		ExecuteProportion_25: max(options.ExecuteProportion_25, 0),
 		ExecuteProportion_35: max(options.ExecuteProportion_35, 0),
+		FixedExecuteDuration: options.FixedExecuteDuration,
+		UsePositions:         options.UsePositions,
 		Targets:              []*Target{},
 	}
+	if !options.UseHealth {
+		encounter.initDurationDistribution(options)
+	}
+
 	// If UseHealth is set, we use the sum of targets health.
 	if options.UseHealth {
 		for _, t := range options.Targets {
@@ -129,6 +144,9 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 			Metrics:     NewUnitMetrics(),
 
 			StatDependencyManager: stats.NewStatDependencyManager(),
+
+			StartPosition: Vector2FromProto(options.Position),
+			defaultFacing: Vector2FromDegrees(options.FacingDegrees),
 		},
 	}
 	defaultRaidBossLevel := int32(CharacterMaxLevel + 3)
@@ -1,9 +1,12 @@
 package core
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
 	"github.com/wowsims/sod/sim/core/stats"
@@ -447,6 +450,42 @@ func MakeSingleTargetEncounter(playerLevel int32, variation float64) *proto.Enco
 	}
 }
 
+// A cast completed by a unit, as returned by RunCastSequence.
+type CastEvent struct {
+	Time     time.Duration
+	ActionID ActionID
+}
+
+// Runs one iteration of a single player request and returns the casts completed
+// by the player, or by its pet with the given name, in order. Lets rotation tests
+// check which actions were chosen and when, rather than only that casts happened.
+func RunCastSequence(request *proto.RaidSimRequest, petName string) []CastEvent {
+	sim := NewSim(request)
+
+	character := sim.Raid.Parties[0].Players[0].GetCharacter()
+	unit := &character.Unit
+	if petName != "" {
+		petIdx := slices.IndexFunc(character.Pets, func(pet *Pet) bool {
+			return pet.Name == petName
+		})
+		if petIdx == -1 {
+			panic(fmt.Sprintf("No pet named %s", petName))
+		}
+		unit = &character.Pets[petIdx].Unit
+	}
+
+	var casts []CastEvent
+	MakePermanent(unit.RegisterAura(Aura{
+		Label: "Cast Sequence",
+		OnCastComplete: func(_ *Aura, sim *Simulation, spell *Spell) {
+			casts = append(casts, CastEvent{Time: sim.CurrentTime, ActionID: spell.ActionID})
+		},
+	}))
+
+	sim.runOnce()
+	return casts
+}
+
 func CharacterStatsTest(label string, t *testing.T, raid *proto.Raid, expectedStats stats.Stats) {
 	csr := &proto.ComputeStatsRequest{
 		Raid: raid,
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Classic aggro rules: a unit pulls aggro once its threat exceeds that of the
+// current holder by 10% while in melee range, or by 30% at range.
+const (
+	AggroPullMeleeThreshold  = 1.1
+	AggroPullRangedThreshold = 1.3
+)
+
+type ThreatCeiling struct {
+	OnPull proto.AggroPullAction
+
+	tankTpsCurve []*proto.TankThreatPoint
+}
+
+func newThreatCeiling(config *proto.ThreatCeiling) *ThreatCeiling {
+	if !config.GetEnabled() {
+		return nil
+	}
+
+	tankTpsCurve := slices.Clone(config.TankTpsCurve)
+	slices.SortStableFunc(tankTpsCurve, func(a, b *proto.TankThreatPoint) int {
+		return cmp.Compare(a.TimeSeconds, b.TimeSeconds)
+	})
+
+	return &ThreatCeiling{
+		OnPull:       config.OnPull,
+		tankTpsCurve: tankTpsCurve,
+	}
+}
+
+// Returns the threat the tank holds on the target, and whether it is known at
+// all. Simulated tanks take precedence over the configured TPS curve.
+func (tc *ThreatCeiling) tankThreat(sim *Simulation, target *Unit) (float64, bool) {
+	if tank := target.CurrentTarget; tank != nil && tank.Type != EnemyUnit {
+		return tank.threat[target.UnitIndex], true
+	}
+
+	if len(tc.tankTpsCurve) == 0 {
+		return 0, false
+	}
+
+	threat := 0.0
+	for i, point := range tc.tankTpsCurve {
+		start := DurationFromSeconds(point.TimeSeconds)
+		if start >= sim.CurrentTime {
+			break
+		}
+		end := sim.CurrentTime
+		if i+1 < len(tc.tankTpsCurve) {
+			end = min(end, DurationFromSeconds(tc.tankTpsCurve[i+1].TimeSeconds))
+		}
+		threat += point.Tps * (end - start).Seconds()
+	}
+	return threat, true
+}
+
+// Returns the threat this unit has generated on the target so far this iteration.
+func (unit *Unit) GetThreat(target *Unit) float64 {
+	return unit.threat[target.UnitIndex]
+}
+
+// Returns this unit's threat on the target as a fraction of the tank's, or 0
+// if there is no threat ceiling or the tank's threat isn't known.
+func (unit *Unit) ThreatPercentOfTank(sim *Simulation, target *Unit) float64 {
+	ceiling := unit.Env.Raid.ThreatCeiling
+	if ceiling == nil || unit.isTankOf(target) {
+		return 0
+	}
+
+	tankThreat, ok := ceiling.tankThreat(sim, target)
+	if !ok {
+		return 0
+	}
+
+	threat := unit.threat[target.UnitIndex]
+	if tankThreat <= 0 {
+		if threat > 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return threat / tankThreat
+}
+
+func (unit *Unit) isTankOf(target *Unit) bool {
+	return target.CurrentTarget == unit
+}
+
+func (unit *Unit) aggroPullThreshold() float64 {
+	if unit.DistanceFromTarget <= MaxMeleeAttackDistance {
+		return AggroPullMeleeThreshold
+	}
+	return AggroPullRangedThreshold
+}
+
+func (unit *Unit) resetThreat() {
+	if unit.threat == nil {
+		unit.threat = make([]float64, len(unit.Env.AllUnits))
+		unit.hasAggro = make([]bool, len(unit.Env.AllUnits))
+	}
+	clear(unit.threat)
+	clear(unit.hasAggro)
+}
+
+// Adds threat generated by this unit on the target, and checks it against the
+// raid's threat ceiling if there is one.
+//
+// Threat from resource gains is only calculated at the end of each iteration,
+// so it doesn't count towards the ceiling.
+func (unit *Unit) addThreat(sim *Simulation, target *Unit, threat float64) {
+	if target.Type != EnemyUnit || threat == 0 {
+		return
+	}
+
+	unit.threat[target.UnitIndex] += threat
+
+	ceiling := unit.Env.Raid.ThreatCeiling
+	if ceiling == nil || unit.hasAggro[target.UnitIndex] || unit.isTankOf(target) {
+		return
+	}
+
+	if unit.ThreatPercentOfTank(sim, target) > unit.aggroPullThreshold() {
+		unit.pullAggro(sim, target, ceiling.OnPull)
+	}
+}
+
+// Spreads threat evenly across all targets, e.g. for healing.
+func (unit *Unit) addSplitThreat(sim *Simulation, threat float64) {
+	targets := unit.Env.Encounter.TargetUnits
+	for _, target := range targets {
+		unit.addThreat(sim, target, threat/float64(len(targets)))
+	}
+}
+
+func (unit *Unit) pullAggro(sim *Simulation, target *Unit, onPull proto.AggroPullAction) {
+	unit.hasAggro[target.UnitIndex] = true
+
+	if !unit.Metrics.PulledAggro {
+		unit.Metrics.PulledAggro = true
+		unit.Metrics.FirstAggroPullTimestamp = max(sim.CurrentTime, 0)
+	}
+
+	if sim.Log != nil {
+		unit.Log(sim, "Pulled aggro on %s (%0.3f threat).", target.Label, unit.threat[target.UnitIndex])
+	}
+
+	switch onPull {
+	case proto.AggroPullAction_AggroPullActionWipe:
+		sim.endOfCombatDuration = min(sim.endOfCombatDuration, max(sim.CurrentTime, 0))
+	case proto.AggroPullAction_AggroPullActionStop:
+		unit.stopAttacking(sim)
+	}
+}
+
+// Stops all further actions from this unit for the rest of the iteration.
+// Effects that are already running, like DoTs, keep going.
+func (unit *Unit) stopAttacking(sim *Simulation) {
+	unit.enabled = false
+	unit.AutoAttacks.CancelAutoSwing(sim)
+	if unit.gcdAction != nil {
+		unit.CancelGCDTimer(sim)
+	}
+	if unit.hardcastAction != nil {
+		unit.hardcastAction.Cancel(sim)
+	}
+	unit.Hardcast = Hardcast{}
+
+	if sim.Log != nil {
+		unit.Log(sim, "Stopped attacking for the rest of the fight.")
+	}
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestTankThreatFromTpsCurve(t *testing.T) {
+	ceiling := newThreatCeiling(&proto.ThreatCeiling{
+		Enabled: true,
+		TankTpsCurve: []*proto.TankThreatPoint{
+			{TimeSeconds: 10, Tps: 200},
+			{TimeSeconds: 2, Tps: 100},
+		},
+	})
+	target := &Unit{Type: EnemyUnit}
+
+	cases := map[time.Duration]float64{
+		time.Second:      0,
+		time.Second * 6:  400,
+		time.Second * 10: 800,
+		time.Second * 15: 1800,
+	}
+
+	for currentTime, expectedThreat := range cases {
+		sim := &Simulation{CurrentTime: currentTime}
+		threat, ok := ceiling.tankThreat(sim, target)
+		if !ok {
+			t.Fatalf("Expected tank threat to be known at %s", currentTime)
+		}
+		if !WithinToleranceFloat64(expectedThreat, threat, 0.0001) {
+			t.Errorf("Expected %0.3f tank threat at %s, got %0.3f", expectedThreat, currentTime, threat)
+		}
+	}
+}
+
+// Sets up a fake sim whose tank generates 100 threat per second on the target,
+// so the tank holds 1000 threat after 10 seconds.
+func setupThreatCeilingTest(onPull proto.AggroPullAction, distance float64) (*Simulation, *Unit, *Unit) {
+	sim := SetupFakeSim()
+	sim.Raid.ThreatCeiling = newThreatCeiling(&proto.ThreatCeiling{
+		Enabled:      true,
+		OnPull:       onPull,
+		TankTpsCurve: []*proto.TankThreatPoint{{TimeSeconds: 0, Tps: 100}},
+	})
+
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	unit.DistanceFromTarget = distance
+	target := sim.Encounter.TargetUnits[0]
+	target.CurrentTarget = nil
+
+	sim.CurrentTime = time.Second * 10
+	return sim, unit, target
+}
+
+func TestAggroPullThreshold(t *testing.T) {
+	cases := []struct {
+		name     string
+		distance float64
+		below    float64
+		above    float64
+	}{
+		{name: "Melee", distance: MaxMeleeAttackDistance, below: 1050, above: 1150},
+		{name: "Ranged", distance: 30, below: 1250, above: 1350},
+	}
+
+	for _, c := range cases {
+		sim, unit, target := setupThreatCeilingTest(proto.AggroPullAction_AggroPullActionNone, c.distance)
+
+		unit.addThreat(sim, target, c.below)
+		if unit.Metrics.PulledAggro {
+			t.Fatalf("%s: Expected no aggro pull at %0.0f threat against 1000", c.name, c.below)
+		}
+
+		unit.addThreat(sim, target, c.above-c.below)
+		if !unit.Metrics.PulledAggro {
+			t.Fatalf("%s: Expected an aggro pull at %0.0f threat against 1000", c.name, c.above)
+		}
+		if unit.Metrics.FirstAggroPullTimestamp != sim.CurrentTime {
+			t.Errorf("%s: Expected the pull at %s, got %s", c.name, sim.CurrentTime, unit.Metrics.FirstAggroPullTimestamp)
+		}
+		if !unit.enabled {
+			t.Errorf("%s: Expected the unit to keep attacking when pulls are only reported", c.name)
+		}
+	}
+}
+
+func TestAggroPullWipe(t *testing.T) {
+	sim, unit, target := setupThreatCeilingTest(proto.AggroPullAction_AggroPullActionWipe, MaxMeleeAttackDistance)
+
+	unit.addThreat(sim, target, 1200)
+	if sim.endOfCombatDuration != sim.CurrentTime {
+		t.Errorf("Expected the iteration to end at the pull (%s), got %s", sim.CurrentTime, sim.endOfCombatDuration)
+	}
+}
+
+func TestAggroPullStop(t *testing.T) {
+	sim, unit, target := setupThreatCeilingTest(proto.AggroPullAction_AggroPullActionStop, MaxMeleeAttackDistance)
+	endOfCombat := sim.endOfCombatDuration
+
+	unit.addThreat(sim, target, 1200)
+	if unit.enabled {
+		t.Errorf("Expected the unit to stop attacking after pulling aggro")
+	}
+	if sim.endOfCombatDuration != endOfCombat {
+		t.Errorf("Expected the iteration to continue after the pull, ended at %s", sim.endOfCombatDuration)
+	}
+}
@@ -0,0 +1,250 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Tracks a unit's metrics over fight time, in fixed-size bins summed over all
+// iterations. Values are sampled at each bin boundary, as the difference from
+// the previous sample, so nothing needs to be recorded on the hot paths.
+type timelineMetrics struct {
+	binDuration time.Duration
+	hasMana     bool
+	hasRage     bool
+	hasEnergy   bool
+
+	bins  []timelineBin
+	auras []*auraTimeline
+
+	// Cumulative values for the current iteration, as of the last sample.
+	lastSampleAt time.Duration
+	lastDamage   float64
+	lastHealing  float64
+	lastCasts    int
+}
+
+type timelineBin struct {
+	seconds    float64 // Fight time covered by this bin, summed over all iterations.
+	iterations int32   // Number of iterations which lasted into this bin.
+
+	damage  float64
+	healing float64
+	casts   float64
+
+	// Resource levels at the end of this bin, summed over all iterations.
+	mana   float64
+	rage   float64
+	energy float64
+}
+
+type auraTimeline struct {
+	aura *Aura
+
+	uptime     []float64 // Seconds active in each bin, summed over all iterations.
+	lastUptime time.Duration
+}
+
+func newTimelineMetrics(unit *Unit, binDuration time.Duration) *timelineMetrics {
+	timeline := &timelineMetrics{
+		binDuration: binDuration,
+		hasMana:     unit.HasManaBar(),
+		hasRage:     unit.HasRageBar(),
+		hasEnergy:   unit.HasEnergyBar(),
+	}
+	for _, aura := range unit.auras {
+		if !aura.ActionID.IsEmptyAction() {
+			timeline.auras = append(timeline.auras, &auraTimeline{aura: aura})
+		}
+	}
+	return timeline
+}
+
+func (timeline *timelineMetrics) reset() {
+	timeline.lastSampleAt = 0
+	timeline.lastDamage = 0
+	timeline.lastHealing = 0
+	timeline.lastCasts = 0
+	for _, auraTimeline := range timeline.auras {
+		auraTimeline.lastUptime = 0
+	}
+}
+
+// Records everything since the last sample into the bin containing it.
+func (timeline *timelineMetrics) sample(sim *Simulation, unit *Unit) {
+	binIdx := int(timeline.lastSampleAt / timeline.binDuration)
+	for len(timeline.bins) <= binIdx {
+		timeline.bins = append(timeline.bins, timelineBin{})
+		for _, auraTimeline := range timeline.auras {
+			auraTimeline.uptime = append(auraTimeline.uptime, 0)
+		}
+	}
+
+	damage, healing, casts := unit.cumulativeTimelineTotals()
+
+	bin := &timeline.bins[binIdx]
+	bin.seconds += (sim.CurrentTime - timeline.lastSampleAt).Seconds()
+	bin.iterations++
+	bin.damage += damage - timeline.lastDamage
+	bin.healing += healing - timeline.lastHealing
+	bin.casts += float64(casts - timeline.lastCasts)
+	if timeline.hasMana {
+		bin.mana += unit.CurrentMana()
+	}
+	if timeline.hasRage {
+		bin.rage += unit.CurrentRage()
+	}
+	if timeline.hasEnergy {
+		bin.energy += unit.CurrentEnergy()
+	}
+
+	for _, auraTimeline := range timeline.auras {
+		uptime := auraTimeline.aura.cumulativeUptime(sim)
+		auraTimeline.uptime[binIdx] += (uptime - auraTimeline.lastUptime).Seconds()
+		auraTimeline.lastUptime = uptime
+	}
+
+	timeline.lastSampleAt = sim.CurrentTime
+	timeline.lastDamage = damage
+	timeline.lastHealing = healing
+	timeline.lastCasts = casts
+}
+
+// Returns the damage, healing and casts from this unit so far this iteration.
+func (unit *Unit) cumulativeTimelineTotals() (float64, float64, int) {
+	damage, healing, casts := 0.0, 0.0, 0
+	for _, spell := range unit.Spellbook {
+		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
+			casts += spell.casts
+		}
+		for _, spellMetrics := range spell.splitSpellMetrics {
+			for i := range spellMetrics {
+				if unit.IsOpponent(unit.Env.AllUnits[i]) {
+					damage += spellMetrics[i].TotalDamage
+				} else {
+					healing += spellMetrics[i].TotalHealing + spellMetrics[i].TotalShielding
+				}
+			}
+		}
+	}
+	return damage, healing, casts
+}
+
+// Returns the time this aura has been active so far this iteration.
+func (aura *Aura) cumulativeUptime(sim *Simulation) time.Duration {
+	uptime := aura.metrics.Uptime
+	if aura.active {
+		uptime += max(min(sim.CurrentTime, aura.expires)-max(aura.startTime, 0), 0)
+	}
+	return uptime
+}
+
+func (timeline *timelineMetrics) ToProto() *proto.TimelineMetrics {
+	numBins := len(timeline.bins)
+	protoTimeline := &proto.TimelineMetrics{
+		BinSeconds:     timeline.binDuration.Seconds(),
+		Coverage:       make([]float64, numBins),
+		Dps:            make([]float64, numBins),
+		Hps:            make([]float64, numBins),
+		CastsPerMinute: make([]float64, numBins),
+	}
+	if timeline.hasMana {
+		protoTimeline.Mana = make([]float64, numBins)
+	}
+	if timeline.hasRage {
+		protoTimeline.Rage = make([]float64, numBins)
+	}
+	if timeline.hasEnergy {
+		protoTimeline.Energy = make([]float64, numBins)
+	}
+
+	if numBins == 0 {
+		return protoTimeline
+	}
+
+	totalIterations := float64(timeline.bins[0].iterations)
+	for i, bin := range timeline.bins {
+		if bin.iterations == 0 || bin.seconds == 0 {
+			continue
+		}
+		iterations := float64(bin.iterations)
+
+		protoTimeline.Coverage[i] = iterations / totalIterations
+		protoTimeline.Dps[i] = bin.damage / bin.seconds
+		protoTimeline.Hps[i] = bin.healing / bin.seconds
+		protoTimeline.CastsPerMinute[i] = bin.casts / bin.seconds * 60
+		if protoTimeline.Mana != nil {
+			protoTimeline.Mana[i] = bin.mana / iterations
+		}
+		if protoTimeline.Rage != nil {
+			protoTimeline.Rage[i] = bin.rage / iterations
+		}
+		if protoTimeline.Energy != nil {
+			protoTimeline.Energy[i] = bin.energy / iterations
+		}
+	}
+
+	for _, auraTimeline := range timeline.auras {
+		protoAura := &proto.AuraTimeline{
+			Id:     auraTimeline.aura.ActionID.ToProto(),
+			Uptime: make([]float64, numBins),
+		}
+		active := false
+		for i, uptime := range auraTimeline.uptime {
+			if seconds := timeline.bins[i].seconds; seconds > 0 && uptime > 0 {
+				protoAura.Uptime[i] = uptime / seconds
+				active = true
+			}
+		}
+		if active {
+			protoTimeline.Auras = append(protoTimeline.Auras, protoAura)
+		}
+	}
+
+	return protoTimeline
+}
+
+// Schedules the timeline samples for this iteration, if enabled.
+func (sim *Simulation) initTimelineAction() {
+	binDuration := DurationFromSeconds(sim.Options.TimelineBinSeconds)
+	if binDuration <= 0 {
+		return
+	}
+
+	for _, unit := range sim.AllUnits {
+		if unit.Metrics.timeline == nil {
+			unit.Metrics.timeline = newTimelineMetrics(unit, binDuration)
+		}
+		unit.Metrics.timeline.reset()
+	}
+
+	pa := &PendingAction{
+		NextActionAt: binDuration,
+		Priority:     ActionPriorityLow,
+	}
+	pa.OnAction = func(sim *Simulation) {
+		sim.sampleTimelines()
+
+		pa.NextActionAt = sim.CurrentTime + binDuration
+		sim.AddPendingAction(pa)
+	}
+	sim.AddPendingAction(pa)
+}
+
+func (sim *Simulation) sampleTimelines() {
+	for _, unit := range sim.AllUnits {
+		if unit.Metrics.timeline != nil && sim.CurrentTime > unit.Metrics.timeline.lastSampleAt {
+			unit.Metrics.timeline.sample(sim, unit)
+		}
+	}
+}
+
+// Samples the last, partial bin of the iteration. Fights ended early by an
+// aggro pull wipe stop at that point, rather than at the planned duration.
+func (sim *Simulation) finishTimelines() {
+	if sim.Encounter.EndFightAtHealth == 0 {
+		sim.CurrentTime = min(sim.Duration, sim.endOfCombatDuration)
+	}
+	sim.sampleTimelines()
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimelineMetricsToProto(t *testing.T) {
+	timeline := &timelineMetrics{
+		binDuration: time.Second * 10,
+		hasMana:     true,
+		bins: []timelineBin{
+			{seconds: 20, iterations: 2, damage: 2000, casts: 10, mana: 1500},
+			{seconds: 5, iterations: 1, damage: 1000, casts: 1, mana: 200},
+		},
+	}
+
+	protoTimeline := timeline.ToProto()
+
+	expected := map[string][]float64{
+		"coverage":         {1, 0.5},
+		"dps":              {100, 200},
+		"casts_per_minute": {30, 12},
+		"mana":             {750, 200},
+	}
+	actual := map[string][]float64{
+		"coverage":         protoTimeline.Coverage,
+		"dps":              protoTimeline.Dps,
+		"casts_per_minute": protoTimeline.CastsPerMinute,
+		"mana":             protoTimeline.Mana,
+	}
+
+	for name, values := range expected {
+		for i, value := range values {
+			if !WithinToleranceFloat64(value, actual[name][i], 0.0001) {
+				t.Errorf("Expected %s of bin %d to be %0.3f, got %0.3f", name, i, value, actual[name][i])
+			}
+		}
+	}
+	if protoTimeline.Rage != nil {
+		t.Errorf("Expected no rage timeline for a unit without rage")
+	}
+}
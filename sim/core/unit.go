@@ -47,11 +47,25 @@ type Unit struct {
 
 	Level int32 // Level of Unit, e.g. Bosses are 63.
 
+	// Spells with no rank learnable at the unit's level, mapped to the level their
+	// first rank is learned at. See GetSpellRankForLevel.
+	unlearnedSpells map[int32]int
+
 	MobType proto.MobType
 
 	// Amount of time it takes for the human agent to react to in-game events.
 	// Used by certain APL values and actions.
-	ReactionTime time.Duration
+	ReactionTime             time.Duration
+	ReactionTimeStdev        time.Duration
+	ReactionTimeDistribution proto.ReactionTimeDistribution
+
+	// Round trip time to the server, and how early the next cast can be queued
+	// before the current GCD or cast ends.
+	Ping             time.Duration
+	SpellQueueWindow time.Duration
+
+	// When the rotation runs next after a hardcast or event, see doNextActionAfterDelay.
+	nextActionAt time.Duration
 
 	// Amount of time following a post-GCD channel tick, to when the next action can be performed.
 	ChannelClipDelay time.Duration
@@ -65,6 +79,11 @@ type Unit struct {
 	moveSpell               *Spell
 	MoveSpeed               float64
 
+	// Position on the encounter's 2D plane, only used when positions are enabled.
+	StartPosition Vector2
+	Position      Vector2
+	defaultFacing Vector2
+
 	// Environment in which this Unit exists. This will be nil until after the
 	// construction phase.
 	Env *Environment
@@ -134,6 +153,13 @@ type Unit struct {
 	AttackTables                []map[proto.CastType]*AttackTable
 	DynamicDamageTakenModifiers []DynamicDamageTakenModifier
 
+	// Absorption effects that can be applied to this unit, in the order they are consumed.
+	shields []*Shield
+
+	// Threat generated on each unit this iteration, and whether this unit has pulled aggro from it.
+	threat   []float64
+	hasAggro []bool
+
 	GCD *Timer
 
 	// Used for applying the effect of a hardcast spell when casting finishes.
@@ -442,6 +468,11 @@ func (unit *Unit) MoveTo(moveRange float64, sim *Simulation) {
 		return
 	}
 
+	if unit.PositionsEnabled() && unit.CurrentTarget != nil {
+		unit.MoveToPoint(unit.pointAtRangeFromTarget(moveRange), sim)
+		return
+	}
+
 	moveDistance := moveRange - unit.DistanceFromTarget
 	moveTicks := math.Abs(moveDistance)
 	moveInterval := moveDistance / float64(moveTicks)
@@ -518,8 +549,10 @@ func (unit *Unit) finalize() {
 
 func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	unit.enabled = true
+	unit.resetThreat()
 	unit.resetCDs(sim)
 	unit.Hardcast.Expires = startingCDTime
+	unit.nextActionAt = 0
 	unit.ChanneledDot = nil
 	unit.Metrics.reset()
 	unit.ResetStatDeps()
@@ -533,6 +566,7 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	}
 
 	unit.DistanceFromTarget = unit.StartDistanceFromTarget
+	unit.Position = unit.StartPosition
 
 	unit.manaBar.reset()
 	unit.focusBar.reset(sim)
@@ -570,6 +604,11 @@ func (unit *Unit) doneIteration(sim *Simulation) {
 	unit.manaBar.doneIteration(sim)
 	unit.rageBar.doneIteration()
 
+	// Shields still up when the iteration ends aren't wasted.
+	for _, shield := range unit.shields {
+		shield.remaining = 0
+	}
+
 	unit.auraTracker.doneIteration(sim)
 	for _, spell := range unit.Spellbook {
 		spell.doneIteration()
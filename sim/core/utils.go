@@ -192,3 +192,13 @@ func (x *aggregator) meanAndStdDev() (float64, float64) {
 	stdDev := math.Sqrt(x.sumSq/float64(x.n) - mean*mean)
 	return mean, stdDev
 }
+
+// Like meanAndStdDev, but with a standard deviation of 0 instead of NaN when
+// all values are equal, and rounding makes the variance slightly negative.
+func (x *aggregator) meanAndStdDevOrZero() (float64, float64) {
+	mean, stdDev := x.meanAndStdDev()
+	if math.IsNaN(stdDev) {
+		stdDev = 0
+	}
+	return mean, stdDev
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+// How long oils, stones and poisons last once applied to a weapon.
+const WeaponImbueDuration = time.Minute * 30
+
+// Time it takes to apply a new oil, stone or poison to a weapon.
+const WeaponImbueApplyTime = time.Second * 3
+
+type WeaponImbueConfig struct {
+	Imbue proto.WeaponImbue
+
+	// The item used to apply the imbue.
+	ActionID ActionID
+
+	IsMH bool
+
+	// Number of charges, one of which is used each time the imbue procs.
+	// 0 means the imbue never runs out of charges.
+	MaxCharges int32
+
+	// How long the imbue lasts once applied. 0 means it lasts all fight.
+	Duration time.Duration
+
+	// Bonuses granted while the imbue is on the weapon.
+	Stats        stats.Stats
+	WeaponDamage float64
+}
+
+// A temporary enchant on a weapon, which drops once it runs out of charges or
+// expires. It is on the weapon at the start of each iteration, and can only be
+// applied again by the APL with the Reapply Weapon Imbue action.
+type WeaponImbue struct {
+	WeaponImbueConfig
+
+	character *Character
+
+	// Active while the imbue is on the weapon. If the imbue has charges, the
+	// stacks are the remaining charges.
+	Aura *Aura
+
+	// Applies the imbue again, with full charges and duration.
+	ReapplySpell *Spell
+
+	bonusesApplied bool
+	fadeAction     *PendingAction
+
+	metrics WeaponImbueMetrics
+}
+
+// Registers an imbue on the main hand or off hand weapon. Stats and weapon
+// damage are added immediately, so this should be called while the
+// character is being constructed.
+func (character *Character) RegisterWeaponImbue(config WeaponImbueConfig) *WeaponImbue {
+	if character.GetWeaponImbue(config.IsMH) != nil {
+		panic(fmt.Sprintf("Weapon imbue already registered for %s: %s", handLabel(config.IsMH), config.Imbue))
+	}
+
+	imbue := &WeaponImbue{
+		WeaponImbueConfig: config,
+		character:         character,
+		bonusesApplied:    true,
+		metrics: WeaponImbueMetrics{
+			ID:   config.ActionID,
+			IsMH: config.IsMH,
+		},
+	}
+
+	character.AddStats(config.Stats)
+	imbue.addWeaponDamage(config.WeaponDamage)
+
+	tag := TernaryInt32(config.IsMH, 1, 2)
+
+	imbue.Aura = character.RegisterAura(Aura{
+		Label:     fmt.Sprintf("%s %s", config.Imbue, handLabel(config.IsMH)),
+		ActionID:  config.ActionID.WithTag(tag),
+		Duration:  NeverExpires,
+		MaxStacks: config.MaxCharges,
+		OnReset: func(aura *Aura, sim *Simulation) {
+			imbue.metrics.reset()
+			if !imbue.bonusesApplied {
+				// Stats are reset with the unit, but weapon damage isn't.
+				imbue.addWeaponDamage(imbue.WeaponDamage)
+				imbue.bonusesApplied = true
+			}
+			imbue.apply(sim)
+		},
+	})
+
+	imbue.ReapplySpell = character.RegisterSpell(SpellConfig{
+		ActionID: config.ActionID.WithTag(tag),
+		Flags:    SpellFlagNoOnCastComplete,
+
+		Cast: CastConfig{
+			DefaultCast: Cast{
+				CastTime: WeaponImbueApplyTime,
+			},
+			IgnoreHaste: true,
+		},
+
+		ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
+			if !imbue.bonusesApplied {
+				character.AddStatsDynamic(sim, imbue.Stats)
+				imbue.addWeaponDamage(imbue.WeaponDamage)
+				imbue.bonusesApplied = true
+			}
+			imbue.apply(sim)
+			imbue.metrics.Reapplications++
+		},
+	})
+
+	if config.IsMH {
+		character.mainHandImbue = imbue
+	} else {
+		character.offHandImbue = imbue
+	}
+	return imbue
+}
+
+// Returns the imbue registered for the main hand or off hand, or nil.
+func (character *Character) GetWeaponImbue(isMH bool) *WeaponImbue {
+	if isMH {
+		return character.mainHandImbue
+	}
+	return character.offHandImbue
+}
+
+// Returns the imbue on the weapon a melee spell was cast with, or nil.
+func (character *Character) GetWeaponImbueForSpell(spell *Spell) *WeaponImbue {
+	if spell.ProcMask.Matches(ProcMaskMeleeMH) {
+		return character.mainHandImbue
+	}
+	if spell.ProcMask.Matches(ProcMaskMeleeOH) {
+		return character.offHandImbue
+	}
+	return nil
+}
+
+func (imbue *WeaponImbue) IsActive() bool {
+	return imbue.Aura.IsActive()
+}
+
+// Returns the remaining charges, or 0 if the imbue doesn't use charges.
+func (imbue *WeaponImbue) Charges() int32 {
+	return imbue.Aura.GetStacks()
+}
+
+// Uses one charge, and removes the imbue from the weapon if it was the last.
+func (imbue *WeaponImbue) UseCharge(sim *Simulation) {
+	if imbue.MaxCharges == 0 || !imbue.IsActive() {
+		return
+	}
+
+	imbue.metrics.ChargesUsed++
+	imbue.Aura.RemoveStack(sim)
+	if imbue.Aura.GetStacks() == 0 {
+		imbue.fade(sim)
+	}
+}
+
+func (imbue *WeaponImbue) apply(sim *Simulation) {
+	imbue.Aura.Activate(sim)
+	if imbue.MaxCharges > 0 {
+		imbue.Aura.SetStacks(sim, imbue.MaxCharges)
+	}
+
+	if imbue.Duration > 0 {
+		if imbue.fadeAction != nil {
+			imbue.fadeAction.Cancel(sim)
+		}
+		imbue.fadeAction = StartDelayedAction(sim, DelayedActionOptions{
+			DoAt:     sim.CurrentTime + imbue.Duration,
+			OnAction: imbue.fade,
+		})
+	}
+}
+
+// Removes the imbue and its bonuses from the weapon. This isn't done by the
+// aura's OnExpire, since all auras expire at the end of each iteration.
+func (imbue *WeaponImbue) fade(sim *Simulation) {
+	if !imbue.IsActive() {
+		return
+	}
+
+	if imbue.fadeAction != nil {
+		imbue.fadeAction.Cancel(sim)
+		imbue.fadeAction = nil
+	}
+	imbue.Aura.Deactivate(sim)
+
+	if imbue.bonusesApplied {
+		imbue.character.AddStatsDynamic(sim, imbue.Stats.Invert())
+		imbue.addWeaponDamage(-imbue.WeaponDamage)
+		imbue.bonusesApplied = false
+	}
+}
+
+func (imbue *WeaponImbue) addWeaponDamage(damage float64) {
+	if damage == 0 {
+		return
+	}
+
+	weapon := imbue.character.AutoAttacks.MH()
+	if !imbue.IsMH {
+		weapon = imbue.character.AutoAttacks.OH()
+	}
+	weapon.BaseDamageMin += damage
+	weapon.BaseDamageMax += damage
+}
+
+func (character *Character) getWeaponImbueMetricsProto() []*proto.WeaponImbueMetrics {
+	var metrics []*proto.WeaponImbueMetrics
+	for _, imbue := range []*WeaponImbue{character.mainHandImbue, character.offHandImbue} {
+		if imbue != nil {
+			metrics = append(metrics, imbue.metrics.ToProto())
+		}
+	}
+	return metrics
+}
+
+func handLabel(isMH bool) string {
+	return Ternary(isMH, "MH", "OH")
+}
@@ -22,6 +22,7 @@ func RegisterBalanceDruid() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 func NewBalanceDruid(character *core.Character, options *proto.Player) *BalanceDruid {
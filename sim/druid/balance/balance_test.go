@@ -131,14 +131,6 @@ var Phase4Consumes = core.ConsumesCombo{
 	},
 }
 
-var PlayerOptionsAdaptive = &proto.Player_BalanceDruid{
-	BalanceDruid: &proto.BalanceDruid{
-		Options: &proto.BalanceDruid_Options{
-			OkfUptime: 0.2,
-		},
-	},
-}
-
 var ItemFilters = core.ItemFilter{
 	WeaponTypes: []proto.WeaponType{
 		proto.WeaponType_WeaponTypeDagger,
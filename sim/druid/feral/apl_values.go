@@ -114,7 +114,12 @@ func (impl *APLActionCatOptimalRotationAction) GetNextAction(*core.Simulation) *
 	return nil
 }
 
-func (cat *FeralDruid) newActionCatOptimalRotationAction(_ *core.APLRotation, config *proto.APLActionCatOptimalRotationAction) core.APLActionImpl {
+func (cat *FeralDruid) newActionCatOptimalRotationAction(rot *core.APLRotation, config *proto.APLActionCatOptimalRotationAction) core.APLActionImpl {
+	if cat.Shred == nil {
+		rot.ValidationWarning("%s can't use the optimal rotation before learning Shred", cat.Label)
+		return nil
+	}
+
 	cat.setupRotation(config)
 
 	return &APLActionCatOptimalRotationAction{
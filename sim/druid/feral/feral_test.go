@@ -106,36 +106,6 @@ var Phase2Talents = "-550002032320211-05"
 var Phase3Talents = "500005301-5500020323002-05"
 var Phase4Talents = "500005301-5500020323202151-15"
 
-var PlayerOptionsMonoCat = &proto.Player_FeralDruid{
-	FeralDruid: &proto.FeralDruid{
-		Options: &proto.FeralDruid_Options{
-			InnervateTarget:   &proto.UnitReference{}, // no Innervate
-			LatencyMs:         100,
-			AssumeBleedActive: true,
-		},
-	},
-}
-
-var PlayerOptionsMonoCatNoBleed = &proto.Player_FeralDruid{
-	FeralDruid: &proto.FeralDruid{
-		Options: &proto.FeralDruid_Options{
-			InnervateTarget:   &proto.UnitReference{}, // no Innervate
-			LatencyMs:         100,
-			AssumeBleedActive: false,
-		},
-	},
-}
-
-var PlayerOptionsFlowerCatAoe = &proto.Player_FeralDruid{
-	FeralDruid: &proto.FeralDruid{
-		Options: &proto.FeralDruid_Options{
-			InnervateTarget:   &proto.UnitReference{}, // no Innervate
-			LatencyMs:         100,
-			AssumeBleedActive: false,
-		},
-	},
-}
-
 var Phase1Consumes = core.ConsumesCombo{
 	Label: "Phase 1 Consumes",
 	Consumes: &proto.Consumes{
@@ -214,3 +184,23 @@ var Stats = []proto.Stat{
 	proto.Stat_StatMeleeCrit,
 	proto.Stat_StatMeleeHit,
 }
+
+var PlayerOptionsMonoCatNoBleed = &proto.Player_FeralDruid{
+	FeralDruid: &proto.FeralDruid{
+		Options: &proto.FeralDruid_Options{
+			InnervateTarget:   &proto.UnitReference{}, // no Innervate
+			LatencyMs:         100,
+			AssumeBleedActive: false,
+		},
+	},
+}
+
+var PlayerOptionsFlowerCatAoe = &proto.Player_FeralDruid{
+	FeralDruid: &proto.FeralDruid{
+		Options: &proto.FeralDruid_Options{
+			InnervateTarget:   &proto.UnitReference{}, // no Innervate
+			LatencyMs:         100,
+			AssumeBleedActive: false,
+		},
+	},
+}
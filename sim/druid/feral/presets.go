@@ -0,0 +1,71 @@
+package feral
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "phase_1",
+		Spec:        proto.Spec_SpecFeralDruid,
+		Class:       proto.Class_ClassDruid,
+		Race:        proto.Race_RaceTauren,
+		Phase:       1,
+		Level:       25,
+		GearSet:     "phase_1",
+		Rotation:    "phase_1",
+		Talents:     "TalentsPhase1",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsMonoCat,
+	},
+	{
+		Name:        "phase_2",
+		Spec:        proto.Spec_SpecFeralDruid,
+		Class:       proto.Class_ClassDruid,
+		Race:        proto.Race_RaceTauren,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "phase_2",
+		Rotation:    "phase_2",
+		Talents:     "TalentsPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsMonoCat,
+	},
+	{
+		Name:        "phase_3",
+		Spec:        proto.Spec_SpecFeralDruid,
+		Class:       proto.Class_ClassDruid,
+		Race:        proto.Race_RaceTauren,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "phase_3",
+		Rotation:    "phase_3",
+		Talents:     "TalentsPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsMonoCat,
+	},
+	{
+		Name:        "phase_4",
+		Spec:        proto.Spec_SpecFeralDruid,
+		Class:       proto.Class_ClassDruid,
+		Race:        proto.Race_RaceTauren,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "phase_4",
+		Rotation:    "phase_4",
+		Talents:     "TalentsPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsMonoCat,
+	},
+}
+
+var PlayerOptionsMonoCat = &proto.Player_FeralDruid{
+	FeralDruid: &proto.FeralDruid{
+		Options: &proto.FeralDruid_Options{
+			InnervateTarget:   &proto.UnitReference{}, // no Innervate
+			LatencyMs:         100,
+			AssumeBleedActive: true,
+		},
+	},
+}
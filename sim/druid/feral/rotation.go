@@ -671,7 +671,7 @@ func (cat *FeralDruid) doRotation(sim *core.Simulation) (bool, time.Duration) {
 		cat.readyToShift = true
 	} else if flowershiftNow && curEnergy < 42 {
 		cat.readyToGift = true
-	} else if (rotation.MangleSpam && !isClearcast) || cat.PseudoStats.InFrontOfTarget {
+	} else if (rotation.MangleSpam && !isClearcast) || cat.IsInFrontOf(cat.CurrentTarget) {
 		if cat.MangleCat != nil && excessE >= cat.CurrentMangleCatCost() {
 			cat.MangleCat.Cast(sim, cat.CurrentTarget)
 			return false, 0
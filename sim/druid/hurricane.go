@@ -36,6 +36,9 @@ func (druid *Druid) registerHurricaneSpell() {
 		}
 	}
 
+	// Hurricane stays where it was cast, even if the druid or target moves.
+	var center core.Vector2
+
 	for i, rank := range ranks {
 		if druid.Level < rank.level {
 			break
@@ -47,6 +50,8 @@ func (druid *Druid) registerHurricaneSpell() {
 			SpellSchool: core.SpellSchoolNature,
 			ProcMask:    core.ProcMaskSpellDamage,
 			Flags:       SpellFlagOmen | core.SpellFlagChanneled | core.SpellFlagBinary | core.SpellFlagAPL,
+			MaxRange:    30,
+			Aoe:         core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 
 			RequiredLevel: int(rank.level),
 			Rank:          i + 1,
@@ -79,13 +84,14 @@ func (druid *Druid) registerHurricaneSpell() {
 					dot.Snapshot(target, damage, isRollover)
 				},
 				OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-					for _, aoeTarget := range sim.Encounter.TargetUnits {
+					for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 						dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 					}
 				},
 			},
 
-			ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				center = spell.AoeCenter(target)
 				druid.AutoAttacks.CancelAutoSwing(sim)
 				spell.AOEDot().Apply(sim)
 			},
@@ -18,12 +18,15 @@ func (druid *Druid) registerShredSpell() {
 	// has6pCunningOfStormrage := druid.HasSetBonus(ItemSetCunningOfStormrage, 6)
 
 	damageMultiplier := 2.25
-	flatDamageBonus := map[int32]float64{
-		25: 24,
-		40: 44,
-		50: 64,
-		60: 80,
-	}[druid.Level] * ShredFlatDmgMultiplier
+	levels := [6]int{0, 22, 30, 38, 46, 54}
+	spellIDs := [6]int32{0, 5221, 6800, 8992, 9829, 9830}
+	rank, ok := druid.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	// Rank 2 is an estimate between its neighbouring ranks
+	flatDamageBonus := [6]float64{0, 24, 32, 44, 64, 80}[rank] * ShredFlatDmgMultiplier
 
 	if druid.Ranged().ID == IdolOfTheDream {
 		damageMultiplier *= 1.02
@@ -35,13 +38,8 @@ func (druid *Druid) registerShredSpell() {
 	damageMultiplier += ShredWeaponMultiplierBuff
 
 	druid.Shred = druid.RegisterSpell(Cat, core.SpellConfig{
-		SpellCode: SpellCode_DruidShred,
-		ActionID: core.ActionID{SpellID: map[int32]int32{
-			25: 5221,
-			40: 8992,
-			50: 9829,
-			60: 9830,
-		}[druid.Level]},
+		SpellCode:   SpellCode_DruidShred,
+		ActionID:    core.ActionID{SpellID: spellIDs[rank]},
 		SpellSchool: core.SpellSchoolPhysical,
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
@@ -58,7 +56,7 @@ func (druid *Druid) registerShredSpell() {
 			IgnoreHaste: true,
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return !druid.PseudoStats.InFrontOfTarget
+			return !druid.IsInFrontOf(target)
 		},
 
 		DamageMultiplier: damageMultiplier,
@@ -109,7 +107,7 @@ func (druid *Druid) registerShredSpell() {
 }
 
 func (druid *Druid) CanShred() bool {
-	return !druid.PseudoStats.InFrontOfTarget && druid.CurrentEnergy() >= druid.CurrentShredCost()
+	return druid.Shred != nil && !druid.IsInFrontOf(druid.CurrentTarget) && druid.CurrentEnergy() >= druid.CurrentShredCost()
 }
 
 func (druid *Druid) CurrentShredCost() float64 {
@@ -34,6 +34,7 @@ func (druid *Druid) registerStarfallCD() {
 		SpellSchool: core.SpellSchoolArcane,
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskEmpty,
+		Aoe:         core.AoeConfig{Radius: 5, CenteredOnTarget: true},
 
 		BonusCritRating:  druid.ImprovedMoonfireCritBonus(),
 		DamageMultiplier: 1,
@@ -41,7 +42,7 @@ func (druid *Druid) registerStarfallCD() {
 		BonusCoefficient: spellCoefSplash,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDamageSplash, spell.OutcomeMagicHitAndCrit)
 			}
 		},
@@ -20,12 +20,10 @@ const SwipeThreatMultiplier = 2.0
 func (druid *Druid) registerSwipeBearSpell() {
 	hasImprovedSwipeRune := druid.HasRune(proto.DruidRune_RuneCloakImprovedSwipe)
 
-	rank := map[int32]int{
-		25: 2,
-		40: 3,
-		50: 4,
-		60: 6,
-	}[druid.Level]
+	rank, ok := druid.GetSpellRankForLevel(SwipeLevel[:], SwipeSpellId[:])
+	if !ok {
+		return
+	}
 
 	level := SwipeLevel[rank]
 	spellID := SwipeSpellId[rank]
@@ -47,6 +45,7 @@ func (druid *Druid) registerSwipeBearSpell() {
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
 		Flags:       SpellFlagOmen | core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		Aoe:         core.AoeConfig{Radius: 8, ConeAngle: 180},
 
 		Rank:          rank,
 		RequiredLevel: level,
@@ -66,12 +65,19 @@ func (druid *Druid) registerSwipeBearSpell() {
 		ThreatMultiplier: SwipeThreatMultiplier,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx := range results {
-				results[idx] = spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMeleeSpecialHitAndCrit)
-				target = sim.Environment.NextTargetUnit(target)
+			// The primary target is always hit, and then the next targets in front of the druid.
+			center := spell.AoeCenter(target)
+			numHits := 0
+			curTarget := target
+			for i := int32(0); i < sim.Environment.GetNumTargets() && numHits < len(results); i++ {
+				if curTarget == target || spell.InAoe(center, curTarget) {
+					results[numHits] = spell.CalcDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeSpecialHitAndCrit)
+					numHits++
+				}
+				curTarget = sim.Environment.NextTargetUnit(curTarget)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:numHits] {
 				spell.DealDamage(sim, result)
 			}
 		},
@@ -91,6 +97,7 @@ func (druid *Druid) registerSwipeCatSpell() {
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
 		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL | SpellFlagOmen | SpellFlagBuilder,
+		Aoe:         core.AoeConfig{Radius: 8, ConeAngle: 180},
 
 		EnergyCost: core.EnergyCostOptions{
 			Cost: 50 - float64(druid.Talents.Ferocity),
@@ -108,13 +115,11 @@ func (druid *Druid) registerSwipeCatSpell() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
-			aoeTarget := target
-			for i := 0; i < len(sim.Encounter.TargetUnits); i++ {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				result := spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMeleeSpecialHitAndCrit)
-				if i == 0 && result.Landed() {
+				if aoeTarget == target && result.Landed() {
 					druid.AddComboPoints(sim, 1, spell.ComboPointMetrics())
 				}
-				aoeTarget = sim.Environment.NextTargetUnit(target)
 			}
 		},
 	})
@@ -22,6 +22,7 @@ func (hunter *Hunter) registerCarveSpell() {
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
 		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		Aoe:         core.AoeConfig{Radius: 8, ConeAngle: 180},
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.04,
@@ -40,7 +41,7 @@ func (hunter *Hunter) registerCarveSpell() {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				hunter.CarveMH.Cast(sim, aoeTarget)
 				if hunter.AutoAttacks.IsDualWielding {
 					hunter.CarveOH.Cast(sim, aoeTarget)
@@ -15,7 +15,8 @@ func (hunter *Hunter) getExplosiveTrapConfig(rank int, timer *core.Timer) core.S
 	manaCost := [4]float64{0, 275, 395, 520}[rank]
 	level := [4]int{0, 34, 44, 54}[rank]
 
-	numHits := hunter.Env.GetNumTargets()
+	// The trap stays where it landed, even if the hunter or target moves.
+	var center core.Vector2
 
 	return core.SpellConfig{
 		ActionID:      core.ActionID{SpellID: spellId},
@@ -26,6 +27,7 @@ func (hunter *Hunter) getExplosiveTrapConfig(rank int, timer *core.Timer) core.S
 		Rank:          rank,
 		RequiredLevel: level,
 		MissileSpeed:  24,
+		Aoe:           core.AoeConfig{Radius: 10, CenteredOnTarget: true},
 
 		ManaCost: core.ManaCostOptions{
 			FlatCost: manaCost * hunter.resourcefulnessManacostModifier(),
@@ -62,7 +64,7 @@ func (hunter *Hunter) getExplosiveTrapConfig(rank int, timer *core.Timer) core.S
 				dot.Snapshot(target, dotDamage, isRollover)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 					// Explosive Trap DoT only does damage if the target does not have an immolation trap ticking on them
 					if !aoeTarget.HasActiveAuraWithTag("ImmolationTrap") {
 						dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
@@ -72,14 +74,13 @@ func (hunter *Hunter) getExplosiveTrapConfig(rank int, timer *core.Timer) core.S
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			center = spell.AoeCenter(target)
 			spell.WaitTravelTime(sim, func(s *core.Simulation) {
-				curTarget := target
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+				for _, aoeTarget := range spell.AoeTargetsAt(center) {
 					baseDamage := sim.Roll(minDamage, maxDamage)
 					baseDamage += hunter.tntDamageFlatBonus()
 					baseDamage *= sim.Encounter.AOECapMultiplier()
-					spell.CalcAndDealDamage(sim, curTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
-					curTarget = sim.Environment.NextTargetUnit(curTarget)
+					spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 				}
 				spell.AOEDot().ApplyOrReset(sim)
 			})
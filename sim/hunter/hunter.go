@@ -64,6 +64,7 @@ func RegisterHunter() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type Hunter struct {
@@ -108,6 +109,7 @@ type Hunter struct {
 	CarveMH        *core.Spell
 	CarveOH        *core.Spell
 	WingClip       *core.Spell
+	RevivePet      *core.Spell
 
 	Shots       []*core.Spell
 	Strikes     []*core.Spell
@@ -217,6 +219,7 @@ func (hunter *Hunter) Initialize() {
 	// hunter.registerKillCommand()
 	hunter.registerRapidFire()
 	hunter.registerFocusFireSpell()
+	hunter.registerRevivePetSpell()
 }
 
 func (hunter *Hunter) Reset(sim *core.Simulation) {
@@ -220,40 +220,6 @@ var Phase4Consumes = core.ConsumesCombo{
 	},
 }
 
-var Phase1PlayerOptions = &proto.Player_Hunter{
-	Hunter: &proto.Hunter{
-		Options: &proto.Hunter_Options{
-			Ammo:           proto.Hunter_Options_RazorArrow,
-			PetType:        proto.Hunter_Options_Cat,
-			PetUptime:      1,
-			PetAttackSpeed: 2.0,
-		},
-	},
-}
-
-var Phase2PlayerOptions = &proto.Player_Hunter{
-	Hunter: &proto.Hunter{
-		Options: &proto.Hunter_Options{
-			Ammo:           proto.Hunter_Options_JaggedArrow,
-			PetType:        proto.Hunter_Options_Cat,
-			PetUptime:      1,
-			PetAttackSpeed: 2.0,
-		},
-	},
-}
-
-var Phase4PlayerOptions = &proto.Player_Hunter{
-	Hunter: &proto.Hunter{
-		Options: &proto.Hunter_Options{
-			Ammo:                 proto.Hunter_Options_JaggedArrow,
-			PetType:              proto.Hunter_Options_PetNone,
-			PetUptime:            1,
-			PetAttackSpeed:       2.0,
-			SniperTrainingUptime: 1.0,
-		},
-	},
-}
-
 var ItemFilters = core.ItemFilter{
 	ArmorType: proto.ArmorType_ArmorTypeMail,
 	WeaponTypes: []proto.WeaponType{
@@ -280,3 +246,14 @@ var Stats = []proto.Stat{
 	proto.Stat_StatMeleeCrit,
 	proto.Stat_StatMeleeHit,
 }
+
+var Phase1PlayerOptions = &proto.Player_Hunter{
+	Hunter: &proto.Hunter{
+		Options: &proto.Hunter_Options{
+			Ammo:           proto.Hunter_Options_RazorArrow,
+			PetType:        proto.Hunter_Options_Cat,
+			PetUptime:      1,
+			PetAttackSpeed: 2.0,
+		},
+	},
+}
@@ -427,12 +427,13 @@ func init() {
 			DefenseType: 	core.DefenseTypeMagic,
 			ProcMask: 		core.ProcMaskSpellDamage,
 			Flags: 			core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell,
+			Aoe: 			core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 
 			DamageMultiplier: 1,
 			ThreatMultiplier: 1,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					damage := sim.Roll(185, 210)
 					spell.CalcAndDealDamage(sim, aoeTarget, damage, spell.OutcomeMagicHitAndCrit)
 				}
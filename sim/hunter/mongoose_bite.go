@@ -7,11 +7,16 @@ import (
 	"github.com/wowsims/sod/sim/core/proto"
 )
 
+const MongooseBiteRanks = 4
+
+var MongooseBiteSpellId = [MongooseBiteRanks + 1]int32{0, 1495, 14269, 14270, 14271}
+var MongooseBiteLevel = [MongooseBiteRanks + 1]int{0, 16, 30, 44, 58}
+
 func (hunter *Hunter) getMongooseBiteConfig(rank int) core.SpellConfig {
-	spellId := [5]int32{0, 1495, 14269, 14270, 14271}[rank]
-	baseDamage := [5]float64{0, 25, 45, 75, 115}[rank]
-	manaCost := [5]float64{0, 30, 40, 50, 65}[rank]
-	level := [5]int{0, 16, 30, 44, 58}[rank]
+	spellId := MongooseBiteSpellId[rank]
+	baseDamage := [MongooseBiteRanks + 1]float64{0, 25, 45, 75, 115}[rank]
+	manaCost := [MongooseBiteRanks + 1]float64{0, 30, 40, 50, 65}[rank]
+	level := MongooseBiteLevel[rank]
 
 	hasCobraSlayer := hunter.HasRune(proto.HunterRune_RuneHandsCobraSlayer)
 	hasRaptorFury := hunter.HasRune(proto.HunterRune_RuneBracersRaptorFury)
@@ -90,12 +95,10 @@ func (hunter *Hunter) registerMongooseBiteSpell() {
 		},
 	})
 
-	rank := map[int32]int{
-		25: 1,
-		40: 2,
-		50: 3,
-		60: 4,
-	}[hunter.Level]
+	rank, ok := hunter.GetSpellRankForLevel(MongooseBiteLevel[:], MongooseBiteSpellId[:])
+	if !ok {
+		return
+	}
 
 	config := hunter.getMongooseBiteConfig(rank)
 	hunter.MongooseBite = hunter.GetOrRegisterSpell(config)
@@ -25,6 +25,115 @@ type HunterPet struct {
 
 	uptimePercent    float64
 	hasOwnerCooldown bool
+
+	cleaveDamagePerSecond float64
+	cleaveAction          *core.PendingAction
+	onDeath               func(sim *core.Simulation)
+	reviveAt              time.Duration // Earliest time Revive Pet can be cast after the pet dies.
+}
+
+// Base stats of a hunter pet at a given level, before family modifiers.
+type petBaseStats struct {
+	Level int32
+
+	// Auto attack damage per second of weapon speed.
+	MinDamage float64
+	MaxDamage float64
+
+	Stats stats.Stats
+}
+
+// Known pet base stats. Levels in between are linearly interpolated.
+var petBaseStatsByLevel = []petBaseStats{
+	{
+		Level:     25,
+		MinDamage: 6.5,
+		MaxDamage: 12.5,
+		Stats: stats.Stats{
+			stats.Strength:  53,
+			stats.Agility:   45,
+			stats.Stamina:   120,
+			stats.Intellect: 29,
+			stats.Spirit:    39,
+		},
+	},
+	{
+		Level:     40,
+		MinDamage: 9.5,
+		MaxDamage: 15.5,
+		Stats: stats.Stats{
+			stats.Strength:  78,
+			stats.Agility:   66,
+			stats.Stamina:   160,
+			stats.Intellect: 37,
+			stats.Spirit:    55,
+		},
+	},
+	{
+		Level:     50,
+		MinDamage: 23.5,
+		MaxDamage: 27.5,
+		Stats: stats.Stats{
+			stats.Strength:  113,
+			stats.Agility:   82,
+			stats.Stamina:   257,
+			stats.Intellect: 43,
+			stats.Spirit:    67,
+		},
+	},
+	{
+		// Carried over from the old per-level table, where they were marked TODO.
+		// Not yet checked against level 60 logs.
+		Level:     60,
+		MinDamage: 18.5,
+		MaxDamage: 28.0,
+		Stats: stats.Stats{
+			stats.Strength:  136,
+			stats.Agility:   100,
+			stats.Stamina:   274,
+			stats.Intellect: 50,
+			stats.Spirit:    80,
+		},
+	},
+}
+
+func getPetBaseStats(level int32) petBaseStats {
+	if level <= petBaseStatsByLevel[0].Level {
+		return petBaseStatsByLevel[0]
+	}
+
+	for i := 1; i < len(petBaseStatsByLevel); i++ {
+		upper := petBaseStatsByLevel[i]
+		if level > upper.Level {
+			continue
+		}
+
+		lower := petBaseStatsByLevel[i-1]
+		ratio := float64(level-lower.Level) / float64(upper.Level-lower.Level)
+		lerp := func(a, b float64) float64 {
+			return a + (b-a)*ratio
+		}
+
+		baseStats := petBaseStats{
+			Level:     level,
+			MinDamage: lerp(lower.MinDamage, upper.MinDamage),
+			MaxDamage: lerp(lower.MaxDamage, upper.MaxDamage),
+		}
+		for stat := range baseStats.Stats {
+			baseStats.Stats[stat] = lerp(lower.Stats[stat], upper.Stats[stat])
+		}
+		return baseStats
+	}
+
+	return petBaseStatsByLevel[len(petBaseStatsByLevel)-1]
+}
+
+// Loyalty level of the pet, from 1 (Rebellious) to 6 (Best Friend).
+func petLoyaltyLevel(loyalty proto.Hunter_Options_PetLoyalty) int32 {
+	if loyalty == proto.Hunter_Options_BestFriend {
+		return 6
+	}
+	return int32(loyalty)
 }
 
 func (hunter *Hunter) NewHunterPet() *HunterPet {
@@ -36,10 +145,6 @@ func (hunter *Hunter) NewHunterPet() *HunterPet {
 	}
 	petConfig := PetConfigs[hunter.Options.PetType]
 
-	hunterPetBaseStats := stats.Stats{}
-
-	baseMinDamage := 0.0
-	baseMaxDamage := 0.0
 	attackSpeed := 2.0
 
 	switch hunter.Options.PetAttackSpeed {
@@ -65,68 +170,30 @@ func (hunter *Hunter) NewHunterPet() *HunterPet {
 		attackSpeed = 2.5
 	}
 
-	switch hunter.Level {
-	case 25:
-		baseMinDamage = 6.5 * attackSpeed
-		baseMaxDamage = 12.5 * attackSpeed
-		hunterPetBaseStats = stats.Stats{
-			stats.Strength:  53,
-			stats.Agility:   45,
-			stats.Stamina:   120,
-			stats.Intellect: 29,
-			stats.Spirit:    39,
-
-			stats.AttackPower: -20,
+	baseStats := getPetBaseStats(hunter.Level)
+	hunterPetBaseStats := baseStats.Stats.Add(stats.Stats{
+		stats.AttackPower: -20,
 
-			// Add 1.8% because pets aren't affected by that component of crit suppression.
-			stats.MeleeCrit: (3.2 + 1.8) * core.CritRatingPerCritChance,
-		}
-	case 40:
-		baseMinDamage = 9.5 * attackSpeed
-		baseMaxDamage = 15.5 * attackSpeed
-		hunterPetBaseStats = stats.Stats{
-			stats.Strength:  78,
-			stats.Agility:   66,
-			stats.Stamina:   160,
-			stats.Intellect: 37,
-			stats.Spirit:    55,
-
-			stats.AttackPower: -20,
-
-			// Add 1.8% because pets aren't affected by that component of crit suppression.
-			stats.MeleeCrit: (3.2 + 1.8) * core.CritRatingPerCritChance,
-		}
-	case 50:
-		baseMinDamage = 23.5 * attackSpeed
-		baseMaxDamage = 27.5 * attackSpeed
-		hunterPetBaseStats = stats.Stats{
-			stats.Strength:  113,
-			stats.Agility:   82,
-			stats.Stamina:   257,
-			stats.Intellect: 43,
-			stats.Spirit:    67,
-
-			stats.AttackPower: -20,
-
-			// Add 1.8% because pets aren't affected by that component of crit suppression.
-			stats.MeleeCrit: (3.2 + 1.8) * core.CritRatingPerCritChance,
-		}
-	case 60:
-		// TODO:
-		baseMinDamage = 18.5 * attackSpeed
-		baseMaxDamage = 28.0 * attackSpeed
-		hunterPetBaseStats = stats.Stats{
-			stats.Strength:  136,
-			stats.Agility:   100,
-			stats.Stamina:   274,
-			stats.Intellect: 50,
-			stats.Spirit:    80,
+		// Add 1.8% because pets aren't affected by that component of crit suppression.
+		stats.MeleeCrit: (3.2 + 1.8) * core.CritRatingPerCritChance,
+	})
 
-			stats.AttackPower: -20,
+	trainingPoints := hunter.Level * (petLoyaltyLevel(hunter.Options.PetLoyalty) - 1)
+	if hunter.Options.PetTrainingPoints > trainingPoints {
+		panic(fmt.Sprintf("Pet training uses %d training points, but a level %d pet with %s loyalty only has %d.",
+			hunter.Options.PetTrainingPoints, hunter.Level, hunter.Options.PetLoyalty, trainingPoints))
+	}
 
-			// Add 1.8% because pets aren't affected by that component of crit suppression.
-			stats.MeleeCrit: (3.2 + 1.8) * core.CritRatingPerCritChance,
-		}
+	if training := hunter.Options.PetTraining; training != nil {
+		hunterPetBaseStats = hunterPetBaseStats.Add(stats.Stats{
+			stats.Stamina:          float64(training.GreatStamina),
+			stats.Armor:            float64(training.NaturalArmor),
+			stats.ArcaneResistance: float64(training.ArcaneResistance),
+			stats.FireResistance:   float64(training.FireResistance),
+			stats.FrostResistance:  float64(training.FrostResistance),
+			stats.NatureResistance: float64(training.NatureResistance),
+			stats.ShadowResistance: float64(training.ShadowResistance),
+		})
 	}
 
 	hp := &HunterPet{
@@ -135,12 +202,14 @@ func (hunter *Hunter) NewHunterPet() *HunterPet {
 		hunterOwner: hunter,
 
 		hasOwnerCooldown: petConfig.SpecialAbility == FuriousHowl,
+
+		cleaveDamagePerSecond: max(0, hunter.Options.PetCleaveDps),
 	}
 
 	hp.EnableAutoAttacks(hp, core.AutoAttackOptions{
 		MainHand: core.Weapon{
-			BaseDamageMin: baseMinDamage,
-			BaseDamageMax: baseMaxDamage,
+			BaseDamageMin: baseStats.MinDamage * attackSpeed,
+			BaseDamageMax: baseStats.MaxDamage * attackSpeed,
 			SwingSpeed:    attackSpeed,
 		},
 		AutoSwingMelee: true,
@@ -152,7 +221,12 @@ func (hunter *Hunter) NewHunterPet() *HunterPet {
 	//hp.AutoAttacks.MHConfig().DamageMultiplier *= 0.45
 
 	// Happiness
-	hp.PseudoStats.DamageDealtMultiplier *= 1.25
+	switch hunter.Options.PetHappiness {
+	case proto.Hunter_Options_Happy:
+		hp.PseudoStats.DamageDealtMultiplier *= 1.25
+	case proto.Hunter_Options_Unhappy:
+		hp.PseudoStats.DamageDealtMultiplier *= 0.75
+	}
 
 	// Family scalars
 	hp.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] *= hp.config.Damage
@@ -176,13 +250,6 @@ func (hp *HunterPet) GetPet() *core.Pet {
 	return &hp.Pet
 }
 
-func (hp *HunterPet) Talents() *proto.HunterPetTalents {
-	if talents := hp.hunterOwner.Options.PetTalents; talents != nil {
-		return talents
-	}
-	return &proto.HunterPetTalents{}
-}
-
 func (hp *HunterPet) Initialize() {
 	hp.specialAbility = hp.NewPetAbility(hp.config.SpecialAbility, true)
 	hp.focusDump = hp.NewPetAbility(hp.config.FocusDump, false)
@@ -192,15 +259,24 @@ func (hp *HunterPet) Initialize() {
 			hp.OnGCDReady(sim)
 		}
 	})
+
+	if hp.cleaveDamagePerSecond > 0 {
+		hp.registerCleaveDamage()
+	}
 }
 
 func (hp *HunterPet) Reset(_ *core.Simulation) {
 	hp.uptimePercent = min(1, max(0, hp.hunterOwner.Options.PetUptime))
+	hp.reviveAt = 0
+}
+
+// Whether the fight is far enough along that the pet has been dismissed for good.
+func (hp *HunterPet) pastUptime(sim *core.Simulation) bool {
+	return sim.GetRemainingDurationPercent() < 1.0-hp.uptimePercent
 }
 
 func (hp *HunterPet) ExecuteCustomRotation(sim *core.Simulation) {
-	percentRemaining := sim.GetRemainingDurationPercent()
-	if percentRemaining < 1.0-hp.uptimePercent { // once fight is % completed, disable pet.
+	if hp.pastUptime(sim) { // once fight is % completed, disable pet.
 		hp.Disable(sim)
 		return
 	}
@@ -299,15 +375,14 @@ type PetConfig struct {
 	RandomSelection bool
 }
 
-// Abilities reference: https://wotlk.wowhead.com/hunter-pets
-// https://wotlk.wowhead.com/guides/hunter-dps-best-pets-taming-loyalty-burning-crusade-classic
+// Family modifiers reference: https://classic.wowhead.com/guides/hunter-pet-families-abilities-classic-wow
 var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 	proto.Hunter_Options_Cat: {
 		Name:           "Cat",
 		SpecialAbility: Bite,
 		FocusDump:      Claw,
 
-		Health: 1.0,
+		Health: 0.98,
 		Armor:  1.0,
 		Damage: 1.1,
 	},
@@ -327,25 +402,33 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 
 		Health: 1.0,
 		Armor:  1.0,
-		Damage: 1.0,
+		Damage: 1.07,
 	},
 	proto.Hunter_Options_Bear: {
 		Name:           "Bear",
 		SpecialAbility: Swipe,
 		FocusDump:      Claw,
 
+		Health: 1.08,
+		Armor:  1.05,
+		Damage: 0.91,
+	},
+	proto.Hunter_Options_BirdOfPrey: {
+		Name:      "Owl",
+		FocusDump: Claw,
+
 		Health: 1.0,
 		Armor:  1.0,
-		Damage: 1.0,
+		Damage: 1.07,
 	},
 	proto.Hunter_Options_Boar: {
 		Name: "Boar",
 		//SpecialAbility: Gore,
 		FocusDump: Bite,
 
-		Health: 1.0,
-		Armor:  1.0,
-		Damage: 1.0,
+		Health: 1.04,
+		Armor:  1.09,
+		Damage: 0.9,
 	},
 	proto.Hunter_Options_CarrionBird: {
 		Name:           "Carrion Bird",
@@ -353,7 +436,7 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		FocusDump:      Bite,
 
 		Health: 1.0,
-		Armor:  1.0,
+		Armor:  1.05,
 		Damage: 1.0,
 	},
 	proto.Hunter_Options_Chimaera: {
@@ -379,17 +462,17 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		//SpecialAbility: Pin,
 		FocusDump: Claw,
 
-		Health: 1.0,
-		Armor:  1.0,
-		Damage: 1.0,
+		Health: 0.96,
+		Armor:  1.13,
+		Damage: 0.95,
 	},
 	proto.Hunter_Options_Crocolisk: {
 		Name: "Crocolisk",
 		//SpecialAbility: BadAttitude,
 		FocusDump: Bite,
 
-		Health: 1.0,
-		Armor:  1.0,
+		Health: 0.95,
+		Armor:  1.1,
 		Damage: 1.0,
 	},
 	proto.Hunter_Options_Devilsaur: {
@@ -415,9 +498,9 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		//SpecialAbility: Pummel,
 		//FocusDump: Smack,
 
-		Health: 1.0,
+		Health: 1.04,
 		Armor:  1.0,
-		Damage: 1.0,
+		Damage: 1.02,
 	},
 	proto.Hunter_Options_Hyena: {
 		Name: "Hyena",
@@ -425,7 +508,7 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		FocusDump: Bite,
 
 		Health: 1.0,
-		Armor:  1.0,
+		Armor:  1.05,
 		Damage: 1.0,
 	},
 	proto.Hunter_Options_Raptor: {
@@ -433,9 +516,9 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		//SpecialAbility: SavageRend,
 		FocusDump: Claw,
 
-		Health: 1.0,
-		Armor:  1.0,
-		Damage: 1.0,
+		Health: 0.95,
+		Armor:  1.03,
+		Damage: 1.1,
 	},
 	proto.Hunter_Options_Scorpid: {
 		Name:           "Scorpid",
@@ -443,8 +526,8 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		FocusDump:      Bite,
 
 		Health: 1.0,
-		Armor:  1.0,
-		Damage: 1.0,
+		Armor:  1.1,
+		Damage: 0.94,
 	},
 	proto.Hunter_Options_Serpent: {
 		Name: "Serpent",
@@ -471,7 +554,7 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 
 		Health: 1.0,
 		Armor:  1.0,
-		Damage: 1.0,
+		Damage: 1.07,
 	},
 	proto.Hunter_Options_SpiritBeast: {
 		Name: "Spirit Beast",
@@ -496,7 +579,7 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		//SpecialAbility:   DustCloud,
 		FocusDump: Claw,
 
-		Health: 1.0,
+		Health: 1.05,
 		Armor:  1.0,
 		Damage: 1.0,
 	},
@@ -506,8 +589,8 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		FocusDump: Bite,
 
 		Health: 1.0,
-		Armor:  1.0,
-		Damage: 1.0,
+		Armor:  1.13,
+		Damage: 0.9,
 	},
 	proto.Hunter_Options_Wolf: {
 		Name:           "Wolf",
@@ -515,7 +598,7 @@ var PetConfigs = map[proto.Hunter_Options_PetType]PetConfig{
 		FocusDump:      Bite,
 
 		Health: 1.0,
-		Armor:  1.0,
+		Armor:  1.05,
 		Damage: 1.0,
 	},
 }
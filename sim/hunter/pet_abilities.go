@@ -45,27 +45,40 @@ func (hp *HunterPet) NewPetAbility(abilityType PetAbilityType, isPrimary bool) *
 	}
 }
 
+// A trainable rank of a pet ability.
+type petAbilityRank struct {
+	SpellID   int32
+	Level     int32
+	MinDamage float64
+	MaxDamage float64
+}
+
+// Returns the highest rank of an ability the pet has learned at its level, or
+// false if it can't learn the ability yet. Ranks must be ordered by level.
+func (hp *HunterPet) getAbilityRank(ranks []petAbilityRank) (petAbilityRank, bool) {
+	levels := append([]int{0}, core.MapSlice(ranks, func(rank petAbilityRank) int { return int(rank.Level) })...)
+	spellIDs := append([]int32{0}, core.MapSlice(ranks, func(rank petAbilityRank) int32 { return rank.SpellID })...)
+	rank, ok := hp.GetSpellRankForLevel(levels, spellIDs)
+	return ranks[rank-1], ok
+}
+
+var clawRanks = []petAbilityRank{
+	{SpellID: 16827, Level: 1, MinDamage: 4, MaxDamage: 6},
+	{SpellID: 16828, Level: 8, MinDamage: 8, MaxDamage: 12},
+	{SpellID: 16829, Level: 16, MinDamage: 12, MaxDamage: 16},
+	{SpellID: 16830, Level: 24, MinDamage: 16, MaxDamage: 22},
+	{SpellID: 16831, Level: 32, MinDamage: 21, MaxDamage: 29},
+	{SpellID: 16832, Level: 40, MinDamage: 26, MaxDamage: 36},
+	{SpellID: 3010, Level: 48, MinDamage: 35, MaxDamage: 49},
+	{SpellID: 3009, Level: 56, MinDamage: 43, MaxDamage: 59},
+}
+
 func (hp *HunterPet) newClaw() *core.Spell {
-	baseDamageMin := map[int32]float64{
-		25: 16,
-		40: 26,
-		50: 35,
-		60: 43,
-	}[hp.Owner.Level]
-
-	baseDamageMax := map[int32]float64{
-		25: 22,
-		40: 36,
-		50: 49,
-		60: 59,
-	}[hp.Owner.Level]
-
-	spellID := map[int32]int32{
-		25: 16830,
-		40: 16832,
-		50: 3010,
-		60: 3009,
-	}[hp.Owner.Level]
+	rank, ok := hp.getAbilityRank(clawRanks)
+	if !ok {
+		return nil
+	}
+	spellID, baseDamageMin, baseDamageMax := rank.SpellID, rank.MinDamage, rank.MaxDamage
 
 	return hp.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellID},
@@ -97,27 +110,23 @@ func (hp *HunterPet) newClaw() *core.Spell {
 	})
 }
 
+var biteRanks = []petAbilityRank{
+	{SpellID: 17253, Level: 1, MinDamage: 7, MaxDamage: 9},
+	{SpellID: 17255, Level: 8, MinDamage: 13, MaxDamage: 15},
+	{SpellID: 17256, Level: 16, MinDamage: 20, MaxDamage: 24},
+	{SpellID: 17257, Level: 24, MinDamage: 31, MaxDamage: 37},
+	{SpellID: 17258, Level: 32, MinDamage: 40, MaxDamage: 48},
+	{SpellID: 17259, Level: 40, MinDamage: 49, MaxDamage: 59},
+	{SpellID: 17260, Level: 48, MinDamage: 66, MaxDamage: 80},
+	{SpellID: 17261, Level: 56, MinDamage: 81, MaxDamage: 91},
+}
+
 func (hp *HunterPet) newBite() *core.Spell {
-	baseDamageMin := map[int32]float64{
-		25: 31,
-		40: 49,
-		50: 66,
-		60: 81,
-	}[hp.Owner.Level]
-
-	baseDamageMax := map[int32]float64{
-		25: 37,
-		40: 59,
-		50: 80,
-		60: 91,
-	}[hp.Owner.Level]
-
-	spellID := map[int32]int32{
-		25: 17257,
-		40: 17259,
-		50: 17260,
-		60: 17261,
-	}[hp.Owner.Level]
+	rank, ok := hp.getAbilityRank(biteRanks)
+	if !ok {
+		return nil
+	}
+	spellID, baseDamageMin, baseDamageMax := rank.SpellID, rank.MinDamage, rank.MaxDamage
 
 	return hp.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellID},
@@ -153,27 +162,19 @@ func (hp *HunterPet) newBite() *core.Spell {
 	})
 }
 
+// Rank 4 is not available in SoD.
+var lightningBreathRanks = []petAbilityRank{
+	{SpellID: 25009, Level: 24, MinDamage: 36, MaxDamage: 41},
+	{SpellID: 25011, Level: 48, MinDamage: 78, MaxDamage: 91},
+	{SpellID: 25012, Level: 60, MinDamage: 99, MaxDamage: 113},
+}
+
 func (hp *HunterPet) newLightningBreath() *core.Spell {
-	baseDamageMin := map[int32]float64{
-		25: 36,
-		40: 36,
-		50: 78,
-		60: 99,
-	}[hp.Owner.Level]
-
-	baseDamageMax := map[int32]float64{
-		25: 41,
-		40: 41,
-		50: 91,
-		60: 113,
-	}[hp.Owner.Level]
-
-	spellID := map[int32]int32{
-		25: 25009,
-		40: 25009, // rank 4 not available in SoD Phase 2
-		50: 25011,
-		60: 25012,
-	}[hp.Owner.Level]
+	rank, ok := hp.getAbilityRank(lightningBreathRanks)
+	if !ok {
+		return nil
+	}
+	spellID, baseDamageMin, baseDamageMax := rank.SpellID, rank.MinDamage, rank.MaxDamage
 
 	return hp.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellID},
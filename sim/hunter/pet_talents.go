@@ -1,4 +0,0 @@
-package hunter
-
-func (hp *HunterPet) ApplyTalents() {
-}
@@ -0,0 +1,150 @@
+package hunter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func petDeathRaidSimRequest(cleaveDps float64) *proto.RaidSimRequest {
+	player := &proto.Player{
+		Race:      proto.Race_RaceOrc,
+		Class:     proto.Class_ClassHunter,
+		Level:     40,
+		Equipment: core.GetGearSet("../../ui/hunter/gear_sets", "p2_melee").GearSet,
+		Consumes:  &proto.Consumes{},
+		Spec: &proto.Player_Hunter{
+			Hunter: &proto.Hunter{
+				Options: &proto.Hunter_Options{
+					PetType:        proto.Hunter_Options_Cat,
+					PetUptime:      1,
+					PetCleaveDps:   cleaveDps,
+					PetReviveDelay: 1,
+				},
+			},
+		},
+		TalentsString: Phase2BMTalents,
+		Buffs:         &proto.IndividualBuffs{},
+		Rotation: &proto.APLRotation{
+			Type: proto.APLRotation_TypeAPL,
+			PriorityList: []*proto.APLListItem{
+				{Action: &proto.APLAction{Action: &proto.APLAction_AutocastOtherCooldowns{AutocastOtherCooldowns: &proto.APLActionAutocastOtherCooldowns{}}}},
+			},
+		},
+	}
+
+	return &proto.RaidSimRequest{
+		Raid:      core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: core.MakeSingleTargetEncounter(player.Level, 0),
+		SimOptions: &proto.SimOptions{
+			Iterations: 1,
+			IsTest:     true,
+			RandomSeed: 101,
+		},
+	}
+}
+
+// Revive Pet casts by the hunter, in order.
+func revivePetCasts(request *proto.RaidSimRequest) []core.CastEvent {
+	return core.FilterSlice(core.RunCastSequence(request, ""), func(cast core.CastEvent) bool {
+		return cast.ActionID.SpellID == 982
+	})
+}
+
+func TestPetDiesAndIsRevived(t *testing.T) {
+	casts := revivePetCasts(petDeathRaidSimRequest(1000))
+	if len(casts) < 2 {
+		t.Fatalf("Expected the pet to die and be revived repeatedly, got %d Revive Pet casts", len(casts))
+	}
+
+	// Each revive takes a 10s cast, after the pet dies again and the 1s delay.
+	for i := 1; i < len(casts); i++ {
+		if gap := casts[i].Time - casts[i-1].Time; gap < time.Second*11 {
+			t.Errorf("Expected at least 11s between revives, got %s between %s and %s", gap, casts[i-1].Time, casts[i].Time)
+		}
+	}
+}
+
+func TestPetWithoutCleaveDamageSurvives(t *testing.T) {
+	if casts := revivePetCasts(petDeathRaidSimRequest(0)); len(casts) != 0 {
+		t.Errorf("Expected no Revive Pet casts, got %d starting at %s", len(casts), casts[0].Time)
+	}
+}
+
+func TestPetIsNotRevivedAfterItsUptime(t *testing.T) {
+	request := petDeathRaidSimRequest(1000)
+	request.Raid.Parties[0].Players[0].GetHunter().Options.PetUptime = 0.5
+
+	casts := revivePetCasts(request)
+	if len(casts) == 0 {
+		t.Fatalf("Expected the pet to be revived during its uptime")
+	}
+	// The single target encounter lasts LongDuration seconds, and the last revive
+	// must start within the first half of it.
+	if last := casts[len(casts)-1].Time; last > core.DurationFromSeconds(core.LongDuration*0.5)+time.Second*10 {
+		t.Errorf("Expected no revives started after the first half of the fight, got one completing at %s", last)
+	}
+}
+
+func TestPetTrainingPointsLimitedByLoyalty(t *testing.T) {
+	request := petDeathRaidSimRequest(0)
+	options := request.Raid.Parties[0].Players[0].GetHunter().Options
+
+	// A level 40 Unruly pet has 40 * (2 - 1) training points.
+	options.PetLoyalty = proto.Hunter_Options_Unruly
+	options.PetTrainingPoints = 40
+	if result := core.RunRaidSim(request); result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+
+	options.PetTrainingPoints = 41
+	if result := core.RunRaidSim(request); result.ErrorResult == "" {
+		t.Errorf("Expected training beyond the loyalty's training points to be rejected")
+	}
+}
+
+// Bite and Claw (Rank 6), the highest ranks at level 40.
+const (
+	catBiteSpellID = 17259
+	catClawSpellID = 16832
+)
+
+func TestPetAPLRotationOrder(t *testing.T) {
+	request := petDeathRaidSimRequest(0)
+	// Bite whenever it's ready, and only Claw when Bite is more than 2s away.
+	request.Raid.Parties[0].Players[0].PetRotations = map[string]*proto.APLRotation{
+		"Cat": core.APLRotationFromJsonString(`{"type":"TypeAPL","priorityList":[
+			{"action":{"castSpell":{"spellId":{"spellId":17259}}}},
+			{"action":{"condition":{"cmp":{"op":"OpGt","lhs":{"spellTimeToReady":{"spellId":{"spellId":17259}}},"rhs":{"const":{"val":"2s"}}}},"castSpell":{"spellId":{"spellId":16832}}}}
+		]}`),
+	}
+
+	casts := core.FilterSlice(core.RunCastSequence(request, "Cat"), func(cast core.CastEvent) bool {
+		return cast.ActionID.SpellID == catBiteSpellID || cast.ActionID.SpellID == catClawSpellID
+	})
+	if len(casts) == 0 || casts[0].ActionID.SpellID != catBiteSpellID {
+		t.Fatalf("Expected the cat to open with Bite, got %v", casts)
+	}
+
+	lastBite := casts[0].Time
+	numClaws := 0
+	for _, cast := range casts[1:] {
+		switch cast.ActionID.SpellID {
+		case catBiteSpellID:
+			if cast.Time < lastBite+time.Second*10 {
+				t.Errorf("Bite at %s is within its 10s cooldown of the Bite at %s", cast.Time, lastBite)
+			}
+			lastBite = cast.Time
+		case catClawSpellID:
+			if cast.Time >= lastBite+time.Second*8 {
+				t.Errorf("Claw at %s is within 2s of Bite being ready again after the Bite at %s", cast.Time, lastBite)
+			}
+			numClaws++
+		}
+	}
+	if numClaws == 0 {
+		t.Errorf("Expected Claw between Bites, got %v", casts)
+	}
+}
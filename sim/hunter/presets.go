@@ -0,0 +1,110 @@
+package hunter
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p2_melee_bm",
+		Spec:        proto.Spec_SpecHunter,
+		Class:       proto.Class_ClassHunter,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2_melee",
+		Rotation:    "p2_melee",
+		Talents:     "TalentsBeastMasteryPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: Phase2PlayerOptions,
+	},
+	{
+		Name:        "p2_ranged_bm",
+		Spec:        proto.Spec_SpecHunter,
+		Class:       proto.Class_ClassHunter,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2_ranged_bm",
+		Rotation:    "p2_ranged_bm",
+		Talents:     "TalentsBeastMasteryPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: Phase2PlayerOptions,
+	},
+	{
+		Name:        "p2_ranged_mm",
+		Spec:        proto.Spec_SpecHunter,
+		Class:       proto.Class_ClassHunter,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2_ranged_mm",
+		Rotation:    "p2_ranged_mm",
+		Talents:     "TalentsMarksmanPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: Phase2PlayerOptions,
+	},
+	{
+		Name:        "p2_melee_sv",
+		Spec:        proto.Spec_SpecHunter,
+		Class:       proto.Class_ClassHunter,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2_melee",
+		Rotation:    "p2_melee",
+		Talents:     "TalentsSurvivalPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: Phase2PlayerOptions,
+	},
+	{
+		Name:        "p4_ranged",
+		Spec:        proto.Spec_SpecHunter,
+		Class:       proto.Class_ClassHunter,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_ranged",
+		Rotation:    "p4_ranged",
+		Talents:     "TalentsRangedMMPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: Phase4PlayerOptions,
+	},
+	{
+		Name:        "p4_weave",
+		Spec:        proto.Spec_SpecHunter,
+		Class:       proto.Class_ClassHunter,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_weave",
+		Rotation:    "p4_weave",
+		Talents:     "TalentsWeavePhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: Phase4PlayerOptions,
+	},
+}
+
+var Phase2PlayerOptions = &proto.Player_Hunter{
+	Hunter: &proto.Hunter{
+		Options: &proto.Hunter_Options{
+			Ammo:           proto.Hunter_Options_JaggedArrow,
+			PetType:        proto.Hunter_Options_Cat,
+			PetUptime:      1,
+			PetAttackSpeed: 2.0,
+		},
+	},
+}
+
+var Phase4PlayerOptions = &proto.Player_Hunter{
+	Hunter: &proto.Hunter{
+		Options: &proto.Hunter_Options{
+			Ammo:                 proto.Hunter_Options_JaggedArrow,
+			PetType:              proto.Hunter_Options_PetNone,
+			PetUptime:            1,
+			PetAttackSpeed:       2.0,
+			SniperTrainingUptime: 1.0,
+		},
+	},
+}
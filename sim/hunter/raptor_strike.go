@@ -71,7 +71,9 @@ func (hunter *Hunter) getRaptorStrikeConfig(rank int) core.SpellConfig {
 
 			if hasMeleeSpecialist && sim.Proc(0.3, "Raptor Strike Reset") {
 				spell.CD.Reset()
-				hunter.MongooseBite.CD.Reset()
+				if hunter.MongooseBite != nil {
+					hunter.MongooseBite.CD.Reset()
+				}
 			}
 
 			if hasRaptorFury {
@@ -187,14 +189,14 @@ func (hunter *Hunter) makeQueueSpellsAndAura() *core.Spell {
 }
 
 func (hunter *Hunter) registerRaptorStrikeSpell() {
-	rank := map[int32]int{
-		25: 4,
-		40: 6,
-		50: 7,
-		60: 8,
-	}[hunter.Level]
-
 	hasMeleeSpecialist := hunter.HasRune(proto.HunterRune_RuneBeltMeleeSpecialist)
+
+	spellIDs := core.Ternary(hasMeleeSpecialist, RaptorStrikeSpellIdMeleeSpecialist, RaptorStrikeSpellId)
+	rank, ok := hunter.GetSpellRankForLevel(RaptorStrikeLevel[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
 	config := hunter.getRaptorStrikeConfig(rank)
 	hunter.RaptorStrike = hunter.GetOrRegisterSpell(config)
 
@@ -0,0 +1,107 @@
+package hunter
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+// Boss melee swings used to model cleave damage taken by the pet.
+const petCleaveInterval = time.Second * 2
+
+func (hunter *Hunter) registerRevivePetSpell() {
+	if hunter.pet == nil {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 982}
+	healthMetrics := hunter.pet.NewHealthMetrics(actionID)
+
+	hunter.RevivePet = hunter.RegisterSpell(core.SpellConfig{
+		ActionID: actionID,
+		Flags:    core.SpellFlagAPL,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Second * 10,
+			},
+			IgnoreHaste: true,
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !hunter.pet.IsEnabled() && sim.CurrentTime >= hunter.pet.reviveAt && !hunter.pet.pastUptime(sim)
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			hunter.pet.Enable(sim, hunter.pet)
+			// Revived pets come back with 15% of their health.
+			hunter.pet.GainHealth(sim, hunter.pet.MaxHealth()*0.15, healthMetrics)
+		},
+	})
+
+	// Rotations which don't cast Revive Pet themselves revive the pet along
+	// with their other cooldowns, if it can die.
+	if hunter.pet.cleaveDamagePerSecond <= 0 {
+		return
+	}
+	hunter.AddMajorCooldown(core.MajorCooldown{
+		Spell:    hunter.RevivePet,
+		Priority: core.CooldownPriorityDefault,
+		Type:     core.CooldownTypeUnknown,
+	})
+}
+
+func (hp *HunterPet) registerCleaveDamage() {
+	hunter := hp.hunterOwner
+
+	oldOnPetEnable := hp.OnPetEnable
+	hp.OnPetEnable = func(sim *core.Simulation) {
+		oldOnPetEnable(sim)
+
+		hp.cleaveAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period: petCleaveInterval,
+			OnAction: func(sim *core.Simulation) {
+				hp.takeCleaveDamage(sim)
+			},
+		})
+	}
+
+	oldOnPetDisable := hp.OnPetDisable
+	hp.OnPetDisable = func(sim *core.Simulation) {
+		oldOnPetDisable(sim)
+
+		if hp.cleaveAction != nil {
+			hp.cleaveAction.Cancel(sim)
+			hp.cleaveAction = nil
+		}
+	}
+
+	reviveDelay := core.DurationFromSeconds(max(0, hunter.Options.PetReviveDelay))
+	hp.onDeath = func(sim *core.Simulation) {
+		hp.reviveAt = sim.CurrentTime + reviveDelay
+	}
+}
+
+func (hp *HunterPet) takeCleaveDamage(sim *core.Simulation) {
+	if sim.CurrentTime < 0 {
+		return
+	}
+
+	attackTable := hp.CurrentTarget.AttackTables[hp.UnitIndex][proto.CastType_CastTypeMainHand]
+	damage := hp.cleaveDamagePerSecond * petCleaveInterval.Seconds() *
+		attackTable.GetArmorDamageModifier() *
+		hp.PseudoStats.DamageTakenMultiplier *
+		hp.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexPhysical]
+
+	hp.RemoveHealth(sim, damage)
+
+	if hp.CurrentHealth() <= 0 {
+		if sim.Log != nil {
+			hp.Log(sim, "Pet died")
+		}
+		hp.Disable(sim)
+		hp.onDeath(sim)
+	}
+}
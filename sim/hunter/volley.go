@@ -36,12 +36,17 @@ func (hunter *Hunter) getVolleyConfig(rank int) core.SpellConfig {
 		manaCostModifer -= 50
 	}
 
+	// Volley stays where it was cast, even if the hunter or target moves.
+	var center core.Vector2
+
 	return core.SpellConfig{
 		SpellCode: SpellCode_HunterVolley,
 		ActionID:    core.ActionID{SpellID: spellId},
 		SpellSchool: core.SpellSchoolArcane,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       core.SpellFlagChanneled | core.SpellFlagAPL,
+		MaxRange:    35,
+		Aoe:         core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 
 		RequiredLevel: level,
 		Rank:          rank,
@@ -76,7 +81,7 @@ func (hunter *Hunter) getVolleyConfig(rank int) core.SpellConfig {
 				dot.Snapshot(target, damage, isRollover)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
 			},
@@ -91,6 +96,7 @@ func (hunter *Hunter) getVolleyConfig(rank int) core.SpellConfig {
 				spell.CD.Reset()
 			}
 			hunter.Unit.AutoAttacks.DelayRangedUntil(sim, sim.CurrentTime+(time.Second*6))
+			center = spell.AoeCenter(target)
 			spell.AOEDot().Apply(sim)
 		},
 	}
@@ -4,11 +4,16 @@ import (
 	"github.com/wowsims/sod/sim/core"
 )
 
+const WingClipRanks = 3
+
+var WingClipSpellId = [WingClipRanks + 1]int32{0, 2974, 14267, 14268}
+var WingClipLevel = [WingClipRanks + 1]int{0, 12, 38, 60}
+
 func (hunter *Hunter) getWingClipConfig(rank int) core.SpellConfig {
-	spellId := [4]int32{0, 2974, 14267, 14268}[rank]
-	baseDamage := [4]float64{0, 5, 25, 50}[rank]
-	manaCost := [4]float64{0, 40, 60, 80}[rank]
-	level := [4]int{0, 12, 38, 60}[rank]
+	spellId := WingClipSpellId[rank]
+	baseDamage := [WingClipRanks + 1]float64{0, 5, 25, 50}[rank]
+	manaCost := [WingClipRanks + 1]float64{0, 40, 60, 80}[rank]
+	level := WingClipLevel[rank]
 
 	return core.SpellConfig{
 		ActionID:      core.ActionID{SpellID: spellId},
@@ -44,12 +49,10 @@ func (hunter *Hunter) getWingClipConfig(rank int) core.SpellConfig {
 }
 
 func (hunter *Hunter) registerWingClipSpell() {
-	rank := map[int32]int{
-		25: 1,
-		40: 2,
-		50: 3,
-		60: 3,
-	}[hunter.Level]
+	rank, ok := hunter.GetSpellRankForLevel(WingClipLevel[:], WingClipSpellId[:])
+	if !ok {
+		return
+	}
 
 	config := hunter.getWingClipConfig(rank)
 	hunter.WingClip = hunter.GetOrRegisterSpell(config)
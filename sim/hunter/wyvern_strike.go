@@ -8,11 +8,16 @@ import (
 	"github.com/wowsims/sod/sim/core/proto"
 )
 
+const WyvernStrikeRanks = 3
+
+var WyvernStrikeSpellId = [WyvernStrikeRanks + 1]int32{0, 458436, 458481, 458482}
+var WyvernStrikeLevel = [WyvernStrikeRanks + 1]int{0, 1, 50, 60}
+
 func (hunter *Hunter) getWyvernStrikeConfig(rank int) core.SpellConfig {
-	spellId := [4]int32{0, 458436, 458481, 458482}[rank]
-	bleedAttackPowerCoefficient := [4]float64{0, 3, 4, 6}[rank] / 100 * 8
-	manaCost := [4]float64{0, 55, 75, 100}[rank]
-	level := [4]int{0, 1, 50, 60}[rank]
+	spellId := WyvernStrikeSpellId[rank]
+	bleedAttackPowerCoefficient := [WyvernStrikeRanks + 1]float64{0, 3, 4, 6}[rank] / 100 * 8
+	manaCost := [WyvernStrikeRanks + 1]float64{0, 55, 75, 100}[rank]
+	level := WyvernStrikeLevel[rank]
 
 	spellConfig := core.SpellConfig{
 		SpellCode: 	   SpellCode_HunterWyvernStrike,
@@ -80,11 +85,10 @@ func (hunter *Hunter) registerWyvernStrikeSpell() {
 		return
 	}
 
-	rank := map[int32]int{
-		1:  1,
-		50: 2,
-		60: 3,
-	}[hunter.Level]
+	rank, ok := hunter.GetSpellRankForLevel(WyvernStrikeLevel[:], WyvernStrikeSpellId[:])
+	if !ok {
+		return
+	}
 
 	config := hunter.getWyvernStrikeConfig(rank)
 	hunter.WyvernStrike = hunter.GetOrRegisterSpell(config)
@@ -118,6 +118,8 @@ func getDatabase(itemIds *int32, numItems int32, enchantIds *int32, numEnchants
 			WeaponSpeed:      item.SwingSpeed,
 			SetName:          item.SetName,
 			SetId:            item.SetID,
+			Phase:            item.Phase,
+			EffectTypes:      item.EffectTypes,
 		}
 	}
 	for i, enchantId := range eids {
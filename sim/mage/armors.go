@@ -7,26 +7,18 @@ import (
 )
 
 func (mage *Mage) applyFrostIceArmor() {
-	spellID := map[int32]int32{
-		25: 7301,
-		40: 7320,
-		50: 10219,
-		60: 10220,
-	}[mage.Level]
+	// Frost Armor, then Ice Armor from level 30
+	levels := [8]int{0, 1, 10, 20, 30, 40, 50, 60}
+	rank, ok := core.GetRankForLevel(levels[:], mage.Level)
+	if !ok {
+		return
+	}
+
+	spellID := [8]int32{0, 168, 7300, 7301, 7302, 7320, 10219, 10220}[rank]
 
-	armor := map[int32]float64{
-		25: 200,
-		40: 380,
-		50: 470,
-		60: 560,
-	}[mage.Level]
+	armor := [8]float64{0, 30, 110, 200, 290, 380, 470, 560}[rank]
 
-	frostRes := map[int32]float64{
-		25: 0,
-		40: 9,
-		50: 12,
-		60: 15,
-	}[mage.Level]
+	frostRes := [8]float64{0, 0, 0, 0, 6, 9, 12, 15}[rank]
 
 	mage.AddStat(stats.Armor, armor)
 	mage.AddStat(stats.FrostResistance, frostRes)
@@ -42,21 +34,15 @@ func (mage *Mage) applyFrostIceArmor() {
 }
 
 func (mage *Mage) applyMageArmor() {
-	if mage.Level < 40 {
+	levels := [4]int{0, 34, 46, 58}
+	rank, ok := core.GetRankForLevel(levels[:], mage.Level)
+	if !ok {
 		return
 	}
 
-	spellID := map[int32]int32{
-		40: 6117,
-		50: 22782,
-		60: 22783,
-	}[mage.Level]
+	spellID := [4]int32{0, 6117, 22782, 22783}[rank]
 
-	spellRes := map[int32]float64{
-		40: 5,
-		50: 10,
-		60: 15,
-	}[mage.Level]
+	spellRes := [4]float64{0, 5, 10, 15}[rank]
 
 	mage.PseudoStats.SpiritRegenRateCasting += .3
 	mage.AddResistances(spellRes)
@@ -44,12 +44,17 @@ func (mage *Mage) newFlamestrikeSpellConfig(rank int) core.SpellConfig {
 
 	castTime := time.Second * 3
 
+	// Flamestrike stays where it was cast, even if the mage or target moves.
+	var center core.Vector2
+
 	return core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellId},
 		SpellSchool: core.SpellSchoolFire,
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       SpellFlagMage | core.SpellFlagAPL,
+		MaxRange:    30,
+		Aoe:         core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 
 		RequiredLevel: level,
 		Rank:          rank,
@@ -82,14 +87,15 @@ func (mage *Mage) newFlamestrikeSpellConfig(rank int) core.SpellConfig {
 				dot.Snapshot(target, baseDotDamage, isRollover)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
 			},
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			center = spell.AoeCenter(target)
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicCrit)
 			}
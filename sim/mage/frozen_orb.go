@@ -108,6 +108,7 @@ func (ffo *FrozenOrb) registerFrozenOrbTickSpell() {
 		SpellSchool: core.SpellSchoolFrost | core.SpellSchoolArcane,
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamage,
+		Aoe:         core.AoeConfig{Radius: 8},
 
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
@@ -120,7 +121,7 @@ func (ffo *FrozenOrb) registerFrozenOrbTickSpell() {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 			}
 			ffo.TickCount += 1
@@ -12,6 +12,9 @@ const IceBarrierRanks = 4
 var IceBarrierSpellId = [IceBarrierRanks + 1]int32{0, 11426, 13031, 13032, 13033}
 var IceBarrierManaCost = [IceBarrierRanks + 1]float64{0, 305, 360, 420, 480}
 var IceBarrierLevel = [IceBarrierRanks + 1]int{0, 40, 46, 52, 58}
+var IceBarrierAbsorb = [IceBarrierRanks + 1]float64{0, 438, 549, 678, 818}
+
+const IceBarrierSpellCoeff = 0.1
 
 func (mage *Mage) registerIceBarrierSpell() {
 	mage.IceBarrier = make([]*core.Spell, IceBarrierRanks+1)
@@ -24,6 +27,7 @@ func (mage *Mage) registerIceBarrierSpell() {
 
 		if config.RequiredLevel <= int(mage.Level) {
 			mage.IceBarrier[rank] = mage.GetOrRegisterSpell(config)
+			mage.IceBarrierAuras[rank] = mage.IceBarrier[rank].SelfShield().Aura
 		}
 	}
 }
@@ -32,21 +36,10 @@ func (mage *Mage) newIceBarrierSpellConfig(rank int, cdTimer *core.Timer) core.S
 	spellID := IceBarrierSpellId[rank]
 	manaCost := IceBarrierManaCost[rank]
 	level := IceBarrierLevel[rank]
+	absorb := IceBarrierAbsorb[rank]
 
 	cooldown := time.Second * 30
 
-	mage.IceBarrierAuras[rank] = mage.RegisterAura(core.Aura{
-		ActionID: core.ActionID{SpellID: spellID},
-		Label:    fmt.Sprintf("Ice Barrier (Rank %d)", rank),
-		Duration: time.Minute,
-		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			// Dummy
-		},
-		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			// Dummy
-		},
-	})
-
 	return core.SpellConfig{
 		ActionID:      core.ActionID{SpellID: spellID},
 		SpellSchool:   core.SpellSchoolFrost,
@@ -65,13 +58,31 @@ func (mage *Mage) newIceBarrierSpellConfig(rank int, cdTimer *core.Timer) core.S
 				Duration: cooldown,
 			},
 		},
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label:    fmt.Sprintf("Ice Barrier (Rank %d)", rank),
+				Duration: time.Minute,
+				OnGain: func(aura *core.Aura, sim *core.Simulation) {
+					// Dummy
+				},
+				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+					// Dummy
+				},
+			},
+		},
+
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			// Disable an existing barrier
 			if mage.activeBarrier != nil && mage.activeBarrier.IsActive() {
 				mage.activeBarrier.Deactivate(sim)
-				mage.activeBarrier = mage.IceBarrierAuras[rank]
 			}
-			mage.IceBarrierAuras[rank].Activate(sim)
+			shield := spell.SelfShield()
+			shield.Apply(sim, absorb+IceBarrierSpellCoeff*spell.GetBonusDamage())
+			mage.activeBarrier = shield.Aura
 		},
 	}
 }
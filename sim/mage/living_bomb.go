@@ -33,6 +33,7 @@ func (mage *Mage) registerLivingBombSpell() {
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       SpellFlagMage | core.SpellFlagPassiveSpell,
+		Aoe:         core.AoeConfig{Radius: 10, CenteredOnTarget: true},
 
 		DamageMultiplier: 1,
 		ThreatMultiplier: 1,
@@ -65,7 +66,7 @@ func (mage *Mage) registerLivingBombSpell() {
 			Aura: core.Aura{
 				Label: "Living Bomb (DoT)",
 				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-					for _, aoeTarget := range sim.Encounter.TargetUnits {
+					for _, aoeTarget := range livingBombExplosionSpell.AoeTargets(aura.Unit) {
 						livingBombExplosionSpell.Cast(sim, aoeTarget)
 					}
 				},
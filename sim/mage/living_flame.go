@@ -33,6 +33,7 @@ func (mage *Mage) registerLivingFlameSpell() {
 		ProcMask:     core.ProcMaskSpellDamage,
 		Flags:        SpellFlagMage | core.SpellFlagAPL | core.SpellFlagPureDot,
 		MissileSpeed: 6.02,
+		Aoe:          core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: manaCost,
@@ -69,7 +70,7 @@ func (mage *Mage) registerLivingFlameSpell() {
 				}
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargets(target) {
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
 			},
@@ -52,6 +52,7 @@ func RegisterMage() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type Mage struct {
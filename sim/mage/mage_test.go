@@ -185,7 +185,6 @@ var Phase1TalentsFire = "-5050020121"
 
 var Phase2TalentsArcane = "2250050310031531"
 var Phase2TalentsFire = "-5050020123033151"
-var Phase2TalentsFrostfire = Phase2TalentsFire
 
 var Phase3TalentsFire = "-0550020123033151-2035"
 var Phase3TalentsFrost = "-055-20350203100351051"
@@ -194,30 +193,6 @@ var Phase4TalentsArcane = "0550050210031531-054-203500001"
 var Phase4TalentsFire = "21-0552300123033151-203500031"
 var Phase4TalentsFrost = "-0550320003021-2035020310035105"
 
-var PlayerOptionsArcane = &proto.Player_Mage{
-	Mage: &proto.Mage{
-		Options: &proto.Mage_Options{
-			Armor: proto.Mage_Options_MageArmor,
-		},
-	},
-}
-
-var PlayerOptionsFire = &proto.Player_Mage{
-	Mage: &proto.Mage{
-		Options: &proto.Mage_Options{
-			Armor: proto.Mage_Options_MoltenArmor,
-		},
-	},
-}
-
-var PlayerOptionsFrost = &proto.Player_Mage{
-	Mage: &proto.Mage{
-		Options: &proto.Mage_Options{
-			Armor: proto.Mage_Options_IceArmor,
-		},
-	},
-}
-
 var Phase1Consumes = core.ConsumesCombo{
 	Label: "Phase 1 Consumes",
 	Consumes: &proto.Consumes{
@@ -287,3 +262,5 @@ var Stats = []proto.Stat{
 	proto.Stat_StatSpellHit,
 	proto.Stat_StatSpellCrit,
 }
+
+var Phase2TalentsFrostfire = Phase2TalentsFire
@@ -0,0 +1,111 @@
+package mage
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p4_arcane",
+		Spec:        proto.Spec_SpecMage,
+		Class:       proto.Class_ClassMage,
+		Race:        proto.Race_RaceTroll,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_arcane",
+		Rotation:    "p4_arcane",
+		Talents:     "TalentsArcanePhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsArcane,
+	},
+	{
+		Name:        "p2_fire",
+		Spec:        proto.Spec_SpecMage,
+		Class:       proto.Class_ClassMage,
+		Race:        proto.Race_RaceTroll,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2_fire",
+		Rotation:    "p2_fire",
+		Talents:     "TalentsFirePhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsFire,
+	},
+	{
+		Name:        "p3_fire",
+		Spec:        proto.Spec_SpecMage,
+		Class:       proto.Class_ClassMage,
+		Race:        proto.Race_RaceTroll,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "p3_fire",
+		Rotation:    "p3_fire",
+		Talents:     "TalentsFirePhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsFire,
+	},
+	{
+		Name:        "p4_fire",
+		Spec:        proto.Spec_SpecMage,
+		Class:       proto.Class_ClassMage,
+		Race:        proto.Race_RaceTroll,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_fire",
+		Rotation:    "p4_fire",
+		Talents:     "TalentsFirePhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsFire,
+	},
+	{
+		Name:        "p3_frost",
+		Spec:        proto.Spec_SpecMage,
+		Class:       proto.Class_ClassMage,
+		Race:        proto.Race_RaceTroll,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "p3_frost_ffb",
+		Rotation:    "p3_frost",
+		Talents:     "TalentsFrostPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsFrost,
+	},
+	{
+		Name:        "p4_frost",
+		Spec:        proto.Spec_SpecMage,
+		Class:       proto.Class_ClassMage,
+		Race:        proto.Race_RaceTroll,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_frost",
+		Rotation:    "p4_frost",
+		Talents:     "TalentsFrostPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsFrost,
+	},
+}
+
+var PlayerOptionsArcane = &proto.Player_Mage{
+	Mage: &proto.Mage{
+		Options: &proto.Mage_Options{
+			Armor: proto.Mage_Options_MageArmor,
+		},
+	},
+}
+
+var PlayerOptionsFire = &proto.Player_Mage{
+	Mage: &proto.Mage{
+		Options: &proto.Mage_Options{
+			Armor: proto.Mage_Options_MoltenArmor,
+		},
+	},
+}
+
+var PlayerOptionsFrost = &proto.Player_Mage{
+	Mage: &proto.Mage{
+		Options: &proto.Mage_Options{
+			Armor: proto.Mage_Options_IceArmor,
+		},
+	},
+}
@@ -41,12 +41,16 @@ func (paladin *Paladin) registerConsecration() {
 			break
 		}
 
+		// Consecration stays where it was cast, even if the paladin moves.
+		var center core.Vector2
+
 		paladin.RegisterSpell(core.SpellConfig{
 			ActionID:    core.ActionID{SpellID: rank.spellID},
 			SpellSchool: core.SpellSchoolHoly,
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskSpellDamage,
 			Flags:       core.SpellFlagPureDot | core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+			Aoe:         core.AoeConfig{Radius: 8},
 
 			RequiredLevel: int(rank.level),
 			Rank:          i + 1,
@@ -84,13 +88,14 @@ func (paladin *Paladin) registerConsecration() {
 				OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 					// consecration ticks can miss, but those misses aren't logged as "resist"
 					outcomeApplier := core.Ternary(hasWrath, dot.OutcomeMagicHitAndSnapshotCrit, dot.Spell.OutcomeMagicHit)
-					for _, aoeTarget := range sim.Encounter.TargetUnits {
+					for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 						dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, outcomeApplier)
 					}
 				},
 			},
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				center = spell.AoeCenter(target)
 				spell.AOEDot().Apply(sim)
 			},
 		})
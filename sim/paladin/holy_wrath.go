@@ -49,6 +49,7 @@ func (paladin *Paladin) registerHolyWrath() {
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskSpellDamage, // TODO to be tested
 			Flags:       core.SpellFlagAPL,
+			Aoe:         core.AoeConfig{Radius: 20},
 
 			RequiredLevel: int(rank.level),
 			Rank:          i + 1,
@@ -69,15 +70,15 @@ func (paladin *Paladin) registerHolyWrath() {
 			ThreatMultiplier: 1,
 			BonusCoefficient: 0.19,
 
-			ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 				bonusCrit := core.TernaryFloat64(hasWrath, paladin.GetStat(stats.MeleeCrit), 0)
 				spell.BonusCritRating += bonusCrit
 
 				results = results[:0]
-				for _, target := range paladin.Env.Encounter.TargetUnits {
-					if hasPurifyingPower || (target.MobType == proto.MobType_MobTypeDemon || target.MobType == proto.MobType_MobTypeUndead) {
+				for _, aoeTarget := range spell.AoeTargets(target) {
+					if hasPurifyingPower || (aoeTarget.MobType == proto.MobType_MobTypeDemon || aoeTarget.MobType == proto.MobType_MobTypeUndead) {
 						damage := sim.Roll(minDamage, maxDamage)
-						result := spell.CalcDamage(sim, target, damage, spell.OutcomeMagicHitAndCrit)
+						result := spell.CalcDamage(sim, aoeTarget, damage, spell.OutcomeMagicHitAndCrit)
 						results = append(results, result)
 					}
 				}
@@ -0,0 +1,32 @@
+package protection
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p4prot",
+		Spec:        proto.Spec_SpecProtectionPaladin,
+		Class:       proto.Class_ClassPaladin,
+		Race:        proto.Race_RaceHuman,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4prot",
+		Rotation:    "p4prot",
+		Talents:     "P4ProtTalents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSealofMartyrdom,
+	},
+}
+
+var PlayerOptionsSealofMartyrdom = &proto.Player_ProtectionPaladin{
+	ProtectionPaladin: &proto.ProtectionPaladin{
+		Options: optionsSealOfMartyrdom,
+	},
+}
+
+var optionsSealOfMartyrdom = &proto.PaladinOptions{
+	PrimarySeal: proto.PaladinSeal_Martyrdom,
+}
@@ -21,6 +21,7 @@ func RegisterProtectionPaladin() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 func NewProtectionPaladin(character *core.Character, options *proto.Player) *ProtectionPaladin {
@@ -80,36 +80,6 @@ var Phase4Consumes = core.ConsumesCombo{
 	},
 }
 
-var PlayerOptionsSealofCommand = &proto.Player_ProtectionPaladin{
-	ProtectionPaladin: &proto.ProtectionPaladin{
-		Options: optionsSealOfCommand,
-	},
-}
-
-var PlayerOptionsSealofMartyrdom = &proto.Player_ProtectionPaladin{
-	ProtectionPaladin: &proto.ProtectionPaladin{
-		Options: optionsSealOfMartyrdom,
-	},
-}
-
-var PlayerOptionsSealofRighteousness = &proto.Player_ProtectionPaladin{
-	ProtectionPaladin: &proto.ProtectionPaladin{
-		Options: optionsSealOfRighteousness,
-	},
-}
-
-var optionsSealOfCommand = &proto.PaladinOptions{
-	PrimarySeal: proto.PaladinSeal_Command,
-}
-
-var optionsSealOfMartyrdom = &proto.PaladinOptions{
-	PrimarySeal: proto.PaladinSeal_Martyrdom,
-}
-
-var optionsSealOfRighteousness = &proto.PaladinOptions{
-	PrimarySeal: proto.PaladinSeal_Righteousness,
-}
-
 var ItemFilters = core.ItemFilter{
 	WeaponTypes: []proto.WeaponType{
 		proto.WeaponType_WeaponTypeAxe,
@@ -152,3 +122,23 @@ var Stats = []proto.Stat{
 	proto.Stat_StatFrostResistance,
 	proto.Stat_StatArcaneResistance,
 }
+
+var PlayerOptionsSealofCommand = &proto.Player_ProtectionPaladin{
+	ProtectionPaladin: &proto.ProtectionPaladin{
+		Options: optionsSealOfCommand,
+	},
+}
+
+var PlayerOptionsSealofRighteousness = &proto.Player_ProtectionPaladin{
+	ProtectionPaladin: &proto.ProtectionPaladin{
+		Options: optionsSealOfRighteousness,
+	},
+}
+
+var optionsSealOfCommand = &proto.PaladinOptions{
+	PrimarySeal: proto.PaladinSeal_Command,
+}
+
+var optionsSealOfRighteousness = &proto.PaladinOptions{
+	PrimarySeal: proto.PaladinSeal_Righteousness,
+}
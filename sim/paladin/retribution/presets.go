@@ -0,0 +1,104 @@
+package retribution
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p1ret",
+		Spec:        proto.Spec_SpecRetributionPaladin,
+		Class:       proto.Class_ClassPaladin,
+		Race:        proto.Race_RaceHuman,
+		Phase:       1,
+		Level:       25,
+		GearSet:     "p1ret",
+		Rotation:    "p1ret",
+		Talents:     "P1RetTalents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSealofCommand,
+	},
+	{
+		Name:        "p2ret",
+		Spec:        proto.Spec_SpecRetributionPaladin,
+		Class:       proto.Class_ClassPaladin,
+		Race:        proto.Race_RaceHuman,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2retsoc",
+		Rotation:    "p2ret",
+		Talents:     "P2RetTalents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSealofCommand,
+	},
+	{
+		Name:        "p2shockadin",
+		Spec:        proto.Spec_SpecRetributionPaladin,
+		Class:       proto.Class_ClassPaladin,
+		Race:        proto.Race_RaceHuman,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2retsom",
+		Rotation:    "p2ret",
+		Talents:     "P2ShockadinTalents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSealofMartyrdom,
+	},
+	{
+		Name:        "p3ret",
+		Spec:        proto.Spec_SpecRetributionPaladin,
+		Class:       proto.Class_ClassPaladin,
+		Race:        proto.Race_RaceHuman,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "p3retsom",
+		Rotation:    "p3ret",
+		Talents:     "P3RetTalents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSealofMartyrdom,
+	},
+	{
+		Name:        "p5shockadin",
+		Spec:        proto.Spec_SpecRetributionPaladin,
+		Class:       proto.Class_ClassPaladin,
+		Race:        proto.Race_RaceHuman,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p5shockadin",
+		Rotation:    "p5Shockadin",
+		Talents:     "P2ShockadinTalents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSealofRighteousness,
+	},
+}
+
+var PlayerOptionsSealofCommand = &proto.Player_RetributionPaladin{
+	RetributionPaladin: &proto.RetributionPaladin{
+		Options: optionsSealOfCommand,
+	},
+}
+
+var PlayerOptionsSealofMartyrdom = &proto.Player_RetributionPaladin{
+	RetributionPaladin: &proto.RetributionPaladin{
+		Options: optionsSealOfMartyrdom,
+	},
+}
+
+var PlayerOptionsSealofRighteousness = &proto.Player_RetributionPaladin{
+	RetributionPaladin: &proto.RetributionPaladin{
+		Options: optionsSealOfRighteousness,
+	},
+}
+
+var optionsSealOfCommand = &proto.PaladinOptions{
+	PrimarySeal: proto.PaladinSeal_Command,
+}
+
+var optionsSealOfMartyrdom = &proto.PaladinOptions{
+	PrimarySeal: proto.PaladinSeal_Martyrdom,
+}
+
+var optionsSealOfRighteousness = &proto.PaladinOptions{
+	PrimarySeal: proto.PaladinSeal_Righteousness,
+}
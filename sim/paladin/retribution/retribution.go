@@ -21,6 +21,7 @@ func RegisterRetributionPaladin() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 func NewRetributionPaladin(character *core.Character, options *proto.Player) *RetributionPaladin {
@@ -249,37 +249,6 @@ var Phase4Consumes = core.ConsumesCombo{
 	},
 }
 
-var PlayerOptionsSealofCommand = &proto.Player_RetributionPaladin{
-	RetributionPaladin: &proto.RetributionPaladin{
-		Options: optionsSealOfCommand,
-	},
-}
-
-var PlayerOptionsSealofMartyrdom = &proto.Player_RetributionPaladin{
-	RetributionPaladin: &proto.RetributionPaladin{
-		Options: optionsSealOfMartyrdom,
-	},
-}
-
-
-var PlayerOptionsSealofRighteousness = &proto.Player_RetributionPaladin{
-	RetributionPaladin: &proto.RetributionPaladin{
-		Options: optionsSealOfRighteousness,
-	},
-}
-
-var optionsSealOfCommand = &proto.PaladinOptions{
-	PrimarySeal: proto.PaladinSeal_Command,
-}
-
-var optionsSealOfMartyrdom = &proto.PaladinOptions{
-	PrimarySeal: proto.PaladinSeal_Martyrdom,
-}
-
-var optionsSealOfRighteousness = &proto.PaladinOptions{
-	PrimarySeal: proto.PaladinSeal_Righteousness,
-}
-
 var ItemFilters = core.ItemFilter{
 	WeaponTypes: []proto.WeaponType{
 		proto.WeaponType_WeaponTypeAxe,
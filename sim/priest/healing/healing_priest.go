@@ -32,7 +32,9 @@ type HealingPriest struct {
 func NewHealingPriest(character *core.Character, options *proto.Player) *HealingPriest {
 	healingOptions := options.GetHealingPriest()
 
-	basePriest := priest.New(character, options.TalentsString)
+	basePriest := priest.New(character, priest.SelfBuffs{
+		PowerInfusionTarget: healingOptions.GetOptions().GetPowerInfusionTarget(),
+	}, options.TalentsString)
 	hpriest := &HealingPriest{
 		Priest:  basePriest,
 		Options: healingOptions.Options,
@@ -37,11 +37,16 @@ func (priest *Priest) newMindSearSpellConfig(tickIdx int32) core.SpellConfig {
 
 	priest.MindSearTicks[tickIdx] = priest.newMindSearTickSpell(tickIdx)
 
+	// Mind Sear is centered on the target it was channeled on.
+	var center core.Vector2
+
 	return core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellId}.WithTag(tickIdx),
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       flags,
+		MaxRange:    30,
+		Aoe:         core.AoeConfig{Radius: 10, CenteredOnTarget: true},
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: manaCost,
@@ -61,7 +66,7 @@ func (priest *Priest) newMindSearSpellConfig(tickIdx int32) core.SpellConfig {
 			NumberOfTicks: numTicks,
 			TickLength:    tickLength,
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 					priest.MindSearTicks[tickIdx].Cast(sim, aoeTarget)
 					priest.MindSearTicks[tickIdx].SpellMetrics[target.UnitIndex].Casts -= 1
 				}
@@ -69,6 +74,7 @@ func (priest *Priest) newMindSearSpellConfig(tickIdx int32) core.SpellConfig {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			center = spell.AoeCenter(target)
 			result := spell.CalcOutcome(sim, target, spell.OutcomeMagicHit)
 			priest.MindSearTicks[tickIdx].SpellMetrics[target.UnitIndex].Casts += 1
 
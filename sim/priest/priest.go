@@ -29,6 +29,7 @@ const (
 
 type Priest struct {
 	core.Character
+	SelfBuffs
 	Talents *proto.PriestTalents
 
 	Latency                     float64
@@ -88,6 +89,10 @@ type Priest struct {
 	ProcPrayerOfMending core.ApplySpellResults
 }
 
+type SelfBuffs struct {
+	PowerInfusionTarget *proto.UnitReference
+}
+
 func (priest *Priest) GetCharacter() *core.Character {
 	return &priest.Character
 }
@@ -128,15 +133,37 @@ func (priest *Priest) Reset(_ *core.Simulation) {
 	priest.MindBlastModifier = 1
 }
 
-func New(character *core.Character, talents string) *Priest {
+func New(character *core.Character, selfBuffs SelfBuffs, talents string) *Priest {
 	priest := &Priest{
 		Character: *character,
+		SelfBuffs: selfBuffs,
 		Talents:   &proto.PriestTalents{},
 	}
 	core.FillTalentsProto(priest.Talents.ProtoReflect(), talents, TalentTreeSizes)
 
 	priest.EnableManaBar()
 
+	if priest.Talents.ShadowWeaving > 0 {
+		priest.RegisterProvidedBuff(core.ProvidedBuffConfig{
+			Label: "Shadow Weaving",
+			ClearDebuffs: func(debuffs *proto.Debuffs) {
+				debuffs.ShadowWeaving = false
+			},
+		})
+	}
+
+	if priest.Talents.PowerInfusion && priest.PowerInfusionTarget.GetType() != proto.UnitReference_Unknown {
+		priest.RegisterProvidedBuff(core.ProvidedBuffConfig{
+			Label: "Power Infusion",
+			// Replaces one of the Power Infusions assigned to the target.
+			ClearPlayerBuffs: func(player *core.Character, individualBuffs *proto.IndividualBuffs, _ *proto.Consumes) {
+				if &player.Unit == priest.GetUnit(priest.PowerInfusionTarget) {
+					individualBuffs.PowerInfusions = max(0, individualBuffs.PowerInfusions-1)
+				}
+			},
+		})
+	}
+
 	priest.AddStatDependency(stats.Strength, stats.AttackPower, core.APPerStrength[character.Class])
 	priest.AddStatDependency(stats.Intellect, stats.SpellCrit, core.CritPerIntAtLevel[priest.Class][int(priest.Level)]*core.SpellCritRatingPerCritChance)
 
@@ -23,11 +23,14 @@ func RegisterShadowPriest() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 func NewShadowPriest(character *core.Character, options *proto.Player) *ShadowPriest {
 	shadowOptions := options.GetShadowPriest()
-	basePriest := priest.New(character, options.TalentsString)
+	basePriest := priest.New(character, priest.SelfBuffs{
+		PowerInfusionTarget: shadowOptions.GetOptions().GetPowerInfusionTarget(),
+	}, options.TalentsString)
 	basePriest.Latency = float64(basePriest.ChannelClipDelay.Milliseconds())
 	spriest := &ShadowPriest{
 		Priest:  basePriest,
@@ -132,14 +132,6 @@ var Phase4Consumes = core.ConsumesCombo{
 	},
 }
 
-var PlayerOptionsBasic = &proto.Player_ShadowPriest{
-	ShadowPriest: &proto.ShadowPriest{
-		Options: &proto.ShadowPriest_Options{
-			Armor: proto.ShadowPriest_Options_InnerFire,
-		},
-	},
-}
-
 var ItemFilters = core.ItemFilter{
 	WeaponTypes: []proto.WeaponType{
 		proto.WeaponType_WeaponTypeDagger,
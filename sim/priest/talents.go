@@ -176,7 +176,7 @@ func (priest *Priest) applyShadowFocus() {
 }
 
 func (priest *Priest) applyShadowWeaving() {
-	if priest.Talents.ShadowWeaving == 0 {
+	if priest.Talents.ShadowWeaving == 0 || priest.ProvidedBuffsSuppressed() {
 		return
 	}
 
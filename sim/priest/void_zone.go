@@ -23,12 +23,17 @@ func (priest *Priest) registerVoidZoneSpell() {
 
 	hasDespairRune := priest.HasRune(proto.PriestRune_RuneBracersDespair)
 
+	// Void Zone stays where it was cast, even if the priest or target moves.
+	var center core.Vector2
+
 	priest.GetOrRegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: int32(proto.PriestRune_RuneBracersVoidZone)},
 		SpellSchool: core.SpellSchoolShadow,
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       SpellFlagPriest | core.SpellFlagAPL | core.SpellFlagPureDot,
+		MaxRange:    30,
+		Aoe:         core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: manaCost,
@@ -60,7 +65,7 @@ func (priest *Priest) registerVoidZoneSpell() {
 				dot.Snapshot(target, baseTickDamage, isRollover)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 					if hasDespairRune {
 						dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeSnapshotCrit)
 					} else {
@@ -71,6 +76,7 @@ func (priest *Priest) registerVoidZoneSpell() {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			center = spell.AoeCenter(target)
 			spell.AOEDot().Apply(sim)
 		},
 
@@ -8,19 +8,16 @@ import (
 )
 
 func (rogue *Rogue) registerAmbushSpell() {
-	flatDamageBonus := map[int32]float64{
-		25: 28,
-		40: 50,
-		50: 92,
-		60: 116,
-	}[rogue.Level]
+	levels := [5]int{0, 18, 34, 50, 58}
+	spellIDs := [5]int32{0, 8676, 8725, 11268, 11269}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	flatDamageBonus := [5]float64{0, 28, 50, 92, 116}[rank]
 
-	spellID := map[int32]int32{
-		25: 8676,
-		40: 8725,
-		50: 11268,
-		60: 11269,
-	}[rogue.Level]
+	spellID := spellIDs[rank]
 
 	// waylay := rogue.HasRune(proto.RogueRune_RuneWaylay)
 	hasCutthroatRune := rogue.HasRune(proto.RogueRune_RuneCutthroat)
@@ -59,7 +56,7 @@ func (rogue *Rogue) registerAmbushSpell() {
 			if hasCutthroatRune && (rogue.CutthroatProcAura.IsActive() || rogue.IsStealthed()) {
 				return true
 			}
-			return !rogue.PseudoStats.InFrontOfTarget && rogue.IsStealthed()
+			return !rogue.IsInFrontOf(target) && rogue.IsStealthed()
 		},
 
 		BonusCritRating:  15 * core.CritRatingPerCritChance * float64(rogue.Talents.ImprovedAmbush),
@@ -8,23 +8,17 @@ import (
 )
 
 func (rogue *Rogue) registerBackstabSpell() {
-	flatDamageBonus := map[int32]float64{
-		25: 32,
-		40: 60,
-		50: 90,
-		// TODO: AQ
-		60: 140,
-		// 60: 150,
-	}[rogue.Level]
-
-	spellID := map[int32]int32{
-		25: 2590,
-		40: 8721,
-		50: 11279,
-		// TODO: AQ
-		60: 11281,
-		// 60: 25300
-	}[rogue.Level]
+	// TODO: AQ rank 25300 with 150 damage
+	levels := [5]int{0, 20, 36, 44, 60}
+	spellIDs := [5]int32{0, 2590, 8721, 11279, 11281}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	flatDamageBonus := [5]float64{0, 32, 60, 90, 140}[rank]
+
+	spellID := spellIDs[rank]
 
 	// waylay := rogue.HasRune(proto.RogueRune_RuneWaylay)
 	hasCutthroatRune := rogue.HasRune(proto.RogueRune_RuneCutthroat)
@@ -60,7 +54,7 @@ func (rogue *Rogue) registerBackstabSpell() {
 			if !rogue.HasDagger(core.MainHand) {
 				return false
 			}
-			return hasCutthroatRune || !rogue.PseudoStats.InFrontOfTarget
+			return hasCutthroatRune || !rogue.IsInFrontOf(target)
 		},
 
 		BonusCritRating: 10 * core.CritRatingPerCritChance * float64(rogue.Talents.ImprovedBackstab),
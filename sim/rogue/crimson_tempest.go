@@ -70,6 +70,7 @@ func (rogue *Rogue) registerCrimsonTempestSpell() {
 		ProcMask:     core.ProcMaskMeleeMHSpecial,
 		Flags:        rogue.finisherFlags(),
 		MetricSplits: 6,
+		Aoe:          core.AoeConfig{Radius: 8},
 
 		EnergyCost: core.EnergyCostOptions{
 			Cost:   35 - core.TernaryFloat64(activate2PcBonuses, 20, 0),
@@ -85,7 +86,7 @@ func (rogue *Rogue) registerCrimsonTempestSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			rogue.BreakStealth(sim)
 
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				rogue.CrimsonTempestBleed.Cast(sim, aoeTarget)
 			}
 
@@ -22,6 +22,7 @@ func RegisterDpsRogue() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type DpsRogue struct {
@@ -144,6 +144,116 @@ func BenchmarkSimulate(b *testing.B) {
 	}, func(rsr *proto.RaidSimRequest) { core.RaidBenchmark(b, rsr) })
 }
 
+func newPoisonedRogueSim(t *testing.T) (*core.Simulation, *DpsRogue) {
+	player := &proto.Player{
+		Name:          "Rogue",
+		Class:         proto.Class_ClassRogue,
+		Race:          proto.Race_RaceHuman,
+		Level:         40,
+		TalentsString: CombatDagger40Talents,
+		Equipment:     core.GetGearSet("../../../ui/rogue/gear_sets", "p2_daggers").GearSet,
+		Consumes: &proto.Consumes{
+			MainHandImbue: proto.WeaponImbue_InstantPoison,
+			OffHandImbue:  proto.WeaponImbue_DeadlyPoison,
+		},
+		Buffs: &proto.IndividualBuffs{},
+		Spec:  DefaultCombatRogue,
+		Rotation: &proto.APLRotation{
+			Type: proto.APLRotation_TypeAPL,
+			PriorityList: []*proto.APLListItem{
+				{Action: &proto.APLAction{Action: &proto.APLAction_ReapplyWeaponImbue{ReapplyWeaponImbue: &proto.APLActionReapplyWeaponImbue{}}}},
+			},
+		},
+	}
+
+	sim := core.NewSim(&proto.RaidSimRequest{
+		Raid:       core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter:  core.MakeSingleTargetEncounter(player.Level, 0),
+		SimOptions: &proto.SimOptions{RandomSeed: 101},
+	})
+	sim.Reset()
+
+	rogue, ok := sim.Raid.Parties[0].Players[0].(*DpsRogue)
+	if !ok {
+		t.Fatalf("Expected a DpsRogue")
+	}
+	return sim, rogue
+}
+
+func TestPoisonImbueUsesCharges(t *testing.T) {
+	sim, rogue := newPoisonedRogueSim(t)
+
+	mainHand := rogue.GetWeaponImbue(true)
+	offHand := rogue.GetWeaponImbue(false)
+	if mainHand == nil || mainHand.Imbue != proto.WeaponImbue_InstantPoison || offHand == nil || offHand.Imbue != proto.WeaponImbue_DeadlyPoison {
+		t.Fatalf("Expected Instant Poison on the main hand and Deadly Poison on the off hand")
+	}
+	if mainHand.MaxCharges != 70 || mainHand.Charges() != 70 {
+		t.Fatalf("Expected Instant Poison to start with 70 charges at level 40, got %d of %d", mainHand.Charges(), mainHand.MaxCharges)
+	}
+
+	mainHand.UseCharge(sim)
+	if mainHand.Charges() != 69 || offHand.Charges() != offHand.MaxCharges {
+		t.Errorf("Expected only the main hand to use a charge, got %d and %d", mainHand.Charges(), offHand.Charges())
+	}
+}
+
+func TestPoisonImbueRunsOutOfCharges(t *testing.T) {
+	sim, rogue := newPoisonedRogueSim(t)
+
+	mainHand := rogue.GetWeaponImbue(true)
+	for i := int32(0); i < mainHand.MaxCharges; i++ {
+		if !mainHand.IsActive() {
+			t.Fatalf("Expected the imbue to be active with %d charges used", i)
+		}
+		mainHand.UseCharge(sim)
+	}
+
+	if mainHand.IsActive() || mainHand.Charges() != 0 {
+		t.Errorf("Expected the imbue to fade once out of charges, got %d charges", mainHand.Charges())
+	}
+
+	// Using a charge without the imbue does nothing.
+	mainHand.UseCharge(sim)
+	if mainHand.IsActive() {
+		t.Errorf("Expected the imbue to stay inactive")
+	}
+}
+
+func TestReapplyWeaponImbueAction(t *testing.T) {
+	sim, rogue := newPoisonedRogueSim(t)
+
+	mainHand := rogue.GetWeaponImbue(true)
+	for mainHand.IsActive() {
+		mainHand.UseCharge(sim)
+	}
+
+	start := sim.CurrentTime
+	rogue.Rotation.DoNextAction(sim)
+	if rogue.Hardcast.Expires != start+core.WeaponImbueApplyTime {
+		t.Fatalf("Expected reapplying the imbue to take %s, finishes at %s", core.WeaponImbueApplyTime, rogue.Hardcast.Expires-start)
+	}
+
+	for sim.CurrentTime <= start+core.WeaponImbueApplyTime {
+		if mainHand.IsActive() && sim.CurrentTime < start+core.WeaponImbueApplyTime {
+			t.Fatalf("Expected the imbue to be reapplied when the cast finishes, was reapplied after %s", sim.CurrentTime-start)
+		}
+		if sim.Step() {
+			t.Fatalf("Expected the sim to run past the cast")
+		}
+	}
+
+	if !mainHand.IsActive() {
+		t.Errorf("Expected the imbue to be reapplied")
+	}
+	if casts := mainHand.ReapplySpell.SpellMetrics[0].Casts; casts != 1 {
+		t.Errorf("Expected 1 reapplication in the spell metrics, got %d", casts)
+	}
+	if offHand := rogue.GetWeaponImbue(false); offHand.ReapplySpell.SpellMetrics[0].Casts != 0 {
+		t.Errorf("Expected the off hand imbue not to be reapplied")
+	}
+}
+
 var CombatDagger25Talents = "-025305000001"
 var CombatDagger40Talents = "-0053052020550100201"
 var Assassination25Talents = "0053021--05"
@@ -172,20 +282,6 @@ var Stats = []proto.Stat{
 	proto.Stat_StatMeleeCrit,
 }
 
-var DefaultAssassinationRogue = &proto.Player_Rogue{
-	Rogue: &proto.Rogue{
-		Options: DefaultDeadlyBrewOptions,
-	},
-}
-
-var DefaultCombatRogue = &proto.Player_Rogue{
-	Rogue: &proto.Rogue{
-		Options: DefaultDeadlyBrewOptions,
-	},
-}
-
-var DefaultDeadlyBrewOptions = &proto.RogueOptions{}
-
 var Phase1Consumes = core.ConsumesCombo{
 	Label: "Phase 1 Consumes",
 	Consumes: &proto.Consumes{
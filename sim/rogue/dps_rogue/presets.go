@@ -0,0 +1,62 @@
+package dpsrogue
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p1_combat",
+		Spec:        proto.Spec_SpecRogue,
+		Class:       proto.Class_ClassRogue,
+		Race:        proto.Race_RaceHuman,
+		Phase:       1,
+		Level:       25,
+		GearSet:     "p1_combat",
+		Rotation:    "basic_strike_25",
+		Talents:     "CombatDagger25Talents",
+		Consumes:    "P1Consumes",
+		SpecOptions: DefaultCombatRogue,
+	},
+	{
+		Name:        "p2_combat",
+		Spec:        proto.Spec_SpecRogue,
+		Class:       proto.Class_ClassRogue,
+		Race:        proto.Race_RaceHuman,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2_daggers",
+		Rotation:    "mutilate",
+		Talents:     "CombatMutilate40Talents",
+		Consumes:    "P2Consumes",
+		SpecOptions: DefaultCombatRogue,
+	},
+	{
+		Name:        "p2_mutilate",
+		Spec:        proto.Spec_SpecRogue,
+		Class:       proto.Class_ClassRogue,
+		Race:        proto.Race_RaceHuman,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2_daggers",
+		Rotation:    "mutilate",
+		Talents:     "ColdBloodMutilate40Talents",
+		Consumes:    "P2Consumes",
+		SpecOptions: DefaultAssassinationRogue,
+	},
+}
+
+var DefaultAssassinationRogue = &proto.Player_Rogue{
+	Rogue: &proto.Rogue{
+		Options: DefaultDeadlyBrewOptions,
+	},
+}
+
+var DefaultCombatRogue = &proto.Player_Rogue{
+	Rogue: &proto.Rogue{
+		Options: DefaultDeadlyBrewOptions,
+	},
+}
+
+var DefaultDeadlyBrewOptions = &proto.RogueOptions{}
@@ -50,7 +50,7 @@ func (rogue *Rogue) registerEnvenom() {
 			},
 		},
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
-			return rogue.ComboPoints() > 0 && rogue.deadlyPoisonTick.Dot(target).IsActive()
+			return rogue.ComboPoints() > 0 && rogue.deadlyPoisonTick != nil && rogue.deadlyPoisonTick.Dot(target).IsActive()
 		},
 
 		DamageMultiplier: rogue.getPoisonDamageMultiplier(),
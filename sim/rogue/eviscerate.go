@@ -8,33 +8,21 @@ import (
 )
 
 func (rogue *Rogue) registerEviscerate() {
-	flatDamage := map[int32]float64{
-		25: 10,
-		40: 22,
-		50: 34,
-		60: 54,
-	}[rogue.Level]
-
-	comboDamageBonus := map[int32]float64{
-		25: 31,
-		40: 77,
-		50: 110,
-		60: 170,
-	}[rogue.Level]
-
-	damageVariance := map[int32]float64{
-		25: 20,
-		40: 44,
-		50: 68,
-		60: 108,
-	}[rogue.Level]
-
-	spellID := map[int32]int32{
-		25: 6762,
-		40: 8624,
-		50: 11299,
-		60: 31016,
-	}[rogue.Level]
+	levels := [10]int{0, 1, 8, 16, 24, 32, 40, 48, 56, 60}
+	spellIDs := [10]int32{0, 2098, 6760, 6761, 6762, 8623, 8624, 11299, 11300, 31016}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	// Ranks 3, 5 and 8 are estimates between their neighbouring ranks
+	flatDamage := [10]float64{0, 1, 1, 3, 10, 12, 22, 34, 52, 54}[rank]
+
+	comboDamageBonus := [10]float64{0, 5, 11, 19, 31, 52, 77, 110, 147, 170}[rank]
+
+	damageVariance := [10]float64{0, 4, 10, 14, 20, 32, 44, 68, 96, 108}[rank]
+
+	spellID := spellIDs[rank]
 
 	cutToTheChase := rogue.HasRune(proto.RogueRune_RuneCutToTheChase)
 
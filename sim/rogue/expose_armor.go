@@ -11,19 +11,16 @@ func (rogue *Rogue) registerExposeArmorSpell() {
 		return core.ExposeArmorAura(target, rogue.Talents.ImprovedExposeArmor, rogue.Level)
 	})
 
-	spellID := map[int32]int32{
-		25: 8647,
-		40: 8650,
-		50: 11197,
-		60: 11198,
-	}[rogue.Level]
-
-	arpenPerCombo := map[int32]float64{
-		25: 80,
-		40: 210,
-		50: 275,
-		60: 340,
-	}[rogue.Level]
+	levels := [5]int{0, 14, 36, 46, 56}
+	spellIDs := [5]int32{0, 8647, 8650, 11197, 11198}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	spellID := spellIDs[rank]
+
+	arpenPerCombo := [5]float64{0, 80, 210, 275, 340}[rank]
 
 	arpenPerCombo *= []float64{1, 1.25, 1.5}[rogue.Talents.ImprovedExposeArmor]
 
@@ -35,7 +35,6 @@ func (rogue *Rogue) makeFanOfKnivesWeaponHitSpell(isMH bool) *core.Spell {
 	})
 }
 
-// TODO: 8 yd range
 func (rogue *Rogue) registerFanOfKnives() {
 	if !rogue.HasRune(proto.RogueRune_RuneFanOfKnives) {
 		return
@@ -50,6 +49,7 @@ func (rogue *Rogue) registerFanOfKnives() {
 		ActionID:    core.ActionID{SpellID: FanOfKnivesSpellID},
 		SpellSchool: core.SpellSchoolPhysical,
 		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL | SpellFlagCarnage,
+		Aoe:         core.AoeConfig{Radius: 8},
 
 		EnergyCost: core.EnergyCostOptions{
 			Cost: 50 - core.TernaryFloat64(activate2PcBonuses, 20, 0),
@@ -63,23 +63,24 @@ func (rogue *Rogue) registerFanOfKnives() {
 
 		ApplyEffects: func(sim *core.Simulation, unit *core.Unit, spell *core.Spell) {
 			rogue.BreakStealth(sim)
+			aoeTargets := spell.AoeTargets(unit)
 			// Calc and apply all OH hits first, because MH hits can benefit from an OH felstriker proc.
-			for i, aoeTarget := range sim.Encounter.TargetUnits {
+			for i, aoeTarget := range aoeTargets {
 				baseDamage := ohSpell.Unit.OHWeaponDamage(sim, ohSpell.MeleeAttackPower())
 				baseDamage *= sim.Encounter.AOECapMultiplier()
 				results[i] = ohSpell.CalcDamage(sim, aoeTarget, baseDamage, ohSpell.OutcomeMeleeSpecialHitAndCrit)
 			}
-			for i := range sim.Encounter.TargetUnits {
-				ohSpell.DealDamage(sim, results[i])
+			for _, result := range results[:len(aoeTargets)] {
+				ohSpell.DealDamage(sim, result)
 			}
 
-			for i, aoeTarget := range sim.Encounter.TargetUnits {
+			for i, aoeTarget := range aoeTargets {
 				baseDamage := mhSpell.Unit.MHWeaponDamage(sim, mhSpell.MeleeAttackPower())
 				baseDamage *= sim.Encounter.AOECapMultiplier()
 				results[i] = mhSpell.CalcDamage(sim, aoeTarget, baseDamage, mhSpell.OutcomeMeleeSpecialHitAndCrit)
 			}
-			for i := range sim.Encounter.TargetUnits {
-				mhSpell.DealDamage(sim, results[i])
+			for _, result := range results[:len(aoeTargets)] {
+				mhSpell.DealDamage(sim, result)
 			}
 		},
 	})
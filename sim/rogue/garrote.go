@@ -8,19 +8,16 @@ import (
 )
 
 func (rogue *Rogue) registerGarrote() {
-	baseDamage := map[int32]float64{
-		25: 34,
-		40: 59,
-		50: 74,
-		60: 92,
-	}[rogue.Level]
+	levels := [5]int{0, 22, 38, 46, 54}
+	spellIDs := [5]int32{0, 8631, 8633, 11289, 11290}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
 
-	spellID := map[int32]int32{
-		25: 8631,
-		40: 8633,
-		50: 11289,
-		60: 11290,
-	}[rogue.Level]
+	baseDamage := [5]float64{0, 34, 59, 74, 92}[rank]
+
+	spellID := spellIDs[rank]
 
 	hasCutthroatRune := rogue.HasRune(proto.RogueRune_RuneCutthroat)
 
@@ -46,7 +43,7 @@ func (rogue *Rogue) registerGarrote() {
 			if !rogue.IsStealthed() {
 				return false
 			}
-			return hasCutthroatRune || !rogue.PseudoStats.InFrontOfTarget
+			return hasCutthroatRune || !rogue.IsInFrontOf(target)
 		},
 
 		DamageMultiplier: 1 +
@@ -11,11 +11,14 @@ func (rogue *Rogue) registerHemorrhageSpell() {
 		return
 	}
 
-	spellID := map[int32]int32{
-		40: 16511,
-		50: 17347,
-		60: 17348,
-	}[rogue.Level]
+	levels := [4]int{0, 30, 46, 58}
+	spellIDs := [4]int32{0, 16511, 17347, 17348}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	spellID := spellIDs[rank]
 
 	actionID := core.ActionID{SpellID: spellID}
 
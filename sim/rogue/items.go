@@ -29,6 +29,7 @@ func init() {
 			DefenseType: core.DefenseTypeMagic,
 			ProcMask:    core.ProcMaskSpellDamage,
 			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagOffensiveEquipment,
+			Aoe:         core.AoeConfig{Radius: 8},
 
 			Cast: core.CastConfig{
 				CD: core.Cooldown{
@@ -40,7 +41,7 @@ func init() {
 			DamageMultiplier: 1,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					//Confirmed always hits through logs
 					spell.CalcAndDealDamage(sim, aoeTarget, 140, spell.OutcomeAlwaysHit)
 				}
@@ -46,7 +46,7 @@ func (rogue *Rogue) newMutilateHitSpell(isMH bool) *core.Spell {
 
 			// TODO: Add support for all poison effects (such as chipped bite proc), if they apply ;)
 			oldMultiplier := spell.DamageMultiplier
-			if rogue.deadlyPoisonTick.Dot(target).IsActive() || rogue.woundPoisonDebuffAuras.Get(target).IsActive() {
+			if (rogue.deadlyPoisonTick != nil && rogue.deadlyPoisonTick.Dot(target).IsActive()) || rogue.woundPoisonDebuffAuras.Get(target).IsActive() {
 				spell.DamageMultiplier *= 1.2
 			}
 			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeSpecialCritOnly)
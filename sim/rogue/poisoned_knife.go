@@ -58,21 +58,32 @@ func (rogue *Rogue) registerPoisonedKnife() {
 
 			if result.Landed() {
 				rogue.AddComboPoints(sim, 1, spell.ComboPointMetrics())
-				dp := rogue.deadlyPoisonTick.Dot(target)
-				numDPStacks := float64(dp.GetStacks() * 5)
-				rogue.AddEnergy(sim, numDPStacks, poisonedKnifeMetrics)
+				if rogue.deadlyPoisonTick != nil {
+					dp := rogue.deadlyPoisonTick.Dot(target)
+					numDPStacks := float64(dp.GetStacks() * 5)
+					rogue.AddEnergy(sim, numDPStacks, poisonedKnifeMetrics)
+				}
 
 				// 100% application of OH poison (except for 1%? It can resist extremely rarely)
-				switch rogue.Consumes.OffHandImbue {
+				offHandImbue := rogue.GetWeaponImbue(false)
+				offHandPoison := proto.WeaponImbue_WeaponImbueUnknown
+				if offHandImbue != nil && offHandImbue.IsActive() {
+					offHandPoison = offHandImbue.Imbue
+				}
+
+				switch offHandPoison {
 				case proto.WeaponImbue_InstantPoison:
 					rogue.InstantPoison[ShivProc].Cast(sim, target)
+					offHandImbue.UseCharge(sim)
 				case proto.WeaponImbue_DeadlyPoison:
 					rogue.DeadlyPoison[ShivProc].Cast(sim, target)
+					offHandImbue.UseCharge(sim)
 				case proto.WeaponImbue_WoundPoison:
 					rogue.WoundPoison[ShivProc].Cast(sim, target)
+					offHandImbue.UseCharge(sim)
 				// Add new alternative poisons as they are implemented
 				default:
-					if hasDeadlyBrew {
+					if hasDeadlyBrew && rogue.InstantPoison[DeadlyBrewProc] != nil {
 						rogue.InstantPoison[DeadlyBrewProc].Cast(sim, target)
 					}
 				}
@@ -22,7 +22,7 @@ Deadly Poison: 30% proc chance, 5 stacks
 60: 108 damage, 11356 ID, 105 charges (Rank 4, Rank 5 is by book)
 
 Wound Poison: 30% proc chance, 5 stacks
-25: x damage, x ID (none, first rank is level 32)
+25: -55 healing, 13219 ID, 75 charges (Rank 1, trained at 32)
 40: -75 healing, 11325 ID, 75 charges (Rank 2)
 50: -105 healing, 13226 ID, 90 charges (Rank 3)
 60: -135 healing, 13227 ID, 105 charges (Rank 4)
@@ -35,8 +35,6 @@ Sebacious Poison: 30% proc chance, 5 stacks
 60: 1700 armor for 15 sec, 105 charges
 */
 
-// TODO: Add charges to poisons (not deadly brew)
-
 type PoisonProcSource int
 
 const (
@@ -65,6 +63,35 @@ func (rogue *Rogue) getPoisonDamageMultiplier() float64 {
 	return []float64{1, 1.04, 1.08, 1.12, 1.16, 1.2}[rogue.Talents.VilePoisons]
 }
 
+// Levels each rank of the poisons is trained at. Deadly and Wound Poison are
+// trained at 30 and 32, but the sim allows them from 25.
+var (
+	instantPoisonLevels = [7]int{0, 20, 28, 36, 44, 52, 60}
+	deadlyPoisonLevels  = [5]int{0, 25, 38, 46, 54}
+	woundPoisonLevels   = [5]int{0, 25, 40, 48, 56}
+)
+
+var (
+	instantPoisonSpellIDs = [7]int32{0, 8679, 8686, 8688, 11338, 11339, 11340}
+	deadlyPoisonSpellIDs  = [5]int32{0, 2823, 2824, 11355, 11356}
+)
+
+// Registers a weapon imbue for each weapon the poison is applied to, so it uses up
+// charges when it procs. Deadly Brew procs don't use charges.
+func (rogue *Rogue) registerPoisonImbues(imbue proto.WeaponImbue, itemID int32, charges int32) {
+	for _, isMH := range []bool{true, false} {
+		if rogue.getImbueProcMask(imbue).Matches(core.Ternary(isMH, core.ProcMaskMeleeMH, core.ProcMaskMeleeOH)) {
+			rogue.RegisterWeaponImbue(core.WeaponImbueConfig{
+				Imbue:      imbue,
+				ActionID:   core.ActionID{ItemID: itemID},
+				IsMH:       isMH,
+				MaxCharges: charges,
+				Duration:   core.WeaponImbueDuration,
+			})
+		}
+	}
+}
+
 ///////////////////////////////////////////////////////////////////////////
 //                               Apply Poisons
 ///////////////////////////////////////////////////////////////////////////
@@ -79,13 +106,8 @@ func (rogue *Rogue) applyPoisons() {
 
 // Apply Deadly Brew Instant Poison procs
 func (rogue *Rogue) applyDeadlyBrewInstant() {
-	// apply IP from all weapons w/o IP, DP, or WP applied
-	procMask := core.ProcMaskMelee
-	procMask ^= rogue.getImbueProcMask(proto.WeaponImbue_InstantPoison)
-	procMask ^= rogue.getImbueProcMask(proto.WeaponImbue_DeadlyPoison)
-	procMask ^= rogue.getImbueProcMask(proto.WeaponImbue_WoundPoison)
-
-	if procMask == core.ProcMaskUnknown {
+	// Instant Poison is only registered once the rogue can learn it
+	if _, ok := core.GetRankForLevel(instantPoisonLevels[:], rogue.Level); !ok {
 		return
 	}
 
@@ -96,7 +118,11 @@ func (rogue *Rogue) applyDeadlyBrewInstant() {
 			aura.Activate(sim)
 		},
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if !result.Landed() || !spell.ProcMask.Matches(procMask) {
+			if !result.Landed() || !spell.ProcMask.Matches(core.ProcMaskMelee) {
+				return
+			}
+			// apply IP from all weapons w/o IP, DP, or WP on them at the time of the hit
+			if rogue.hasActivePoisonImbue(spell) {
 				return
 			}
 			if sim.RandomFloat("Instant Poison") < rogue.GetInstantPoisonProcChance() {
@@ -106,8 +132,26 @@ func (rogue *Rogue) applyDeadlyBrewInstant() {
 	})
 }
 
+// Returns whether the weapon the spell was dealt with still has Instant, Deadly or
+// Wound Poison on it. Poisons that ran out of charges or expired don't count.
+func (rogue *Rogue) hasActivePoisonImbue(spell *core.Spell) bool {
+	weaponImbue := rogue.GetWeaponImbueForSpell(spell)
+	if weaponImbue == nil || !weaponImbue.IsActive() {
+		return false
+	}
+	switch weaponImbue.Imbue {
+	case proto.WeaponImbue_InstantPoison, proto.WeaponImbue_DeadlyPoison, proto.WeaponImbue_WoundPoison:
+		return true
+	}
+	return false
+}
+
 // Apply Deadly Brew Deadly Poison procs
 func (rogue *Rogue) applyDeadlyBrewDeadly() {
+	if _, ok := core.GetRankForLevel(deadlyPoisonLevels[:], rogue.Level); !ok {
+		return
+	}
+
 	rogue.RegisterAura(core.Aura{
 		Label:    "Deadly Brew (Deadly)",
 		Duration: core.NeverExpires,
@@ -130,6 +174,14 @@ func (rogue *Rogue) applyInstantPoison() {
 		return
 	}
 
+	rank, ok := core.GetRankForLevel(instantPoisonLevels[:], rogue.Level)
+	if !ok {
+		return
+	}
+	itemID := [7]int32{0, 6947, 6949, 6950, 8926, 8927, 8928}[rank]
+	charges := [7]int32{0, 40, 55, 70, 85, 100, 115}[rank]
+	rogue.registerPoisonImbues(proto.WeaponImbue_InstantPoison, itemID, charges)
+
 	rogue.RegisterAura(core.Aura{
 		Label:    "Instant Poison",
 		Duration: core.NeverExpires,
@@ -141,8 +193,14 @@ func (rogue *Rogue) applyInstantPoison() {
 				return
 			}
 
+			weaponImbue := rogue.GetWeaponImbueForSpell(spell)
+			if weaponImbue == nil || !weaponImbue.IsActive() {
+				return
+			}
+
 			if sim.RandomFloat("Instant Poison") < rogue.GetInstantPoisonProcChance() {
 				rogue.InstantPoison[NormalProc].Cast(sim, result.Target)
+				weaponImbue.UseCharge(sim)
 			}
 		},
 	})
@@ -155,6 +213,14 @@ func (rogue *Rogue) applyDeadlyPoison() {
 		return
 	}
 
+	rank, ok := core.GetRankForLevel(deadlyPoisonLevels[:], rogue.Level)
+	if !ok {
+		return
+	}
+	itemID := [5]int32{0, 2892, 2893, 8984, 8985}[rank]
+	charges := [5]int32{0, 60, 75, 90, 105}[rank]
+	rogue.registerPoisonImbues(proto.WeaponImbue_DeadlyPoison, itemID, charges)
+
 	rogue.RegisterAura(core.Aura{
 		Label:    "Deadly Poison",
 		Duration: core.NeverExpires,
@@ -165,8 +231,14 @@ func (rogue *Rogue) applyDeadlyPoison() {
 			if !result.Landed() || !spell.ProcMask.Matches(procMask) {
 				return
 			}
+
+			weaponImbue := rogue.GetWeaponImbueForSpell(spell)
+			if weaponImbue == nil || !weaponImbue.IsActive() {
+				return
+			}
 			if sim.RandomFloat("Deadly Poison") < rogue.GetDeadlyPoisonProcChance() {
 				rogue.DeadlyPoison[NormalProc].Cast(sim, result.Target)
+				weaponImbue.UseCharge(sim)
 			}
 		},
 	})
@@ -179,6 +251,14 @@ func (rogue *Rogue) applyWoundPoison() {
 		return
 	}
 
+	rank, ok := core.GetRankForLevel(woundPoisonLevels[:], rogue.Level)
+	if !ok {
+		return
+	}
+	itemID := [5]int32{0, 10918, 10920, 10921, 10922}[rank]
+	charges := [5]int32{0, 75, 75, 90, 105}[rank]
+	rogue.registerPoisonImbues(proto.WeaponImbue_WoundPoison, itemID, charges)
+
 	rogue.RegisterAura(core.Aura{
 		Label:    "Wound Poison",
 		Duration: core.NeverExpires,
@@ -190,8 +270,14 @@ func (rogue *Rogue) applyWoundPoison() {
 				return
 			}
 
+			weaponImbue := rogue.GetWeaponImbueForSpell(spell)
+			if weaponImbue == nil || !weaponImbue.IsActive() {
+				return
+			}
+
 			if sim.RandomFloat("Wound Poison") < rogue.GetWoundPoisonProcChance() {
 				rogue.WoundPoison[NormalProc].Cast(sim, result.Target)
+				weaponImbue.UseCharge(sim)
 			}
 		},
 	})
@@ -250,26 +336,26 @@ func (rogue *Rogue) applySebaciousPoison() {
 ///////////////////////////////////////////////////////////////////////////
 
 func (rogue *Rogue) registerInstantPoisonSpell() {
+	rank, ok := rogue.GetSpellRankForLevel(instantPoisonLevels[:], instantPoisonSpellIDs[:])
+	if !ok {
+		return
+	}
+
 	rogue.InstantPoison = [3]*core.Spell{
-		rogue.makeInstantPoison(NormalProc),
-		rogue.makeInstantPoison(ShivProc),
-		rogue.makeInstantPoison(DeadlyBrewProc),
+		rogue.makeInstantPoison(rank, NormalProc),
+		rogue.makeInstantPoison(rank, ShivProc),
+		rogue.makeInstantPoison(rank, DeadlyBrewProc),
 	}
 }
 
 func (rogue *Rogue) registerDeadlyPoisonSpell() {
-	baseDamageTick := map[int32]float64{
-		25: 9,
-		40: 13,
-		50: 20,
-		60: 27,
-	}[rogue.Level]
-	spellID := map[int32]int32{
-		25: 2823,
-		40: 2824,
-		50: 11355,
-		60: 11356,
-	}[rogue.Level]
+	rank, ok := rogue.GetSpellRankForLevel(deadlyPoisonLevels[:], deadlyPoisonSpellIDs[:])
+	if !ok {
+		return
+	}
+
+	baseDamageTick := [5]float64{0, 9, 13, 20, 27}[rank]
+	spellID := deadlyPoisonSpellIDs[rank]
 
 	hasDeadlyBrew := rogue.HasRune(proto.RogueRune_RuneDeadlyBrew)
 
@@ -415,27 +501,12 @@ func (rogue *Rogue) registerSebaciousPoisonSpell() {
 ///////////////////////////////////////////////////////////////////////////
 
 // Make a source based variant of Instant Poison
-func (rogue *Rogue) makeInstantPoison(procSource PoisonProcSource) *core.Spell {
-	baseDamageByLevel := map[int32]float64{
-		25: 19,
-		40: 44,
-		50: 67,
-		60: 112,
-	}[rogue.Level]
-
-	damageVariance := map[int32]float64{
-		25: 6,
-		40: 12,
-		50: 18,
-		60: 36,
-	}[rogue.Level]
-
-	spellID := map[int32]int32{
-		25: 8679,
-		40: 8688,
-		50: 11338,
-		60: 11340,
-	}[rogue.Level]
+func (rogue *Rogue) makeInstantPoison(rank int, procSource PoisonProcSource) *core.Spell {
+	baseDamageByLevel := [7]float64{0, 19, 30, 44, 67, 92, 112}[rank]
+
+	damageVariance := [7]float64{0, 6, 8, 12, 18, 26, 36}[rank]
+
+	spellID := instantPoisonSpellIDs[rank]
 
 	hasDeadlyBrew := rogue.HasRune(proto.RogueRune_RuneDeadlyBrew)
 
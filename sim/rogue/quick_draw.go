@@ -13,12 +13,14 @@ func (rogue *Rogue) registerQuickDrawSpell() {
 		return
 	}
 
-	ammoBonusDamage := map[int32]float64{
-		25: 7.5,
-		40: 13,
-		50: 15,
-		60: 20,
-	}[rogue.Level]
+	// Best ammo available at each phase's level cap
+	levels := [5]int{0, 25, 40, 50, 60}
+	rank, ok := core.GetRankForLevel(levels[:], rogue.Level)
+	if !ok {
+		return
+	}
+
+	ammoBonusDamage := [5]float64{0, 7.5, 13, 15, 20}[rank]
 	normalizedAmmoBonusDamage := ammoBonusDamage / 2.8
 
 	// Quick Draw applies a 50% slow, but bosses are immune
@@ -6,13 +6,16 @@ import (
 	"github.com/wowsims/sod/sim/core"
 )
 
+var ruptureLevels = [5]int{0, 20, 36, 44, 60}
+
 func (rogue *Rogue) registerRupture() {
-	spellID := map[int32]int32{
-		25: 1943,
-		40: 8640,
-		50: 11273,
-		60: 11275,
-	}[rogue.Level]
+	spellIDs := [5]int32{0, 1943, 8640, 11273, 11275}
+	rank, ok := rogue.GetSpellRankForLevel(ruptureLevels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	spellID := spellIDs[rank]
 
 	rogue.Rupture = rogue.RegisterSpell(core.SpellConfig{
 		SpellCode:    SpellCode_RogueRupture,
@@ -77,19 +80,14 @@ func (rogue *Rogue) registerRupture() {
 }
 
 func (rogue *Rogue) RuptureDamage(comboPoints int32) float64 {
-	baseTickDamage := map[int32]float64{
-		25: 8,
-		40: 18,
-		50: 27,
-		60: 60,
-	}[rogue.Level]
-
-	comboTickDamage := map[int32]float64{
-		25: 2,
-		40: 4,
-		50: 5,
-		60: 8,
-	}[rogue.Level]
+	rank, ok := core.GetRankForLevel(ruptureLevels[:], rogue.Level)
+	if !ok {
+		return 0
+	}
+
+	baseTickDamage := [5]float64{0, 8, 18, 27, 60}[rank]
+
+	comboTickDamage := [5]float64{0, 2, 4, 5, 8}[rank]
 
 	return baseTickDamage + comboTickDamage*float64(comboPoints) +
 		[]float64{0, 0.04 / 4, 0.10 / 5, 0.18 / 6, 0.21 / 7, 0.24 / 8}[comboPoints]*rogue.Rupture.MeleeAttackPower()
@@ -10,19 +10,16 @@ import (
 func (rogue *Rogue) registerSinisterStrikeSpell() {
 	hasSaberSlash := rogue.HasRune(proto.RogueRune_RuneSaberSlash)
 
-	flatDamageBonus := map[int32]float64{
-		25: 15,
-		40: 33,
-		50: 52,
-		60: 68,
-	}[rogue.Level]
+	levels := [9]int{0, 1, 6, 14, 22, 30, 38, 46, 54}
+	spellIDs := [9]int32{0, 1752, 1757, 1758, 1759, 1760, 8621, 11293, 11294}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
 
-	spellID := map[int32]int32{
-		25: 1759,
-		40: 8621,
-		50: 11293,
-		60: 11294,
-	}[rogue.Level]
+	flatDamageBonus := [9]float64{0, 3, 6, 10, 15, 22, 33, 52, 68}[rank]
+
+	spellID := spellIDs[rank]
 
 	rogue.SinisterStrike = rogue.RegisterSpell(core.SpellConfig{
 		SpellCode:   SpellCode_RogueSinisterStrike,
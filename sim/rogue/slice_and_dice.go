@@ -7,19 +7,16 @@ import (
 )
 
 func (rogue *Rogue) registerSliceAndDice() {
-	hasteBonusByRank := map[int32]float64{
-		25: 0.20,
-		40: 0.20,
-		50: 0.30,
-		60: 0.30,
-	}[rogue.Level]
+	levels := [3]int{0, 10, 42}
+	spellIDs := [3]int32{0, 5171, 6774}
+	rank, ok := rogue.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	hasteBonusByRank := [3]float64{0, 0.20, 0.30}[rank]
 
-	spellID := map[int32]int32{
-		25: 5171,
-		40: 5171,
-		50: 6774,
-		60: 6774,
-	}[rogue.Level]
+	spellID := spellIDs[rank]
 
 	actionID := core.ActionID{SpellID: spellID}
 
@@ -0,0 +1,43 @@
+package tankrogue
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p3_saber",
+		Spec:        proto.Spec_SpecTankRogue,
+		Class:       proto.Class_ClassRogue,
+		Race:        proto.Race_RaceHuman,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "p3_saber",
+		Rotation:    "Saber_DPS_50",
+		Talents:     "TankSaber50Talents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultTankRogue,
+	},
+	{
+		Name:        "p4_saber",
+		Spec:        proto.Spec_SpecTankRogue,
+		Class:       proto.Class_ClassRogue,
+		Race:        proto.Race_RaceHuman,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_saber",
+		Rotation:    "Saber_Weave_60",
+		Talents:     "TankSaber60Talents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultTankRogue,
+	},
+}
+
+var DefaultTankRogue = &proto.Player_TankRogue{
+	TankRogue: &proto.TankRogue{
+		Options: &proto.RogueOptions{
+			HonorAmongThievesCritRate: 100,
+		},
+	},
+}
@@ -21,6 +21,7 @@ func RegisterTankRogue() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type TankRogue struct {
@@ -17,8 +17,14 @@ var WindfuryTotemLevel = [WindfuryTotemRanks + 1]int{0, 32, 42, 52}
 func (shaman *Shaman) registerWindfuryTotemSpell() {
 	shaman.WindfuryTotem = make([]*core.Spell, WindfuryTotemRanks+1)
 
+	// The party's Windfury imbue toggles are cleared in favour of the totem, see NewShaman.
+	var windfuryAuras core.AuraArray
+	if !shaman.ProvidedBuffsSuppressed() {
+		windfuryAuras = shaman.NewPartyAuraArray(core.WindfuryAura)
+	}
+
 	for rank := 1; rank <= WindfuryTotemRanks; rank++ {
-		config := shaman.newWindfuryTotemSpellConfig(rank)
+		config := shaman.newWindfuryTotemSpellConfig(rank, windfuryAuras)
 
 		if config.RequiredLevel <= int(shaman.Level) {
 			shaman.WindfuryTotem[rank] = shaman.RegisterSpell(config)
@@ -31,9 +37,9 @@ func (shaman *Shaman) registerWindfuryTotemSpell() {
 	)
 }
 
-func (shaman *Shaman) newWindfuryTotemSpellConfig(rank int) core.SpellConfig {
+func (shaman *Shaman) newWindfuryTotemSpellConfig(rank int, windfuryAuras core.AuraArray) core.SpellConfig {
 	spellId := WindfuryTotemSpellId[rank]
-	// TODO: The sim won't respect the value of a totem dropped via the APL. It uses hard-coded values from buffs.go
+	// TODO: The totem's rank isn't respected, the buff's rank comes from each party member's level in buffs.go
 	// bonusDamage := WindfuryTotemBonusDamage[rank]
 	manaCost := WindfuryTotemManaCost[rank]
 	level := WindfuryTotemLevel[rank]
@@ -44,8 +50,15 @@ func (shaman *Shaman) newWindfuryTotemSpellConfig(rank int) core.SpellConfig {
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[EarthTotem] = sim.CurrentTime + duration
-		shaman.ActiveTotems[EarthTotem] = spell
+		shaman.TotemExpirations[AirTotem] = sim.CurrentTime + duration
+		shaman.ActiveTotems[AirTotem] = spell
+
+		for _, aura := range windfuryAuras {
+			if aura != nil {
+				aura.Duration = duration
+				aura.Activate(sim)
+			}
+		}
 	}
 	return spell
 }
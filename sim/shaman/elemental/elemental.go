@@ -21,6 +21,7 @@ func RegisterElementalShaman() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 func NewElementalShaman(character *core.Character, options *proto.Player) *ElementalShaman {
@@ -90,12 +90,6 @@ var Phase2Talents = "550031550000151"
 var Phase3Talents = "550031550000151-500203"
 var Phase4Talents = "550301550000151--50205300005"
 
-var PlayerOptionsAdaptive = &proto.Player_ElementalShaman{
-	ElementalShaman: &proto.ElementalShaman{
-		Options: &proto.ElementalShaman_Options{},
-	},
-}
-
 var Phase1Consumes = core.ConsumesCombo{
 	Label: "Phase 1 Consumes",
 	Consumes: &proto.Consumes{
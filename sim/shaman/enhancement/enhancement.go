@@ -23,6 +23,7 @@ func RegisterEnhancementShaman() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 func NewEnhancementShaman(character *core.Character, options *proto.Player) *EnhancementShaman {
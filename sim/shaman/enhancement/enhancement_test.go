@@ -111,26 +111,6 @@ var Phase2Talents = "-5005202105023051"
 var Phase3Talents = "05003-5005132105023051"
 var Phase4Talents = "25003105003-5005032105023051"
 
-var PlayerOptionsSyncDelayOH = &proto.Player_EnhancementShaman{
-	EnhancementShaman: &proto.EnhancementShaman{
-		Options: optionsSyncDelayOffhand,
-	},
-}
-
-var PlayerOptionsSyncAuto = &proto.Player_EnhancementShaman{
-	EnhancementShaman: &proto.EnhancementShaman{
-		Options: optionsSyncAuto,
-	},
-}
-
-var optionsSyncDelayOffhand = &proto.EnhancementShaman_Options{
-	SyncType: proto.ShamanSyncType_DelayOffhandSwings,
-}
-
-var optionsSyncAuto = &proto.EnhancementShaman_Options{
-	SyncType: proto.ShamanSyncType_Auto,
-}
-
 var Phase1Consumes = core.ConsumesCombo{
 	Label: "Phase 1 Consumes",
 	Consumes: &proto.Consumes{
@@ -261,3 +241,13 @@ var Stats = []proto.Stat{
 	proto.Stat_StatMeleeCrit,
 	proto.Stat_StatSpellPower,
 }
+
+var PlayerOptionsSyncDelayOH = &proto.Player_EnhancementShaman{
+	EnhancementShaman: &proto.EnhancementShaman{
+		Options: optionsSyncDelayOffhand,
+	},
+}
+
+var optionsSyncDelayOffhand = &proto.EnhancementShaman_Options{
+	SyncType: proto.ShamanSyncType_DelayOffhandSwings,
+}
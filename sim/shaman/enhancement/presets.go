@@ -0,0 +1,71 @@
+package enhancement
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "phase_1",
+		Spec:        proto.Spec_SpecEnhancementShaman,
+		Class:       proto.Class_ClassShaman,
+		Race:        proto.Race_RaceTroll,
+		Phase:       1,
+		Level:       25,
+		GearSet:     "phase_1",
+		Rotation:    "phase_1",
+		Talents:     "TalentsPhase1",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSyncAuto,
+	},
+	{
+		Name:        "phase_2",
+		Spec:        proto.Spec_SpecEnhancementShaman,
+		Class:       proto.Class_ClassShaman,
+		Race:        proto.Race_RaceTroll,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "phase_2",
+		Rotation:    "phase_2",
+		Talents:     "TalentsPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSyncAuto,
+	},
+	{
+		Name:        "phase_3",
+		Spec:        proto.Spec_SpecEnhancementShaman,
+		Class:       proto.Class_ClassShaman,
+		Race:        proto.Race_RaceTroll,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "phase_3",
+		Rotation:    "phase_3",
+		Talents:     "TalentsPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSyncAuto,
+	},
+	{
+		Name:        "phase_4_dw",
+		Spec:        proto.Spec_SpecEnhancementShaman,
+		Class:       proto.Class_ClassShaman,
+		Race:        proto.Race_RaceTroll,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "phase_4_dw",
+		Rotation:    "phase_4",
+		Talents:     "TalentsPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsSyncAuto,
+	},
+}
+
+var PlayerOptionsSyncAuto = &proto.Player_EnhancementShaman{
+	EnhancementShaman: &proto.EnhancementShaman{
+		Options: optionsSyncAuto,
+	},
+}
+
+var optionsSyncAuto = &proto.EnhancementShaman_Options{
+	SyncType: proto.ShamanSyncType_Auto,
+}
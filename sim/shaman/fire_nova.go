@@ -41,6 +41,7 @@ func (shaman *Shaman) newFireNovaSpellConfig(rank int) core.SpellConfig {
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamage,
 		Flags:       SpellFlagShaman | SpellFlagFocusable | core.SpellFlagAPL,
+		Aoe:         core.AoeConfig{Radius: 10},
 
 		RequiredLevel: level,
 		Rank:          rank,
@@ -64,7 +65,7 @@ func (shaman *Shaman) newFireNovaSpellConfig(rank int) core.SpellConfig {
 		BonusCoefficient: spellCoeff,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 				result := spell.CalcDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicCrit)
 
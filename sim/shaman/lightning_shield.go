@@ -59,6 +59,7 @@ func (shaman *Shaman) registerNewLightningShieldSpell(rank int) {
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskEmpty,
 		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagPassiveSpell | SpellFlagShaman | SpellFlagLightning,
+		Aoe:         core.AoeConfig{Radius: 8},
 
 		DamageMultiplier: 1,
 		ThreatMultiplier: 1,
@@ -126,10 +127,8 @@ func (shaman *Shaman) registerNewLightningShieldSpell(rank int) {
 
 			if hasOverchargedRune {
 				// Deals damage to all targets within 8 yards and does not lose stacks
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
-					if aoeTarget.DistanceFromTarget <= 8 {
-						shaman.LightningShieldProcs[rank].Cast(sim, aoeTarget)
-					}
+				for _, aoeTarget := range shaman.LightningShieldProcs[rank].AoeTargets(spell.Unit) {
+					shaman.LightningShieldProcs[rank].Cast(sim, aoeTarget)
 				}
 			} else {
 				aura.RemoveStack(sim)
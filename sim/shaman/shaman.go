@@ -40,6 +40,23 @@ func NewShaman(character *core.Character, talents string) *Shaman {
 	shaman.ApplyFrostbrandImbue(shaman.getImbueProcMask(character, proto.WeaponImbue_FrostbrandWeapon))
 	shaman.ApplyWindfuryImbue(shaman.getImbueProcMask(character, proto.WeaponImbue_WindfuryWeapon))
 
+	if int(shaman.Level) >= WindfuryTotemLevel[1] {
+		shaman.RegisterProvidedBuff(core.ProvidedBuffConfig{
+			Label: "Windfury Totem",
+			ClearPlayerBuffs: func(player *core.Character, _ *proto.IndividualBuffs, consumes *proto.Consumes) {
+				if player.Party != shaman.Party {
+					return
+				}
+				if consumes.MainHandImbue == proto.WeaponImbue_Windfury {
+					consumes.MainHandImbue = proto.WeaponImbue_WeaponImbueUnknown
+				}
+				if consumes.OffHandImbue == proto.WeaponImbue_Windfury {
+					consumes.OffHandImbue = proto.WeaponImbue_WeaponImbueUnknown
+				}
+			},
+		})
+	}
+
 	if shaman.HasRune(proto.ShamanRune_RuneCloakFeralSpirit) {
 		shaman.SpiritWolves = &SpiritWolves{
 			SpiritWolf1: shaman.NewSpiritWolf(1),
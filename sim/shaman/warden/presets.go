@@ -0,0 +1,28 @@
+package warden
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "phase_4_enh_tank",
+		Spec:        proto.Spec_SpecWardenShaman,
+		Class:       proto.Class_ClassShaman,
+		Race:        proto.Race_RaceTroll,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "phase_4_enh_tank",
+		Rotation:    "phase_4_enh_tank",
+		Talents:     "TalentsDeepEnhPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsBasic,
+	},
+}
+
+var PlayerOptionsBasic = &proto.Player_WardenShaman{
+	WardenShaman: &proto.WardenShaman{
+		Options: &proto.WardenShaman_Options{},
+	},
+}
@@ -21,6 +21,7 @@ func RegisterWardenShaman() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type WardenShaman struct {
@@ -33,12 +33,6 @@ func TestWardenShaman(t *testing.T) {
 	}))
 }
 
-var PlayerOptionsBasic = &proto.Player_WardenShaman{
-	WardenShaman: &proto.WardenShaman{
-		Options: &proto.WardenShaman_Options{},
-	},
-}
-
 var Phase4Talents = "05033150003-0505032015003151"
 
 var Phase4Consumes = core.ConsumesCombo{
@@ -21,6 +21,7 @@ func RegisterDpsWarlock() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type DpsWarlock struct {
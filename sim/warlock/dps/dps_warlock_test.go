@@ -167,36 +167,6 @@ var Phase3NFRuinTalents = "25002500102-03-50502051020001"
 var Phase4AffTalents = "4500253012201005--50502051020001"
 var Phase4DestroTalents = "05002-035004-5050205102005151"
 
-var DefaultDestroWarlock = &proto.Player_Warlock{
-	Warlock: &proto.Warlock{
-		Options: &proto.WarlockOptions{
-			Armor:       proto.WarlockOptions_FelArmor,
-			Summon:      proto.WarlockOptions_Imp,
-			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
-		},
-	},
-}
-
-var DefaultAfflictionWarlock = &proto.Player_Warlock{
-	Warlock: &proto.Warlock{
-		Options: &proto.WarlockOptions{
-			Armor:       proto.WarlockOptions_FelArmor,
-			Summon:      proto.WarlockOptions_Imp,
-			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
-		},
-	},
-}
-
-var DefaultDemonologyWarlock = &proto.Player_Warlock{
-	Warlock: &proto.Warlock{
-		Options: &proto.WarlockOptions{
-			Armor:       proto.WarlockOptions_FelArmor,
-			Summon:      proto.WarlockOptions_Felguard,
-			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
-		},
-	},
-}
-
 var Phase1Consumes = core.ConsumesCombo{
 	Label: "Phase 1 Consumes",
 	Consumes: &proto.Consumes{
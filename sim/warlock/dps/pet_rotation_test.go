@@ -0,0 +1,72 @@
+package dps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Firebolt (Rank 5), the highest rank at level 40.
+const impFireboltSpellID = 7802
+
+func impRotationRaidSimRequest(impRotation string) *proto.RaidSimRequest {
+	player := &proto.Player{
+		Race:          proto.Race_RaceOrc,
+		Class:         proto.Class_ClassWarlock,
+		Level:         40,
+		Equipment:     core.GetGearSet("../../../ui/warlock/gear_sets/p2", "shadow").GearSet,
+		Consumes:      &proto.Consumes{},
+		Spec:          DefaultAfflictionWarlock,
+		TalentsString: Phase2AfflictionTalents,
+		Buffs:         &proto.IndividualBuffs{},
+		Rotation:      core.GetAplRotation("../../../ui/warlock/apls/p2", "affliction").Rotation,
+		PetRotations: map[string]*proto.APLRotation{
+			"Imp": core.APLRotationFromJsonString(impRotation),
+		},
+	}
+
+	return &proto.RaidSimRequest{
+		Raid:      core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: core.MakeSingleTargetEncounter(player.Level, 0),
+		SimOptions: &proto.SimOptions{
+			Iterations: 1,
+			IsTest:     true,
+			RandomSeed: 101,
+		},
+	}
+}
+
+func TestImpAPLRotationConditions(t *testing.T) {
+	// Only cast Firebolt once 10s have passed, while the warlock has mana to spare.
+	casts := core.RunCastSequence(impRotationRaidSimRequest(`{"type":"TypeAPL","priorityList":[
+		{"action":{"condition":{"and":{"vals":[
+			{"cmp":{"op":"OpGe","lhs":{"currentTime":{}},"rhs":{"const":{"val":"10s"}}}},
+			{"cmp":{"op":"OpGt","lhs":{"currentManaPercent":{"sourceUnit":{"type":"Owner"}}},"rhs":{"const":{"val":"0%"}}}}
+		]}},"castSpell":{"spellId":{"spellId":7802,"rank":5}}}}
+	]}`), "Imp")
+
+	if len(casts) == 0 {
+		t.Fatalf("Expected the imp to cast Firebolt from its APL")
+	}
+	for _, cast := range casts {
+		if cast.ActionID.SpellID != impFireboltSpellID {
+			t.Errorf("Expected only Firebolt casts, got %s at %s", cast.ActionID, cast.Time)
+		}
+		if cast.Time < time.Second*10 {
+			t.Errorf("Expected no casts before the condition is met at 10s, got %s at %s", cast.ActionID, cast.Time)
+		}
+	}
+}
+
+func TestImpAPLRotationReplacesBuiltIn(t *testing.T) {
+	// A condition on the owner that's never true, so the imp never casts.
+	casts := core.RunCastSequence(impRotationRaidSimRequest(`{"type":"TypeAPL","priorityList":[
+		{"action":{"condition":{"cmp":{"op":"OpGt","lhs":{"currentManaPercent":{"sourceUnit":{"type":"Owner"}}},"rhs":{"const":{"val":"200%"}}}},"castSpell":{"spellId":{"spellId":7802,"rank":5}}}}
+	]}`), "Imp")
+
+	if len(casts) != 0 {
+		t.Errorf("Expected the imp's APL to replace its built-in rotation, got %d casts starting with %s at %s", len(casts), casts[0].ActionID, casts[0].Time)
+	}
+}
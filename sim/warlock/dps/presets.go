@@ -0,0 +1,143 @@
+package dps
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p1_destruction",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       1,
+		Level:       25,
+		GearSet:     "p1/destruction",
+		Rotation:    "p1/destruction",
+		Talents:     "DestroP1Talents",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+	{
+		Name:        "p2_affliction",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2/shadow",
+		Rotation:    "p2/affliction",
+		Talents:     "AfflictionTalentsPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultAfflictionWarlock,
+	},
+	{
+		Name:        "p2_demonology",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2/fire.succubus",
+		Rotation:    "p2/demonology",
+		Talents:     "DemonologyTalentsPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDemonologyWarlock,
+	},
+	{
+		Name:        "p2_destruction",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2/fire.imp",
+		Rotation:    "p2/fire.imp",
+		Talents:     "DestroMgiTalentsPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+	{
+		Name:        "p3_nf_ruin",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "p3/nf.ruin",
+		Rotation:    "p3/nf.ruin",
+		Talents:     "NFRuinTalentsPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultAfflictionWarlock,
+	},
+	{
+		Name:        "p3_backdraft",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "p3/backdraft",
+		Rotation:    "p3/backdraft",
+		Talents:     "BackdraftTalentsPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+	{
+		Name:        "p4_affliction",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4/affliction",
+		Rotation:    "p4/affliction",
+		Talents:     "AffTalentsPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultAfflictionWarlock,
+	},
+	{
+		Name:        "p4_destruction",
+		Spec:        proto.Spec_SpecWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4/destruction",
+		Rotation:    "p4/destruction",
+		Talents:     "DestroTalentsPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+}
+
+var DefaultDestroWarlock = &proto.Player_Warlock{
+	Warlock: &proto.Warlock{
+		Options: &proto.WarlockOptions{
+			Armor:       proto.WarlockOptions_FelArmor,
+			Summon:      proto.WarlockOptions_Imp,
+			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
+		},
+	},
+}
+
+var DefaultAfflictionWarlock = &proto.Player_Warlock{
+	Warlock: &proto.Warlock{
+		Options: &proto.WarlockOptions{
+			Armor:       proto.WarlockOptions_FelArmor,
+			Summon:      proto.WarlockOptions_Imp,
+			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
+		},
+	},
+}
+
+var DefaultDemonologyWarlock = &proto.Player_Warlock{
+	Warlock: &proto.Warlock{
+		Options: &proto.WarlockOptions{
+			Armor:       proto.WarlockOptions_FelArmor,
+			Summon:      proto.WarlockOptions_Felguard,
+			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
+		},
+	},
+}
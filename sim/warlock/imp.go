@@ -69,12 +69,12 @@ func (warlock *Warlock) makeImp() *WarlockPet {
 }
 
 func (wp *WarlockPet) registerImpFireboltSpell() {
-	warlockLevel := wp.owner.Level
+	levels := [8]int{0, 1, 8, 18, 28, 38, 48, 58}
+	spellIds := [8]int32{0, 3110, 7799, 7800, 7801, 7802, 11762, 11763}
 	// assuming max rank available
-	rank := map[int32]int{25: 3, 40: 5, 50: 6, 60: 7}[warlockLevel]
-
-	if rank == 0 {
-		rank = 1
+	rank, ok := wp.GetSpellRankForLevel(levels[:], spellIds[:])
+	if !ok {
+		return
 	}
 
 	if wp.owner.Options.MaxFireboltRank != proto.WarlockOptions_NoMaximum {
@@ -83,9 +83,9 @@ func (wp *WarlockPet) registerImpFireboltSpell() {
 
 	spellCoeff := [8]float64{0, .164, .314, .529, .571, .571, .571, .571}[rank]
 	baseDamage := [8][]float64{{0, 0}, {7, 10}, {14, 16}, {25, 29}, {36, 41}, {52, 59}, {72, 80}, {85, 96}}[rank]
-	spellId := [8]int32{0, 3110, 7799, 7800, 7801, 7802, 11762, 11763}[rank]
+	spellId := spellIds[rank]
 	manaCost := [8]float64{0, 10, 20, 35, 50, 70, 95, 115}[rank]
-	level := [8]int{0, 1, 8, 18, 28, 38, 48, 58}[rank]
+	level := levels[rank]
 
 	improvedImp := []float64{1, 1.1, 1.2, 1.3}[wp.owner.Talents.ImprovedImp]
 	baseDamage[0] *= improvedImp
@@ -24,12 +24,17 @@ func (warlock *Warlock) getRainOfFireBaseConfig(rank int) core.SpellConfig {
 		flags |= core.SpellFlagChanneled
 	}
 
+	// Rain of Fire stays where it was cast, even if the warlock or target moves.
+	var center core.Vector2
+
 	config := core.SpellConfig{
 		ActionID:      core.ActionID{SpellID: spellId},
 		SpellSchool:   core.SpellSchoolFire,
 		DefenseType:   core.DefenseTypeMagic,
 		ProcMask:      core.ProcMaskSpellDamage,
 		Flags:         flags,
+		MaxRange:      30,
+		Aoe:           core.AoeConfig{Radius: 8, CenteredOnTarget: true},
 		RequiredLevel: level,
 		Rank:          rank,
 
@@ -57,7 +62,7 @@ func (warlock *Warlock) getRainOfFireBaseConfig(rank int) core.SpellConfig {
 				dot.Snapshot(target, baseDamage, isRollover)
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range dot.Spell.AoeTargetsAt(center) {
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
 
@@ -65,6 +70,7 @@ func (warlock *Warlock) getRainOfFireBaseConfig(rank int) core.SpellConfig {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			center = spell.AoeCenter(target)
 			spell.AOEDot().Apply(sim)
 		},
 	}
@@ -459,7 +459,7 @@ func (warlock *Warlock) applyDemonicPact() {
 		return
 	}
 
-	if warlock.Options.Summon == proto.WarlockOptions_NoSummon {
+	if warlock.Options.Summon == proto.WarlockOptions_NoSummon || warlock.ProvidedBuffsSuppressed() {
 		return
 	}
 
@@ -470,7 +470,7 @@ func (warlock *Warlock) applyDemonicPact() {
 
 	spellPower := max(warlock.getHighestSP()*0.1, float64(warlock.Level)/2.0)
 	demonicPactAuras := warlock.NewRaidAuraArray(func(u *core.Unit) *core.Aura {
-		return core.DemonicPactAura(u, spellPower, core.CharacterBuildPhaseNone)
+		return core.DemonicPactAura(u, spellPower, core.CharacterBuildPhaseNone, warlock.Label)
 	})
 
 	dpTriggerConfig := core.Aura{
@@ -102,19 +102,19 @@ func (warlock *Warlock) makeSuccubus() *WarlockPet {
 }
 
 func (wp *WarlockPet) registerSuccubusLashOfPainSpell() {
-	warlockLevel := wp.owner.Level
+	levels := [7]int{0, 20, 28, 36, 44, 52, 60}
+	spellIds := [7]int32{0, 7814, 7815, 7816, 11778, 11779, 11780}
 	// assuming max rank available
-	rank := map[int32]int{25: 1, 40: 3, 50: 4, 60: 6}[warlockLevel]
-
-	if rank == 0 {
-		rank = 1
+	rank, ok := wp.GetSpellRankForLevel(levels[:], spellIds[:])
+	if !ok {
+		return
 	}
 
 	spellCoeff := [7]float64{0, .429, .429, .429, .429, .429, .429}[rank]
 	baseDamage := [7]float64{0, 33, 44, 60, 73, 87, 99}[rank] * (1 + .10*float64(wp.owner.Talents.ImprovedSayaad))
-	spellId := [7]int32{0, 7814, 7815, 7816, 11778, 11779, 11780}[rank]
+	spellId := spellIds[rank]
 	manaCost := [7]float64{0, 65, 80, 105, 125, 145, 160}[rank]
-	level := [7]int{0, 20, 28, 36, 44, 52, 60}[rank]
+	level := levels[rank]
 
 	wp.primaryAbility = wp.RegisterSpell(core.SpellConfig{
 		ActionID:      core.ActionID{SpellID: spellId},
@@ -0,0 +1,143 @@
+package tank
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "p1_affliction",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       1,
+		Level:       25,
+		GearSet:     "p1.affi.tank",
+		Rotation:    "p1.affi.tank",
+		Talents:     "TalentsAfflictionTankPhase1",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultAfflictionWarlock,
+	},
+	{
+		Name:        "p1_destruction",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       1,
+		Level:       25,
+		GearSet:     "p1.destro.tank",
+		Rotation:    "p1.destro.tank",
+		Talents:     "TalentsDestructionTankPhase1",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+	{
+		Name:        "p2_demonology",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2.demo.tank",
+		Rotation:    "p2.demo.tank",
+		Talents:     "TalentsDemonologyTankPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDemonologyWarlock,
+	},
+	{
+		Name:        "p2_destruction",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "p2.destro.tank",
+		Rotation:    "p2.destro.tank",
+		Talents:     "TalentsDestructionTankPhase2",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+	{
+		Name:        "p3_destruction",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "p3.destro.tank",
+		Rotation:    "p3.destro.tank",
+		Talents:     "TalentsTankPhase3",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+	{
+		Name:        "p4_affliction",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_destro_aff_tank",
+		Rotation:    "p4_destro_aff_tank",
+		Talents:     "TalentsAffTankPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultAfflictionWarlock,
+	},
+	{
+		Name:        "p4_demonology",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_demo_tank",
+		Rotation:    "p4_demo_tank",
+		Talents:     "TalentsDemoTankPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDemonologyWarlock,
+	},
+	{
+		Name:        "p4_destruction",
+		Spec:        proto.Spec_SpecTankWarlock,
+		Class:       proto.Class_ClassWarlock,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "p4_destro_aff_tank",
+		Rotation:    "p4_destro_aff_tank",
+		Talents:     "TalentsDestroTankPhase4",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: DefaultDestroWarlock,
+	},
+}
+
+var DefaultDestroWarlock = &proto.Player_TankWarlock{
+	TankWarlock: &proto.TankWarlock{
+		Options: &proto.WarlockOptions{
+			Armor:       proto.WarlockOptions_FelArmor,
+			Summon:      proto.WarlockOptions_Imp,
+			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
+		},
+	},
+}
+
+var DefaultAfflictionWarlock = &proto.Player_TankWarlock{
+	TankWarlock: &proto.TankWarlock{
+		Options: &proto.WarlockOptions{
+			Armor:       proto.WarlockOptions_FelArmor,
+			Summon:      proto.WarlockOptions_Imp,
+			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
+		},
+	},
+}
+
+var DefaultDemonologyWarlock = &proto.Player_TankWarlock{
+	TankWarlock: &proto.TankWarlock{
+		Options: &proto.WarlockOptions{
+			Armor:       proto.WarlockOptions_FelArmor,
+			Summon:      proto.WarlockOptions_Felguard,
+			WeaponImbue: proto.WarlockOptions_Firestone,
+		},
+	},
+}
@@ -21,6 +21,7 @@ func RegisterTankWarlock() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type TankWarlock struct {
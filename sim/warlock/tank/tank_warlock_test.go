@@ -167,36 +167,6 @@ var Phase4AffTalents = "5500253011201002-03-50502051002001"
 var Phase4DemoTalents = "-205004015250105-50500050005001"
 var Phase4DestroTalents = "45002400102-03-505020510050115"
 
-var DefaultDestroWarlock = &proto.Player_TankWarlock{
-	TankWarlock: &proto.TankWarlock{
-		Options: &proto.WarlockOptions{
-			Armor:       proto.WarlockOptions_FelArmor,
-			Summon:      proto.WarlockOptions_Imp,
-			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
-		},
-	},
-}
-
-var DefaultAfflictionWarlock = &proto.Player_TankWarlock{
-	TankWarlock: &proto.TankWarlock{
-		Options: &proto.WarlockOptions{
-			Armor:       proto.WarlockOptions_FelArmor,
-			Summon:      proto.WarlockOptions_Imp,
-			WeaponImbue: proto.WarlockOptions_NoWeaponImbue,
-		},
-	},
-}
-
-var DefaultDemonologyWarlock = &proto.Player_TankWarlock{
-	TankWarlock: &proto.TankWarlock{
-		Options: &proto.WarlockOptions{
-			Armor:       proto.WarlockOptions_FelArmor,
-			Summon:      proto.WarlockOptions_Felguard,
-			WeaponImbue: proto.WarlockOptions_Firestone,
-		},
-	},
-}
-
 var Phase1Consumes = core.ConsumesCombo{
 	Label: "Phase 1 Consumes",
 	Consumes: &proto.Consumes{
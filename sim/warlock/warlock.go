@@ -213,6 +213,15 @@ func NewWarlock(character *core.Character, options *proto.Player, warlockOptions
 		warlock.applyFelArmor()
 	}
 
+	if warlock.HasRune(proto.WarlockRune_RuneLegsDemonicPact) && warlock.Options.Summon != proto.WarlockOptions_NoSummon {
+		warlock.RegisterProvidedBuff(core.ProvidedBuffConfig{
+			Label: "Demonic Pact",
+			ClearRaidBuffs: func(raidBuffs *proto.RaidBuffs) {
+				raidBuffs.DemonicPact = 0
+			},
+		})
+	}
+
 	warlock.registerPets()
 	warlock.setDefaultActivePet()
 
@@ -17,6 +17,7 @@ func (warrior *Warrior) registerDemoralizingShoutSpell() {
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskEmpty,
 		Flags:       core.SpellFlagAPL,
+		Aoe:         core.AoeConfig{Radius: 10},
 
 		RageCost: core.RageCostOptions{
 			Cost: 10 - warrior.FocusedRageDiscount,
@@ -32,7 +33,7 @@ func (warrior *Warrior) registerDemoralizingShoutSpell() {
 		FlatThreatBonus:  0.4 * 2 * float64(core.DemoralizingShoutLevel[rank]),
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				result := spell.CalcAndDealOutcome(sim, aoeTarget, spell.OutcomeMagicHit)
 				if result.Landed() {
 					warrior.DemoralizingShoutAuras.Get(aoeTarget).Activate(sim)
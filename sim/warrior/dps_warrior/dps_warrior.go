@@ -21,6 +21,7 @@ func RegisterDpsWarrior() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type DpsWarrior struct {
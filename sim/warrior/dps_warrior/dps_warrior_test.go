@@ -127,23 +127,6 @@ var Phase4Consumes = core.ConsumesCombo{
 	},
 }
 
-var PlayerOptionsArms = &proto.Player_Warrior{
-	Warrior: &proto.Warrior{
-		Options: warriorOptions,
-	},
-}
-
-var PlayerOptionsFury = &proto.Player_Warrior{
-	Warrior: &proto.Warrior{
-		Options: warriorOptions,
-	},
-}
-
-var warriorOptions = &proto.Warrior_Options{
-	StartingRage: 50,
-	Shout:        proto.WarriorShout_WarriorShoutBattle,
-}
-
 var ItemFilters = core.ItemFilter{
 	ArmorType: proto.ArmorType_ArmorTypePlate,
 
@@ -0,0 +1,65 @@
+package dpswarrior
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "phase_2_fury",
+		Spec:        proto.Spec_SpecWarrior,
+		Class:       proto.Class_ClassWarrior,
+		Race:        proto.Race_RaceOrc,
+		Phase:       2,
+		Level:       40,
+		GearSet:     "phase_2_dw",
+		Rotation:    "phase_2_fury",
+		Talents:     "TalentsPhase2Fury",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsFury,
+	},
+	{
+		Name:        "phase_3_arms",
+		Spec:        proto.Spec_SpecWarrior,
+		Class:       proto.Class_ClassWarrior,
+		Race:        proto.Race_RaceOrc,
+		Phase:       3,
+		Level:       50,
+		GearSet:     "phase_3_2h",
+		Rotation:    "phase_3_arms",
+		Talents:     "TalentsPhase3Arms",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsArms,
+	},
+	{
+		Name:        "phase_4_fury",
+		Spec:        proto.Spec_SpecWarrior,
+		Class:       proto.Class_ClassWarrior,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "phase_4_dw",
+		Rotation:    "phase_4_fury",
+		Talents:     "TalentsPhase4Fury",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsFury,
+	},
+}
+
+var PlayerOptionsArms = &proto.Player_Warrior{
+	Warrior: &proto.Warrior{
+		Options: warriorOptions,
+	},
+}
+
+var PlayerOptionsFury = &proto.Player_Warrior{
+	Warrior: &proto.Warrior{
+		Options: warriorOptions,
+	},
+}
+
+var warriorOptions = &proto.Warrior_Options{
+	StartingRage: 50,
+	Shout:        proto.WarriorShout_WarriorShoutBattle,
+}
@@ -5,29 +5,20 @@ import (
 )
 
 func (warrior *Warrior) registerHeroicStrikeSpell() {
-	flatDamageBonus := map[int32]float64{
-		25: 44,
-		40: 80,
-		50: 111,
-		// TODO: AQ
-		60: 138,
-		// 60: 157
-	}[warrior.Level]
-
-	spellID := map[int32]int32{
-		25: 1608,
-		40: 11565,
-		50: 11566,
-		60: 11567,
-	}[warrior.Level]
-
-	// No known equation
-	threat := map[int32]float64{
-		25: 68,  //guess
-		40: 103, //guess
-		50: 120,
-		60: 173,
-	}[warrior.Level]
+	// TODO: AQ rank 25286 with 157 damage
+	levels := [9]int{0, 1, 8, 16, 24, 32, 40, 48, 56}
+	spellIDs := [9]int32{0, 78, 284, 285, 1608, 11564, 11565, 11566, 11567}
+	rank, ok := warrior.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	flatDamageBonus := [9]float64{0, 11, 21, 32, 44, 58, 80, 111, 138}[rank]
+
+	spellID := spellIDs[rank]
+
+	// No known equation, all but the last two ranks are guesses
+	threat := [9]float64{0, 20, 36, 52, 68, 86, 103, 120, 173}[rank]
 
 	warrior.HeroicStrike = warrior.RegisterSpell(AnyStance, core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: spellID},
@@ -66,26 +57,19 @@ func (warrior *Warrior) registerHeroicStrikeSpell() {
 }
 
 func (warrior *Warrior) registerCleaveSpell() {
-	flatDamageBonus := map[int32]float64{
-		25: 5,
-		40: 18,
-		50: 32,
-		60: 50,
-	}[warrior.Level]
-
-	spellID := map[int32]int32{
-		25: 845,
-		40: 11608,
-		50: 11609,
-		60: 20569,
-	}[warrior.Level]
-
-	threat := map[int32]float64{
-		25: 20, //guess
-		40: 60, //guess
-		50: 80,
-		60: 100,
-	}[warrior.Level]
+	levels := [6]int{0, 20, 30, 40, 50, 60}
+	spellIDs := [6]int32{0, 845, 7369, 11608, 11609, 20569}
+	rank, ok := warrior.GetSpellRankForLevel(levels[:], spellIDs[:])
+	if !ok {
+		return
+	}
+
+	flatDamageBonus := [6]float64{0, 5, 10, 18, 32, 50}[rank]
+
+	spellID := spellIDs[rank]
+
+	// The first three ranks are guesses
+	threat := [6]float64{0, 20, 40, 60, 80, 100}[rank]
 
 	flatDamageBonus *= []float64{1, 1.4, 1.8, 2.2}[warrior.Talents.ImprovedCleave]
 
@@ -97,6 +81,7 @@ func (warrior *Warrior) registerCleaveSpell() {
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeMHAuto,
 		Flags:       core.SpellFlagMeleeMetrics,
+		Aoe:         core.AoeConfig{Radius: 8, ConeAngle: 180},
 
 		RageCost: core.RageCostOptions{
 			Cost: 20 - warrior.FocusedRageDiscount,
@@ -110,13 +95,20 @@ func (warrior *Warrior) registerCleaveSpell() {
 		BonusCoefficient: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx := range results {
-				baseDamage := flatDamageBonus + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
-				results[idx] = spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
-				target = sim.Environment.NextTargetUnit(target)
+			// The primary target is always hit, and then the next target in front of the warrior.
+			center := spell.AoeCenter(target)
+			numHits := 0
+			curTarget := target
+			for i := int32(0); i < sim.Environment.GetNumTargets() && numHits < len(results); i++ {
+				if curTarget == target || spell.InAoe(center, curTarget) {
+					baseDamage := flatDamageBonus + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
+					results[numHits] = spell.CalcDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
+					numHits++
+				}
+				curTarget = sim.Environment.NextTargetUnit(curTarget)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:numHits] {
 				spell.DealDamage(sim, result)
 			}
 
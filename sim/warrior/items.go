@@ -56,6 +56,7 @@ func init() {
 			SpellSchool: core.SpellSchoolPhysical | core.SpellSchoolShadow,
 			ProcMask:    core.ProcMaskSpellDamage,
 			Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagOffensiveEquipment,
+			Aoe:         core.AoeConfig{Radius: 8},
 
 			Cast: core.CastConfig{
 				CD: core.Cooldown{
@@ -83,7 +84,7 @@ func init() {
 			},
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range spell.AoeTargets(target) {
 					// Has no DefenseType, also haven't seen a miss in logs.
 					result := spell.CalcAndDealDamage(sim, aoeTarget, 65, spell.OutcomeAlwaysHit)
 					if result.Landed() {
@@ -20,6 +20,7 @@ func (warrior *Warrior) registerShockwaveSpell() {
 		DefenseType: core.DefenseTypeRanged,
 		ProcMask:    core.ProcMaskRangedSpecial,
 		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		Aoe:         core.AoeConfig{Radius: 10, ConeAngle: 60},
 
 		RageCost: core.RageCostOptions{
 			Cost: 15 - warrior.FocusedRageDiscount,
@@ -41,7 +42,7 @@ func (warrior *Warrior) registerShockwaveSpell() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := apCoef * spell.MeleeAttackPower()
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range spell.AoeTargets(target) {
 				// Shockwave can miss and be blocked, but it can't be dodged or parried
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMeleeSpecialNoDodgeParry)
 			}
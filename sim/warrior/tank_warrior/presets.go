@@ -0,0 +1,33 @@
+package tankwarrior
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+var Presets = []*core.SpecPreset{
+	{
+		Name:        "phase_4",
+		Spec:        proto.Spec_SpecTankWarrior,
+		Class:       proto.Class_ClassWarrior,
+		Race:        proto.Race_RaceOrc,
+		Phase:       4,
+		Level:       60,
+		GearSet:     "phase_4_tanky",
+		Rotation:    "phase_4",
+		Talents:     "TalentsPhase4Prot",
+		Consumes:    "DefaultConsumes",
+		SpecOptions: PlayerOptionsBasic,
+	},
+}
+
+var PlayerOptionsBasic = &proto.Player_TankWarrior{
+	TankWarrior: &proto.TankWarrior{
+		Options: warriorOptions,
+	},
+}
+
+var warriorOptions = &proto.TankWarrior_Options{
+	Shout:        proto.WarriorShout_WarriorShoutCommanding,
+	StartingRage: 0,
+}
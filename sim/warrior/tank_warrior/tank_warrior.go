@@ -21,6 +21,7 @@ func RegisterTankWarrior() {
 			player.Spec = playerSpec
 		},
 	)
+	core.RegisterSpecPresets(Presets...)
 }
 
 type TankWarrior struct {
@@ -36,17 +36,6 @@ func TestTankWarrior(t *testing.T) {
 
 var P4Talents = "20304300302-03-55200110530201051"
 
-var PlayerOptionsBasic = &proto.Player_TankWarrior{
-	TankWarrior: &proto.TankWarrior{
-		Options: warriorOptions,
-	},
-}
-
-var warriorOptions = &proto.TankWarrior_Options{
-	Shout:        proto.WarriorShout_WarriorShoutCommanding,
-	StartingRage: 0,
-}
-
 var Phase4Consumes = core.ConsumesCombo{
 	Label: "Phase 4 Consumes",
 	Consumes: &proto.Consumes{
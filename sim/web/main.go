@@ -25,6 +25,7 @@ import (
 	"github.com/wowsims/sod/sim"
 	"github.com/wowsims/sod/sim/core"
 	proto "github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/ui"
 
 	googleProto "google.golang.org/protobuf/proto"
 )
@@ -48,9 +49,22 @@ func main() {
 	var host = flag.String("host", "localhost:3333", "URL to host the interface on.")
 	var launch = flag.Bool("launch", true, "auto launch browser")
 	var skipVersionCheck = flag.Bool("nvc", false, "set true to skip version check")
+	var itemEffectsFile = flag.String("itemeffects", "", "File with extra item effect definitions (ItemEffectDefinitions in protojson format)")
 
 	flag.Parse()
 
+	core.PresetFiles = ui.PresetFiles
+
+	if *itemEffectsFile != "" {
+		data, err := os.ReadFile(*itemEffectsFile)
+		if err != nil {
+			log.Fatalf("Failed to read item effects file: %s", err)
+		}
+		if err := core.LoadItemEffectDefinitions(data); err != nil {
+			log.Fatalf("Failed to load item effects: %s", err)
+		}
+	}
+
 	fmt.Printf("Version: %s\n", Version)
 	if !*skipVersionCheck && Version != "development" {
 		go func() {
@@ -100,6 +114,24 @@ var handlers = map[string]apiHandler{
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
+	"/itemEffectCoverage": {msg: func() googleProto.Message { return &proto.ItemEffectCoverageRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ItemEffectCoverage(msg.(*proto.ItemEffectCoverageRequest))
+	}},
+	"/presets": {msg: func() googleProto.Message { return &proto.SpecPresetsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.SpecPresets(msg.(*proto.SpecPresetsRequest))
+	}},
+	"/raidOptimizer": {msg: func() googleProto.Message { return &proto.RaidOptimizerRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunRaidOptimizer(msg.(*proto.RaidOptimizerRequest))
+	}},
+	"/cooldownTimings": {msg: func() googleProto.Message { return &proto.CooldownTimingsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunCooldownTimings(msg.(*proto.CooldownTimingsRequest))
+	}},
+	"/statScaling": {msg: func() googleProto.Message { return &proto.StatScalingRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunStatScaling(msg.(*proto.StatScalingRequest))
+	}},
+	"/itemRankings": {msg: func() googleProto.Message { return &proto.ItemRankingsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunItemRankings(msg.(*proto.ItemRankingsRequest))
+	}},
 }
 
 var asyncAPIHandlers = map[string]asyncAPIHandler{
@@ -114,6 +146,18 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 		// We should have all the async APIs take in context and let it be cancelled via its async ID.
 		core.RunBulkSimAsync(context.Background(), msg.(*proto.BulkSimRequest), reporter)
 	}},
+	"/raidOptimizerAsync": {msg: func() googleProto.Message { return &proto.RaidOptimizerRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.RunRaidOptimizerAsync(context.Background(), msg.(*proto.RaidOptimizerRequest), reporter)
+	}},
+	"/cooldownTimingsAsync": {msg: func() googleProto.Message { return &proto.CooldownTimingsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.RunCooldownTimingsAsync(context.Background(), msg.(*proto.CooldownTimingsRequest), reporter)
+	}},
+	"/statScalingAsync": {msg: func() googleProto.Message { return &proto.StatScalingRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.RunStatScalingAsync(context.Background(), msg.(*proto.StatScalingRequest), reporter)
+	}},
+	"/itemRankingsAsync": {msg: func() googleProto.Message { return &proto.ItemRankingsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.RunItemRankingsAsync(context.Background(), msg.(*proto.ItemRankingsRequest), reporter)
+	}},
 }
 
 type server struct {
@@ -194,7 +238,7 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalRaidOptimizerResult != nil || progMetric.FinalCooldownTimingsResult != nil || progMetric.FinalStatScalingResult != nil || progMetric.FinalItemRankingsResult != nil {
 					return
 				}
 			}
@@ -252,7 +296,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
+		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil || latest.FinalRaidOptimizerResult != nil || latest.FinalCooldownTimingsResult != nil || latest.FinalStatScalingResult != nil || latest.FinalItemRankingsResult != nil {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
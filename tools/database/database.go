@@ -310,6 +310,7 @@ func ReadDatabaseFromJson(jsonStr string) *WowDatabase {
 		Items:          sliceToMap(dbProto.Items),
 		RandomSuffixes: sliceToMap(dbProto.RandomSuffixes),
 		Enchants:       enchants,
+		Runes:          sliceToMap(dbProto.Runes),
 		Zones:          sliceToMap(dbProto.Zones),
 		Npcs:           sliceToMap(dbProto.Npcs),
 		Factions:       sliceToMap(dbProto.Factions),
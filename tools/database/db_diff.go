@@ -0,0 +1,333 @@
+package database
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+type DiffKind int
+
+const (
+	DiffAdded DiffKind = iota
+	DiffRemoved
+	DiffChanged
+)
+
+func (kind DiffKind) symbol() string {
+	switch kind {
+	case DiffAdded:
+		return "+"
+	case DiffRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// A single added, removed or changed database entry.
+type EntryDiff struct {
+	Kind  DiffKind
+	ID    int32
+	Name  string
+	Phase int32
+
+	// Human-readable description of each changed field, only for DiffChanged.
+	Changes []string
+}
+
+type CategoryDiff struct {
+	Name    string
+	Entries []EntryDiff
+}
+
+// Differences between two versions of the database, e.g. before and after a
+// patch or hotfix.
+type DatabaseDiff struct {
+	Categories []CategoryDiff
+
+	// Changed or removed entries which have effects implemented in the sim, and
+	// probably need to be re-implemented.
+	SimEffects []CategoryDiff
+}
+
+func DiffDatabases(oldDB, newDB *WowDatabase) *DatabaseDiff {
+	items := diffEntries(oldDB.Items, newDB.Items, func(item *proto.UIItem) (int32, string, int32) {
+		return item.Id, item.Name, item.Phase
+	}, diffItems)
+	randomSuffixes := diffEntries(oldDB.RandomSuffixes, newDB.RandomSuffixes, func(suffix *proto.ItemRandomSuffix) (int32, string, int32) {
+		return suffix.Id, suffix.Name, 0
+	}, diffRandomSuffixes)
+	enchants := diffEntries(oldDB.Enchants, newDB.Enchants, func(enchant *proto.UIEnchant) (int32, string, int32) {
+		return enchant.EffectId, enchant.Name, enchant.Phase
+	}, diffEnchants)
+	runes := diffEntries(oldDB.Runes, newDB.Runes, func(rune *proto.UIRune) (int32, string, int32) {
+		return rune.Id, rune.Name, 0
+	}, diffRunes)
+	itemSets := diffEntries(collectItemSets(oldDB), collectItemSets(newDB), func(set *itemSetInfo) (int32, string, int32) {
+		return set.id, set.name, set.phase
+	}, diffItemSets)
+
+	return &DatabaseDiff{
+		Categories: []CategoryDiff{
+			{Name: "Items", Entries: items},
+			{Name: "Random Suffixes", Entries: randomSuffixes},
+			{Name: "Enchants", Entries: enchants},
+			{Name: "Runes", Entries: runes},
+			{Name: "Item Sets", Entries: itemSets},
+		},
+		SimEffects: []CategoryDiff{
+			{Name: "Item Effects", Entries: filterSimEffects(items, func(entry EntryDiff) bool {
+				return core.HasItemEffect(entry.ID)
+			})},
+			{Name: "Enchant Effects", Entries: filterSimEffects(enchants, func(entry EntryDiff) bool {
+				// Weapon effects, e.g. Crusader, are registered by enchant effect ID too.
+				return core.HasEnchantEffect(entry.ID) || core.HasWeaponEffect(entry.ID)
+			})},
+			{Name: "Set Bonuses", Entries: filterSimEffects(itemSets, func(entry EntryDiff) bool {
+				return core.HasItemSet(entry.ID, entry.Name)
+			})},
+		},
+	}
+}
+
+func diffEntries[K comparable, T any](oldEntries, newEntries map[K]T, describe func(T) (int32, string, int32), compare func(oldEntry, newEntry T) []string) []EntryDiff {
+	var diffs []EntryDiff
+
+	for key, oldEntry := range oldEntries {
+		newEntry, ok := newEntries[key]
+		if !ok {
+			id, name, phase := describe(oldEntry)
+			diffs = append(diffs, EntryDiff{Kind: DiffRemoved, ID: id, Name: name, Phase: phase})
+			continue
+		}
+		if changes := compare(oldEntry, newEntry); len(changes) > 0 {
+			id, name, phase := describe(newEntry)
+			diffs = append(diffs, EntryDiff{Kind: DiffChanged, ID: id, Name: name, Phase: phase, Changes: changes})
+		}
+	}
+	for key, newEntry := range newEntries {
+		if _, ok := oldEntries[key]; !ok {
+			id, name, phase := describe(newEntry)
+			diffs = append(diffs, EntryDiff{Kind: DiffAdded, ID: id, Name: name, Phase: phase})
+		}
+	}
+
+	// Sort so the output is stable, and grouped by phase.
+	slices.SortFunc(diffs, func(a, b EntryDiff) int {
+		if a.Phase != b.Phase {
+			return cmp.Compare(a.Phase, b.Phase)
+		}
+		if a.Kind != b.Kind {
+			return cmp.Compare(a.Kind, b.Kind)
+		}
+		if a.ID != b.ID {
+			return cmp.Compare(a.ID, b.ID)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return diffs
+}
+
+func filterSimEffects(diffs []EntryDiff, hasEffect func(EntryDiff) bool) []EntryDiff {
+	var filtered []EntryDiff
+	for _, entry := range diffs {
+		if entry.Kind != DiffAdded && hasEffect(entry) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// Accumulates human-readable descriptions of changed fields.
+type fieldChanges []string
+
+func (changes *fieldChanges) add(name string, oldVal, newVal any) {
+	if oldVal != newVal {
+		*changes = append(*changes, fmt.Sprintf("%s: %v -> %v", name, oldVal, newVal))
+	}
+}
+
+func addSlice[T comparable](changes *fieldChanges, name string, oldVals, newVals []T) {
+	if !slices.Equal(oldVals, newVals) {
+		*changes = append(*changes, fmt.Sprintf("%s: %v -> %v", name, oldVals, newVals))
+	}
+}
+
+// Compares arrays indexed by an enum, e.g. stats, one value at a time.
+func (changes *fieldChanges) addIndexed(oldVals, newVals []float64, indexName func(int) string) {
+	for i := 0; i < max(len(oldVals), len(newVals)); i++ {
+		var oldVal, newVal float64
+		if i < len(oldVals) {
+			oldVal = oldVals[i]
+		}
+		if i < len(newVals) {
+			newVal = newVals[i]
+		}
+		changes.add(indexName(i), oldVal, newVal)
+	}
+}
+
+func statName(i int) string {
+	return proto.Stat(i).String()
+}
+
+func weaponSkillName(i int) string {
+	return proto.WeaponSkill(i).String()
+}
+
+func diffItems(oldItem, newItem *proto.UIItem) []string {
+	var changes fieldChanges
+	changes.add("name", oldItem.Name, newItem.Name)
+	changes.add("type", oldItem.Type, newItem.Type)
+	changes.add("armor_type", oldItem.ArmorType, newItem.ArmorType)
+	changes.add("weapon_type", oldItem.WeaponType, newItem.WeaponType)
+	changes.add("hand_type", oldItem.HandType, newItem.HandType)
+	changes.add("ranged_weapon_type", oldItem.RangedWeaponType, newItem.RangedWeaponType)
+	changes.add("requires_level", oldItem.RequiresLevel, newItem.RequiresLevel)
+	changes.addIndexed(oldItem.Stats, newItem.Stats, statName)
+	addSlice(&changes, "random_suffix_options", oldItem.RandomSuffixOptions, newItem.RandomSuffixOptions)
+	changes.add("weapon_damage_min", oldItem.WeaponDamageMin, newItem.WeaponDamageMin)
+	changes.add("weapon_damage_max", oldItem.WeaponDamageMax, newItem.WeaponDamageMax)
+	changes.add("weapon_speed", oldItem.WeaponSpeed, newItem.WeaponSpeed)
+	changes.addIndexed(oldItem.WeaponSkills, newItem.WeaponSkills, weaponSkillName)
+	changes.add("ilvl", oldItem.Ilvl, newItem.Ilvl)
+	changes.add("phase", oldItem.Phase, newItem.Phase)
+	changes.add("quality", oldItem.Quality, newItem.Quality)
+	changes.add("unique", oldItem.Unique, newItem.Unique)
+	addSlice(&changes, "class_allowlist", oldItem.ClassAllowlist, newItem.ClassAllowlist)
+	changes.add("required_profession", oldItem.RequiredProfession, newItem.RequiredProfession)
+	changes.add("set_name", oldItem.SetName, newItem.SetName)
+	changes.add("set_id", oldItem.SetId, newItem.SetId)
+	changes.add("faction_restriction", oldItem.FactionRestriction, newItem.FactionRestriction)
+	addSlice(&changes, "effect_types", oldItem.EffectTypes, newItem.EffectTypes)
+	return changes
+}
+
+func diffRandomSuffixes(oldSuffix, newSuffix *proto.ItemRandomSuffix) []string {
+	var changes fieldChanges
+	changes.add("name", oldSuffix.Name, newSuffix.Name)
+	changes.addIndexed(oldSuffix.Stats, newSuffix.Stats, statName)
+	return changes
+}
+
+func diffEnchants(oldEnchant, newEnchant *proto.UIEnchant) []string {
+	var changes fieldChanges
+	changes.add("name", oldEnchant.Name, newEnchant.Name)
+	changes.add("type", oldEnchant.Type, newEnchant.Type)
+	addSlice(&changes, "extra_types", oldEnchant.ExtraTypes, newEnchant.ExtraTypes)
+	changes.add("enchant_type", oldEnchant.EnchantType, newEnchant.EnchantType)
+	changes.addIndexed(oldEnchant.Stats, newEnchant.Stats, statName)
+	changes.add("quality", oldEnchant.Quality, newEnchant.Quality)
+	changes.add("phase", oldEnchant.Phase, newEnchant.Phase)
+	addSlice(&changes, "class_allowlist", oldEnchant.ClassAllowlist, newEnchant.ClassAllowlist)
+	changes.add("required_profession", oldEnchant.RequiredProfession, newEnchant.RequiredProfession)
+	changes.add("requires_level", oldEnchant.RequiresLevel, newEnchant.RequiresLevel)
+	return changes
+}
+
+func diffRunes(oldRune, newRune *proto.UIRune) []string {
+	var changes fieldChanges
+	changes.add("name", oldRune.Name, newRune.Name)
+	changes.add("type", oldRune.Type, newRune.Type)
+	changes.add("requires_level", oldRune.RequiresLevel, newRune.RequiresLevel)
+	addSlice(&changes, "class_allowlist", oldRune.ClassAllowlist, newRune.ClassAllowlist)
+	return changes
+}
+
+// Item sets aren't stored directly in the database, so they're rebuilt from
+// the set names of their items.
+type itemSetInfo struct {
+	id      int32
+	name    string
+	phase   int32
+	itemIDs []int32
+}
+
+func collectItemSets(db *WowDatabase) map[string]*itemSetInfo {
+	itemSets := make(map[string]*itemSetInfo)
+	for _, item := range db.Items {
+		if item.SetName == "" {
+			continue
+		}
+
+		set, ok := itemSets[item.SetName]
+		if !ok {
+			set = &itemSetInfo{name: item.SetName, phase: item.Phase}
+			itemSets[item.SetName] = set
+		}
+		set.id = max(set.id, item.SetId)
+		set.phase = min(set.phase, item.Phase)
+		set.itemIDs = append(set.itemIDs, item.Id)
+	}
+
+	for _, set := range itemSets {
+		slices.Sort(set.itemIDs)
+	}
+	return itemSets
+}
+
+func diffItemSets(oldSet, newSet *itemSetInfo) []string {
+	var changes fieldChanges
+	changes.add("set_id", oldSet.id, newSet.id)
+	for _, id := range oldSet.itemIDs {
+		if !slices.Contains(newSet.itemIDs, id) {
+			changes = append(changes, fmt.Sprintf("removed item %d", id))
+		}
+	}
+	for _, id := range newSet.itemIDs {
+		if !slices.Contains(oldSet.itemIDs, id) {
+			changes = append(changes, fmt.Sprintf("added item %d", id))
+		}
+	}
+	return changes
+}
+
+func (categoryDiff CategoryDiff) writeTo(builder *strings.Builder) {
+	var numAdded, numRemoved, numChanged int
+	for _, entry := range categoryDiff.Entries {
+		switch entry.Kind {
+		case DiffAdded:
+			numAdded++
+		case DiffRemoved:
+			numRemoved++
+		case DiffChanged:
+			numChanged++
+		}
+	}
+	fmt.Fprintf(builder, "== %s (%d added, %d removed, %d changed) ==\n", categoryDiff.Name, numAdded, numRemoved, numChanged)
+
+	for i, entry := range categoryDiff.Entries {
+		if i == 0 || entry.Phase != categoryDiff.Entries[i-1].Phase {
+			if entry.Phase == 0 {
+				builder.WriteString("  No phase\n")
+			} else {
+				fmt.Fprintf(builder, "  Phase %d\n", entry.Phase)
+			}
+		}
+
+		fmt.Fprintf(builder, "    %s %d %s\n", entry.Kind.symbol(), entry.ID, entry.Name)
+		for _, change := range entry.Changes {
+			fmt.Fprintf(builder, "        %s\n", change)
+		}
+	}
+}
+
+func (diff *DatabaseDiff) String() string {
+	var builder strings.Builder
+	for _, categoryDiff := range diff.Categories {
+		categoryDiff.writeTo(&builder)
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("Changed or removed entries with sim effects, which may need to be re-implemented:\n\n")
+	for _, categoryDiff := range diff.SimEffects {
+		categoryDiff.writeTo(&builder)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
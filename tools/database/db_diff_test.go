@@ -0,0 +1,91 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const (
+	testEffectItemID    = 19019 // Thunderfury
+	testWeaponEnchantID = 1900  // Crusader
+	testStatsEnchantID  = 1     // No sim effect
+)
+
+func registerTestSimEffects() {
+	if !core.HasItemEffect(testEffectItemID) {
+		core.NewItemEffect(testEffectItemID, func(agent core.Agent) {})
+	}
+	if !core.HasWeaponEffect(testWeaponEnchantID) {
+		core.AddWeaponEffect(testWeaponEnchantID, func(agent core.Agent, slot proto.ItemSlot) {})
+	}
+}
+
+func newTestDiffDatabase(items []*proto.UIItem, enchants []*proto.UIEnchant) *WowDatabase {
+	db := NewWowDatabase()
+	for _, item := range items {
+		db.Items[item.Id] = item
+	}
+	for _, enchant := range enchants {
+		db.Enchants[EnchantToDBKey(enchant)] = enchant
+	}
+	return db
+}
+
+func entryIDs(entries []EntryDiff) []int32 {
+	var ids []int32
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+func TestDiffDatabases(t *testing.T) {
+	registerTestSimEffects()
+
+	oldDB := newTestDiffDatabase([]*proto.UIItem{
+		{Id: testEffectItemID, Name: "Thunderfury", Phase: 1, WeaponSpeed: 1.9},
+		{Id: 2, Name: "Removed Item", Phase: 1},
+		{Id: 3, Name: "Unchanged Item", Phase: 2},
+	}, []*proto.UIEnchant{
+		{EffectId: testWeaponEnchantID, Name: "Crusader", Phase: 1},
+		{EffectId: testStatsEnchantID, Name: "Stats Only", Phase: 1, Stats: []float64{1}},
+	})
+	newDB := newTestDiffDatabase([]*proto.UIItem{
+		{Id: testEffectItemID, Name: "Thunderfury", Phase: 1, WeaponSpeed: 2.0},
+		{Id: 3, Name: "Unchanged Item", Phase: 2},
+		{Id: 4, Name: "Added Item", Phase: 2},
+	}, []*proto.UIEnchant{
+		{EffectId: testWeaponEnchantID, Name: "Crusader", Phase: 2},
+		{EffectId: testStatsEnchantID, Name: "Stats Only", Phase: 1, Stats: []float64{2}},
+	})
+
+	diff := DiffDatabases(oldDB, newDB)
+
+	items := diff.Categories[0]
+	expectedKinds := map[int32]DiffKind{testEffectItemID: DiffChanged, 2: DiffRemoved, 4: DiffAdded}
+	if len(items.Entries) != len(expectedKinds) {
+		t.Fatalf("Expected %d item diffs, got %v", len(expectedKinds), entryIDs(items.Entries))
+	}
+	for _, entry := range items.Entries {
+		if kind, ok := expectedKinds[entry.ID]; !ok || kind != entry.Kind {
+			t.Errorf("Unexpected diff %s for item %d", entry.Kind.symbol(), entry.ID)
+		}
+		if entry.ID == testEffectItemID && !slices.Equal(entry.Changes, []string{"weapon_speed: 1.9 -> 2"}) {
+			t.Errorf("Unexpected changes for item %d: %v", entry.ID, entry.Changes)
+		}
+	}
+
+	if ids := entryIDs(diff.Categories[2].Entries); !slices.Equal(ids, []int32{testStatsEnchantID, testWeaponEnchantID}) {
+		t.Errorf("Expected both enchants to be changed, got %v", ids)
+	}
+
+	if ids := entryIDs(diff.SimEffects[0].Entries); !slices.Equal(ids, []int32{testEffectItemID}) {
+		t.Errorf("Expected only item %d to have a changed sim effect, got %v", testEffectItemID, ids)
+	}
+	if ids := entryIDs(diff.SimEffects[1].Entries); !slices.Equal(ids, []int32{testWeaponEnchantID}) {
+		t.Errorf("Expected only the weapon enchant to have a changed sim effect, got %v", ids)
+	}
+}
@@ -14,7 +14,13 @@ import (
 	"github.com/wowsims/sod/sim"
 	"github.com/wowsims/sod/sim/core"
 	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/druid"
 	_ "github.com/wowsims/sod/sim/encounters" // Needed for preset encounters.
+	"github.com/wowsims/sod/sim/hunter"
+	"github.com/wowsims/sod/sim/mage"
+	"github.com/wowsims/sod/sim/priest"
+	"github.com/wowsims/sod/sim/shaman"
+	"github.com/wowsims/sod/sim/warrior"
 	"github.com/wowsims/sod/tools"
 	"github.com/wowsims/sod/tools/database"
 )
@@ -25,17 +31,56 @@ import (
 // go run ./tools/database/gen_db -outDir=assets -gen=wowhead-spells -maxid=31000
 // go run ./tools/database/gen_db -outDir=assets -gen=wowhead-gearplannerdb
 // go run ./tools/database/gen_db -outDir=assets -gen=wago-db2-items
+// go run ./tools/database/gen_db -outDir=assets -gen=wago-db2-spells
 // python3 tools/scrape_runes.py assets/db_inputs/wowhead_rune_tooltips.csv
 
 // Lastly run the following to generate db.json (ensure to delete cached versions and/or rebuild for copying of assets during local development)
 // Note: This does not make network requests, only regenerates core db binary and json files from existing inputs
 // go run ./tools/database/gen_db -outDir=assets -gen=db
 
+// To regenerate sim/core/spell_data_auto_gen.go from the wago DB2 spell tables, and report
+// hardcoded spell values which disagree with them:
+// go run ./tools/database/gen_db -outDir=assets -gen=spelldata
+
+// To see what changed between two versions of the database, e.g. after a patch or hotfix:
+// go run ./tools/database/gen_db -gen=diff -old=path/to/old/db.json -new=assets/database/db.json
+
 var exactId = flag.Int("id", 0, "ID to scan for")
 var minId = flag.Int("minid", 1, "Minimum ID to scan for")
 var maxId = flag.Int("maxid", 31000, "Maximum ID to scan for")
 var outDir = flag.String("outDir", "assets", "Path to output directory for writing generated .go files.")
-var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
+var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'diff', 'spelldata', 'wago-db2-spells', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
+var oldDBPath = flag.String("old", "", "Path to the old db.json, for -gen=diff")
+var spellDataOut = flag.String("spellDataOut", "sim/core/spell_data_auto_gen.go", "Path to the generated spell data, for -gen=spelldata")
+var newDBPath = flag.String("new", "assets/database/db.json", "Path to the new db.json, for -gen=diff")
+
+// Level caps to validate spell data at, since most spells only register the
+// highest rank for the character's level.
+var spellDataValidationLevels = []int32{25, 40, 50, 60}
+
+// Hardcoded direct damage rank tables to validate against the spell data. Damage
+// over time is left out, since the sim stores it as totals rather than per tick.
+var spellDataRankedDamage = []database.RankedDamage{
+	{Name: "Arcane Explosion", SpellIDs: mage.ArcaneExplosionSpellId[:], Damage: mage.ArcaneExplosionBaseDamage[:]},
+	{Name: "Blast Wave", SpellIDs: mage.BlastWaveSpellId[:], Damage: mage.BlastWaveBaseDamage[:]},
+	{Name: "Fire Blast", SpellIDs: mage.FireBlastSpellId[:], Damage: mage.FireBlastBaseDamage[:]},
+	{Name: "Fireball", SpellIDs: mage.FireballSpellId[:], Damage: mage.FireballBaseDamage[:]},
+	{Name: "Flamestrike", SpellIDs: mage.FlamestrikeSpellId[:], Damage: mage.FlamestrikeBaseDamage[:]},
+	{Name: "Frostbolt", SpellIDs: mage.FrostboltSpellId[:], Damage: mage.FrostboltBaseDamage[:]},
+	{Name: "Pyroblast", SpellIDs: mage.PyroblastSpellId[:], Damage: mage.PyroblastBaseDamage[:]},
+	{Name: "Scorch", SpellIDs: mage.ScorchSpellId[:], Damage: mage.ScorchBaseDamage[:]},
+	{Name: "Holy Fire", SpellIDs: priest.HolyFireSpellId[:], Damage: priest.HolyFireBaseDamage[:]},
+	{Name: "Mind Blast", SpellIDs: priest.MindBlastSpellId[:], Damage: priest.MindBlastBaseDamage[:]},
+	{Name: "Smite", SpellIDs: priest.SmiteSpellId[:], Damage: priest.SmiteBaseDamage[:]},
+	{Name: "Moonfire", SpellIDs: druid.MoonfireSpellId[:], Damage: druid.MoonfireBaseDamage[:]},
+	{Name: "Starfire", SpellIDs: druid.StarfireSpellId[:], Damage: druid.StarfireBaseDamage[:]},
+	{Name: "Swipe", SpellIDs: druid.SwipeSpellId[:], Damage: database.FixedRankedDamage(druid.SwipeBaseDamage[:])},
+	{Name: "Wrath", SpellIDs: druid.WrathSpellId[:], Damage: druid.WrathBaseDamage[:]},
+	{Name: "Chain Lightning", SpellIDs: shaman.ChainLightningSpellId[:], Damage: shaman.ChainLightningBaseDamage[:]},
+	{Name: "Frost Shock", SpellIDs: shaman.FrostShockSpellId[:], Damage: shaman.FrostShockBaseDamage[:]},
+	{Name: "Raptor Strike", SpellIDs: hunter.RaptorStrikeSpellId[:], Damage: database.FixedRankedDamage(hunter.RaptorStrikeBaseDamage[:])},
+	{Name: "Revenge", SpellIDs: warrior.RevengeSpellId[:], Damage: warrior.RevengeBaseDamage[:]},
+}
 
 func main() {
 	flag.Parse()
@@ -52,7 +97,15 @@ func main() {
 	dbDir := fmt.Sprintf("%s/database", *outDir)
 	inputsDir := fmt.Sprintf("%s/db_inputs", *outDir)
 
-	if *genAsset == "atlasloot" {
+	if *genAsset == "diff" {
+		if *oldDBPath == "" {
+			panic("old flag is required for diff!")
+		}
+		oldDB := database.ReadDatabaseFromJson(tools.ReadFile(*oldDBPath))
+		newDB := database.ReadDatabaseFromJson(tools.ReadFile(*newDBPath))
+		fmt.Print(database.DiffDatabases(oldDB, newDB).String())
+		return
+	} else if *genAsset == "atlasloot" {
 		db := database.ReadAtlasLootData(inputsDir)
 		db.WriteJson(fmt.Sprintf("%s/atlasloot_db.json", inputsDir))
 		return
@@ -68,6 +121,39 @@ func main() {
 	} else if *genAsset == "wago-db2-items" {
 		tools.WriteFile(fmt.Sprintf("%s/wago_db2_items.csv", inputsDir), tools.ReadWebRequired("https://wago.tools/db2/ItemSparse/csv?build=1.15.3.55646"))
 		return
+	} else if *genAsset == "wago-db2-spells" {
+		for _, table := range database.WagoSpellTables {
+			tools.WriteFile(fmt.Sprintf("%s/wago_db2_%s.csv", inputsDir, table), tools.ReadWebRequired(fmt.Sprintf("https://wago.tools/db2/%s/csv?build=1.15.3.55646", table)))
+		}
+		return
+	} else if *genAsset == "spelldata" {
+		tables := make(map[string]string, len(database.WagoSpellTables))
+		for _, table := range database.WagoSpellTables {
+			tables[table] = tools.ReadFile(fmt.Sprintf("%s/wago_db2_%s.csv", inputsDir, table))
+		}
+		spellData := database.ParseWagoSpellDB(tables)
+
+		// Validate against the freshly parsed data before writing it, so nothing
+		// compiled from the previous spell data is involved.
+		sim.RegisterAll()
+		var mismatches []string
+		for _, level := range spellDataValidationLevels {
+			for _, r := range getRotationRaids() {
+				player := r.Raid.Parties[0].Players[0]
+				player.Level = level
+				// Talents modify cast times and costs, so only base values are compared.
+				player.TalentsString = ""
+				mismatches = append(mismatches, database.ValidateSpellData(spellData, r.Name, CreateTempAgent(r.Raid).GetCharacter().Spellbook)...)
+			}
+		}
+		mismatches = append(mismatches, database.ValidateSpellDamage(spellData, spellDataRankedDamage)...)
+		slices.Sort(mismatches)
+		for _, mismatch := range slices.Compact(mismatches) {
+			fmt.Println(mismatch)
+		}
+
+		database.WriteSpellDataGo(*spellDataOut, spellData)
+		return
 	} else if *genAsset != "db" {
 		panic("Invalid gen value")
 	}
@@ -405,10 +491,9 @@ type RotContainer struct {
 	Raid *proto.Raid
 }
 
-func GetAllRotationSpellIds() map[string][]int32 {
-	sim.RegisterAll()
-
-	rotMapping := []RotContainer{
+// Level 60 characters for each spec, with no gear or talents.
+func getRotationRaids() []RotContainer {
+	return []RotContainer{
 		{Name: "feral", Raid: core.SinglePlayerRaidProto(core.WithSpec(&proto.Player{
 			Class:     proto.Class_ClassDruid,
 			Level:     60,
@@ -491,6 +576,12 @@ func GetAllRotationSpellIds() map[string][]int32 {
 			Equipment: &proto.EquipmentSpec{},
 		}, &proto.Player_TankWarlock{TankWarlock: &proto.TankWarlock{Options: &proto.WarlockOptions{}}}), nil, nil, nil)},
 	}
+}
+
+func GetAllRotationSpellIds() map[string][]int32 {
+	sim.RegisterAll()
+
+	rotMapping := getRotationRaids()
 
 	ret_db := make(map[string][]int32, 0)
 
@@ -0,0 +1,304 @@
+package database
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/tools"
+	"golang.org/x/exp/maps"
+)
+
+// DB2 tables needed for spell data, by the name used in wago.tools URLs.
+var WagoSpellTables = []string{"SpellEffect", "SpellMisc", "SpellLevels", "SpellPower", "SpellCastTimes"}
+
+// Client power types which store costs in tenths of a point.
+const (
+	clientPowerTypeRage       = 1
+	clientPowerTypeRunicPower = 6
+)
+
+// A parsed wago.tools DB2 csv export.
+type wagoTable struct {
+	name    string
+	headers map[string]int
+	rows    [][]string
+}
+
+func parseWagoTable(name string, contents string, requiredHeaders ...string) *wagoTable {
+	r := csv.NewReader(strings.NewReader(contents))
+	rawHeaders, err := r.Read()
+	if err != nil {
+		log.Fatalf("Cannot read wago %s csv header row: %v", name, err)
+	}
+
+	table := &wagoTable{name: name, headers: map[string]int{}}
+	for i, header := range rawHeaders {
+		table.headers[header] = i
+	}
+	for _, header := range requiredHeaders {
+		if _, ok := table.headers[header]; !ok {
+			log.Fatalf("The wago %s csv does not have a %s header column. All columns: %#v", name, header, table.headers)
+		}
+	}
+
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Cannot read wago %s csv row: %v", name, err)
+		}
+		table.rows = append(table.rows, row)
+	}
+	return table
+}
+
+func (table *wagoTable) float(row []string, header string) float64 {
+	value, err := strconv.ParseFloat(row[table.headers[header]], 64)
+	if err != nil {
+		log.Fatalf("Cannot parse %s from %s row %v: %v", header, table.name, row, err)
+	}
+	return value
+}
+
+func (table *wagoTable) int(row []string, header string) int32 {
+	return int32(table.float(row, header))
+}
+
+// Parses the wago.tools DB2 exports listed in WagoSpellTables, keyed by table
+// name, into spell data for every spell which can be learned.
+func ParseWagoSpellDB(tables map[string]string) map[int32]*core.SpellData {
+	levels := parseWagoTable("SpellLevels", tables["SpellLevels"], "SpellID", "DifficultyID", "SpellLevel", "BaseLevel", "MaxLevel")
+	misc := parseWagoTable("SpellMisc", tables["SpellMisc"], "SpellID", "DifficultyID", "CastingTimeIndex", "SchoolMask", "Speed")
+	castTimes := parseWagoTable("SpellCastTimes", tables["SpellCastTimes"], "ID", "Base")
+	power := parseWagoTable("SpellPower", tables["SpellPower"], "SpellID", "OrderIndex", "ManaCost", "ManaCostPerLevel", "PowerCostPct", "PowerType")
+	effects := parseWagoTable("SpellEffect", tables["SpellEffect"], "SpellID", "DifficultyID", "EffectIndex", "Effect", "EffectAura", "EffectBasePoints",
+		"EffectDieSides", "EffectRealPointsPerLevel", "EffectBonusCoefficient", "BonusCoefficientFromAP", "EffectAuraPeriod", "EffectTriggerSpell")
+
+	spells := make(map[int32]*core.SpellData)
+	for _, row := range levels.rows {
+		if levels.int(row, "DifficultyID") != 0 {
+			continue
+		}
+		spellID := levels.int(row, "SpellID")
+		spells[spellID] = &core.SpellData{
+			ID:         spellID,
+			LearnLevel: levels.int(row, "SpellLevel"),
+			BaseLevel:  levels.int(row, "BaseLevel"),
+			MaxLevel:   levels.int(row, "MaxLevel"),
+		}
+	}
+
+	castTimesByIndex := make(map[int32]int32, len(castTimes.rows))
+	for _, row := range castTimes.rows {
+		castTimesByIndex[castTimes.int(row, "ID")] = castTimes.int(row, "Base")
+	}
+	for _, row := range misc.rows {
+		spell, ok := spells[misc.int(row, "SpellID")]
+		if !ok || misc.int(row, "DifficultyID") != 0 {
+			continue
+		}
+		spell.SchoolMask = misc.int(row, "SchoolMask")
+		spell.CastTimeMs = castTimesByIndex[misc.int(row, "CastingTimeIndex")]
+		spell.MissileSpeed = misc.float(row, "Speed")
+	}
+
+	for _, row := range power.rows {
+		spell, ok := spells[power.int(row, "SpellID")]
+		// Only the primary cost is used by the sim.
+		if !ok || power.int(row, "OrderIndex") != 0 {
+			continue
+		}
+		spell.PowerType = power.int(row, "PowerType")
+		spell.PowerCost = power.float(row, "ManaCost")
+		spell.PowerCostPct = power.float(row, "PowerCostPct")
+		spell.PowerCostPerLvl = power.float(row, "ManaCostPerLevel")
+		if spell.PowerType == clientPowerTypeRage || spell.PowerType == clientPowerTypeRunicPower {
+			spell.PowerCost /= 10
+			spell.PowerCostPerLvl /= 10
+		}
+	}
+
+	for _, row := range effects.rows {
+		spell, ok := spells[effects.int(row, "SpellID")]
+		if !ok || effects.int(row, "DifficultyID") != 0 {
+			continue
+		}
+
+		// Values are rolled from BasePoints+1 to BasePoints+DieSides, as in the original client.
+		basePoints := effects.float(row, "EffectBasePoints")
+		dieSides := effects.float(row, "EffectDieSides")
+		baseValueMin, baseValueMax := basePoints, basePoints
+		if dieSides > 0 {
+			baseValueMin, baseValueMax = basePoints+1, basePoints+dieSides
+		}
+
+		spell.Effects = append(spell.Effects, core.SpellEffectData{
+			Index:                  effects.int(row, "EffectIndex"),
+			Effect:                 effects.int(row, "Effect"),
+			Aura:                   effects.int(row, "EffectAura"),
+			BaseValueMin:           baseValueMin,
+			BaseValueMax:           baseValueMax,
+			RealPointsPerLevel:     effects.float(row, "EffectRealPointsPerLevel"),
+			BonusCoefficient:       effects.float(row, "EffectBonusCoefficient"),
+			BonusCoefficientFromAP: effects.float(row, "BonusCoefficientFromAP"),
+			AuraPeriodMs:           effects.int(row, "EffectAuraPeriod"),
+			TriggerSpell:           effects.int(row, "EffectTriggerSpell"),
+		})
+	}
+	for _, spell := range spells {
+		slices.SortFunc(spell.Effects, func(a, b core.SpellEffectData) int {
+			return int(a.Index - b.Index)
+		})
+	}
+
+	return spells
+}
+
+// Writes the spell data as a Go source file in package core.
+func WriteSpellDataGo(filePath string, spells map[int32]*core.SpellData) {
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("package core\n\n")
+	buffer.WriteString("// *******************************************\n")
+	buffer.WriteString("// AUTO GENERATED BY GEN_DB -GEN=SPELLDATA\n")
+	buffer.WriteString("// *******************************************\n\n")
+	buffer.WriteString("func init() {\n")
+	buffer.WriteString("spellDataByID = map[int32]*SpellData{\n")
+
+	spellIDs := maps.Keys(spells)
+	slices.Sort(spellIDs)
+	for _, spellID := range spellIDs {
+		spell := spells[spellID]
+		fmt.Fprintf(buffer, "%d: {ID: %d, LearnLevel: %d, BaseLevel: %d, MaxLevel: %d, SchoolMask: %d, CastTimeMs: %d, MissileSpeed: %v, ",
+			spellID, spell.ID, spell.LearnLevel, spell.BaseLevel, spell.MaxLevel, spell.SchoolMask, spell.CastTimeMs, spell.MissileSpeed)
+		fmt.Fprintf(buffer, "PowerType: %d, PowerCost: %v, PowerCostPct: %v, PowerCostPerLvl: %v, Effects: []SpellEffectData{",
+			spell.PowerType, spell.PowerCost, spell.PowerCostPct, spell.PowerCostPerLvl)
+		for _, effect := range spell.Effects {
+			fmt.Fprintf(buffer, "{Index: %d, Effect: %d, Aura: %d, BaseValueMin: %v, BaseValueMax: %v, RealPointsPerLevel: %v, BonusCoefficient: %v, BonusCoefficientFromAP: %v, AuraPeriodMs: %d, TriggerSpell: %d}, ",
+				effect.Index, effect.Effect, effect.Aura, effect.BaseValueMin, effect.BaseValueMax, effect.RealPointsPerLevel, effect.BonusCoefficient, effect.BonusCoefficientFromAP, effect.AuraPeriodMs, effect.TriggerSpell)
+		}
+		buffer.WriteString("}},\n")
+	}
+	buffer.WriteString("}\n}\n")
+
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		log.Fatalf("Failed to format spell data: %s", err)
+	}
+	tools.WriteFile(filePath, string(formatted))
+}
+
+// School bits in the client data, which are ordered differently than core.SpellSchool.
+var clientSchoolBits = []core.SpellSchool{
+	core.SpellSchoolPhysical,
+	core.SpellSchoolHoly,
+	core.SpellSchoolFire,
+	core.SpellSchoolNature,
+	core.SpellSchoolFrost,
+	core.SpellSchoolShadow,
+	core.SpellSchoolArcane,
+}
+
+func clientSchoolMaskToSpellSchool(mask int32) core.SpellSchool {
+	school := core.SpellSchoolNone
+	for i, bit := range clientSchoolBits {
+		if mask&(1<<i) != 0 {
+			school |= bit
+		}
+	}
+	return school
+}
+
+// Returns a line for every value hardcoded in the given spells which disagrees
+// with the client spell data. Spellbooks of characters at each level cap should
+// be validated, so the lower rank tables are checked too.
+//
+// Cast times and costs are compared as registered, so the spellbook must come
+// from a character without talents, runes or gear which modify them.
+func ValidateSpellData(spells map[int32]*core.SpellData, label string, spellbook []*core.Spell) []string {
+	var mismatches []string
+	report := func(spell *core.Spell, field string, hardcoded, client any) {
+		mismatches = append(mismatches, fmt.Sprintf("%s: %s %s is %v, client data has %v", label, spell.ActionID, field, hardcoded, client))
+	}
+
+	for _, spell := range spellbook {
+		data, ok := spells[spell.SpellID]
+		if !ok {
+			continue
+		}
+
+		if spell.RequiredLevel != 0 && int32(spell.RequiredLevel) != data.LearnLevel {
+			report(spell, "required level", spell.RequiredLevel, data.LearnLevel)
+		}
+		if spell.DefaultCast.CastTime != data.CastTime() {
+			report(spell, "cast time", spell.DefaultCast.CastTime, data.CastTime())
+		}
+		if spell.MissileSpeed != data.MissileSpeed {
+			report(spell, "missile speed", spell.MissileSpeed, data.MissileSpeed)
+		}
+		if spell.Cost != nil && data.PowerCost > 0 && spell.DefaultCast.Cost != data.PowerCost {
+			report(spell, "cost", spell.DefaultCast.Cost, data.PowerCost)
+		}
+		if coefficient := data.BonusCoefficient(); spell.BonusCoefficient != 0 && math.Abs(spell.BonusCoefficient-coefficient) > 0.0005 {
+			report(spell, "bonus coefficient", spell.BonusCoefficient, coefficient)
+		}
+		if school := clientSchoolMaskToSpellSchool(data.SchoolMask); school != core.SpellSchoolNone && spell.SpellSchool != school {
+			report(spell, "school", spell.SpellSchool, school)
+		}
+	}
+
+	slices.Sort(mismatches)
+	return slices.Compact(mismatches)
+}
+
+// Hardcoded base damage for each rank of a spell, indexed by rank like the
+// class packages' rank tables.
+type RankedDamage struct {
+	Name     string
+	SpellIDs []int32
+	Damage   [][]float64 // Min and max damage of each rank, or a single value for fixed damage.
+}
+
+// Returns the same value as both min and max damage for each rank, for spells
+// whose ranks deal fixed damage.
+func FixedRankedDamage(damage []float64) [][]float64 {
+	return core.MapSlice(damage, func(value float64) []float64 { return []float64{value, value} })
+}
+
+// Returns a line for every rank whose hardcoded base damage doesn't match any
+// effect of its client spell data.
+func ValidateSpellDamage(spells map[int32]*core.SpellData, rankedDamage []RankedDamage) []string {
+	var mismatches []string
+	for _, ranks := range rankedDamage {
+		for rank := 1; rank < len(ranks.SpellIDs); rank++ {
+			data, ok := spells[ranks.SpellIDs[rank]]
+			if !ok {
+				continue
+			}
+
+			damage := ranks.Damage[rank]
+			damageMin, damageMax := damage[0], damage[len(damage)-1]
+			matches := slices.ContainsFunc(data.Effects, func(effect core.SpellEffectData) bool {
+				return effect.BaseValueMin == damageMin && effect.BaseValueMax == damageMax
+			})
+			if !matches {
+				clientDamage := core.MapSlice(data.Effects, func(effect core.SpellEffectData) string {
+					return fmt.Sprintf("%v-%v", effect.BaseValueMin, effect.BaseValueMax)
+				})
+				mismatches = append(mismatches, fmt.Sprintf("%s rank %d: %s base damage is %v-%v, client data has %s",
+					ranks.Name, rank, core.ActionID{SpellID: data.ID}, damageMin, damageMax, strings.Join(clientDamage, ", ")))
+			}
+		}
+	}
+	return mismatches
+}
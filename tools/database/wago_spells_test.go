@@ -0,0 +1,91 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+var testWagoSpellTables = map[string]string{
+	"SpellLevels": `ID,DifficultyID,SpellID,BaseLevel,MaxLevel,SpellLevel
+1,0,116,4,9,4
+2,0,78,1,0,1
+3,1,116,60,60,60
+`,
+	"SpellMisc": `ID,DifficultyID,SpellID,CastingTimeIndex,SchoolMask,Speed
+1,0,116,5,16,28
+2,0,78,1,1,0
+`,
+	"SpellCastTimes": `ID,Base,Minimum
+1,0,0
+5,1500,0
+`,
+	"SpellPower": `ID,SpellID,OrderIndex,ManaCost,ManaCostPerLevel,PowerCostPct,PowerType
+1,116,0,25,0,0,0
+2,78,0,150,0,0,1
+3,78,1,99,0,0,0
+`,
+	"SpellEffect": `ID,DifficultyID,SpellID,EffectIndex,Effect,EffectAura,EffectBasePoints,EffectDieSides,EffectRealPointsPerLevel,EffectBonusCoefficient,BonusCoefficientFromAP,EffectAuraPeriod,EffectTriggerSpell
+1,0,116,1,6,33,-40,0,0,0,0,0,0
+2,0,116,0,2,0,19,3,0.5,0.163,0,0,0
+3,1,116,0,2,0,999,0,0,0,0,0,0
+4,0,78,0,2,0,11,0,0,0,0,0,0
+`,
+}
+
+func TestParseWagoSpellDB(t *testing.T) {
+	spells := ParseWagoSpellDB(testWagoSpellTables)
+	if len(spells) != 2 {
+		t.Fatalf("Expected 2 spells, got %d", len(spells))
+	}
+
+	frostbolt := spells[116]
+	if frostbolt.LearnLevel != 4 || frostbolt.BaseLevel != 4 || frostbolt.MaxLevel != 9 {
+		t.Errorf("Expected levels from the default difficulty, got %d/%d/%d", frostbolt.LearnLevel, frostbolt.BaseLevel, frostbolt.MaxLevel)
+	}
+	if frostbolt.CastTime() != time.Millisecond*1500 || frostbolt.MissileSpeed != 28 || frostbolt.SchoolMask != 16 {
+		t.Errorf("Unexpected misc data: %v, %v, %d", frostbolt.CastTime(), frostbolt.MissileSpeed, frostbolt.SchoolMask)
+	}
+	if frostbolt.PowerCost != 25 {
+		t.Errorf("Expected a mana cost of 25, got %v", frostbolt.PowerCost)
+	}
+	if len(frostbolt.Effects) != 2 || frostbolt.Effects[0].Index != 0 {
+		t.Fatalf("Expected 2 effects sorted by index, got %v", frostbolt.Effects)
+	}
+	damage := frostbolt.Effect(0)
+	if damage.BaseValueMin != 20 || damage.BaseValueMax != 22 || damage.RealPointsPerLevel != 0.5 {
+		t.Errorf("Expected damage rolled from 20 to 22, got %v to %v", damage.BaseValueMin, damage.BaseValueMax)
+	}
+	if frostbolt.BonusCoefficient() != 0.163 {
+		t.Errorf("Expected a bonus coefficient of 0.163, got %v", frostbolt.BonusCoefficient())
+	}
+	if slow := frostbolt.Effect(1); slow.BaseValueMin != -40 || slow.BaseValueMax != -40 {
+		t.Errorf("Expected a fixed value of -40 for an effect with no die, got %v to %v", slow.BaseValueMin, slow.BaseValueMax)
+	}
+
+	heroicStrike := spells[78]
+	if heroicStrike.PowerCost != 15 {
+		t.Errorf("Expected client rage costs to be scaled to 15 rage, got %v", heroicStrike.PowerCost)
+	}
+	if heroicStrike.CastTime() != 0 {
+		t.Errorf("Expected an instant cast, got %v", heroicStrike.CastTime())
+	}
+}
+
+func TestValidateSpellDamage(t *testing.T) {
+	spells := ParseWagoSpellDB(testWagoSpellTables)
+
+	matching := []RankedDamage{
+		{Name: "Frostbolt", SpellIDs: []int32{0, 116}, Damage: [][]float64{{0}, {20, 22}}},
+		{Name: "Heroic Strike", SpellIDs: []int32{0, 78}, Damage: FixedRankedDamage([]float64{0, 11})},
+	}
+	if mismatches := ValidateSpellDamage(spells, matching); len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches, got %v", mismatches)
+	}
+
+	mismatching := []RankedDamage{
+		{Name: "Frostbolt", SpellIDs: []int32{0, 116}, Damage: [][]float64{{0}, {20, 23}}},
+	}
+	if mismatches := ValidateSpellDamage(spells, mismatching); len(mismatches) != 1 {
+		t.Errorf("Expected the wrong max damage to be reported, got %v", mismatches)
+	}
+}
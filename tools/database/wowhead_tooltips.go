@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -243,6 +244,53 @@ var natureResistanceRegex = regexp.MustCompile(`\+([0-9]+) Nature Resistance`)
 var shadowResistanceRegex = regexp.MustCompile(`\+([0-9]+) Shadow Resistance`)
 var bonusArmorRegex = regexp.MustCompile(`Has ([0-9]+) bonus armor`)
 
+var itemEffectRegex = regexp.MustCompile(`(Use|Equip|Chance on hit): (.*?)</span>`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Equip effects parsed into plain stats by GetStats and GetWeaponSkills.
+var equipStatRegexes = []*regexp.Regexp{
+	spellHealingRegex, spellPowerRegex, spellPowerRegex3,
+	arcaneSpellPowerRegex, fireSpellPowerRegex, frostSpellPowerRegex, holySpellPowerRegex, natureSpellPowerRegex, shadowSpellPowerRegex,
+	hitRegex, hitRegex2, physicalHitRegex, spellHitRegex,
+	critRegex, critRegex2, spellCritRegex, meleeCritRegex, hasteRegex,
+	spellPenetrationRegex, mp5Regex, attackPowerRegex, rangedAttackPowerRegex, rangedAttackPowerRegex2, feralAttackPowerRegex, armorPenetrationRegex,
+	axesSkill, swordsSkill, daggersSkill, unarmedSkill, macesSkill,
+	twoHandedAxesSkill, twoHandedSwordsSkill, twoHandedMacesSkill, stavesSkill, polearmsSkill,
+	thrownSkill, bowsSkill, crossbowsSkill, gunsSkill, feralCombatSkill,
+	defenseRegex, blockRegex, blockValueRegex, dodgeRegex, parryRegex,
+}
+
+// Returns the special effects described by the tooltip, ignoring effects which
+// are plain stats.
+func (item WowheadItemResponse) GetEffectTypes() []proto.ItemEffectType {
+	var effectTypes []proto.ItemEffectType
+	addEffectType := func(effectType proto.ItemEffectType) {
+		if !slices.Contains(effectTypes, effectType) {
+			effectTypes = append(effectTypes, effectType)
+		}
+	}
+
+	for _, match := range itemEffectRegex.FindAllStringSubmatch(item.TooltipWithoutSetBonus(), -1) {
+		text := htmlTagRegex.ReplaceAllString(match[2], "")
+
+		switch match[1] {
+		case "Use":
+			addEffectType(proto.ItemEffectType_ItemEffectTypeUse)
+		case "Chance on hit":
+			addEffectType(proto.ItemEffectType_ItemEffectTypeProc)
+		case "Equip":
+			if strings.Contains(strings.ToLower(text), "chance") {
+				addEffectType(proto.ItemEffectType_ItemEffectTypeProc)
+			} else if !slices.ContainsFunc(equipStatRegexes, func(pattern *regexp.Regexp) bool { return pattern.MatchString(text) }) {
+				addEffectType(proto.ItemEffectType_ItemEffectTypeEquip)
+			}
+		}
+	}
+
+	slices.Sort(effectTypes)
+	return effectTypes
+}
+
 // Match "Requires <a href=\"/...\" class="...">Profession</a> (level)"
 var alchemyRegex = regexp.MustCompile(`Requires <a [ =/\\"\w]*>Alchemy<\/a> \([0-9]+\)`)
 var blacksmithingRegex = regexp.MustCompile(`Requires <a [ =/\\"\w]*>Blacksmithing<\/a> \([0-9]+\)`)
@@ -690,6 +738,8 @@ func (item WowheadItemResponse) ToItemProto() *proto.UIItem {
 
 		RequiredProfession: item.GetRequiredProfession(),
 		SetName:            item.GetItemSetName(),
+
+		EffectTypes: item.GetEffectTypes(),
 	}
 
 	if item.GetRequiredProfession() != proto.Profession_ProfessionUnknown {
@@ -244,6 +244,11 @@ export class HealingMetricsTable extends MetricsTable<ActionMetrics> {
 				getValue: (metric: ActionMetrics) => metric.healingCritPercent,
 				getDisplayString: (metric: ActionMetrics) => formatToPercent(metric.healingCritPercent, { fallbackString: '-' }),
 			},
+			{
+				name: 'Overheal %',
+				getValue: (metric: ActionMetrics) => metric.overhealingPercent,
+				getDisplayString: (metric: ActionMetrics) => formatToPercent(metric.overhealingPercent, { fallbackString: '-' }),
+			},
 			{
 				name: 'HPET',
 				getValue: (metric: ActionMetrics) => metric.healingThroughput,
@@ -1,11 +1,13 @@
 import { BooleanPicker } from '../components/boolean_picker.js';
 import { EnumPicker } from '../components/enum_picker.js';
 import { ListItemPickerConfig, ListPicker } from '../components/list_picker.js';
+import { NumberListPicker } from '../components/number_list_picker.js';
 import { NumberPicker } from '../components/number_picker.js';
 import * as Mechanics from '../constants/mechanics.js';
 import { Encounter } from '../encounter.js';
 import { IndividualSimUI } from '../individual_sim_ui.js';
-import { InputType, MobType, SpellSchool, Stat, Target, Target as TargetProto, TargetInput } from '../proto/common.js';
+import { AggroPullAction, TankThreatPoint, ThreatCeiling } from '../proto/api.js';
+import { DurationDistribution, InputType, MobType, SpellSchool, Stat, Target, Target as TargetProto, TargetInput, Vector2 } from '../proto/common.js';
 import { statNames } from '../proto_utils/names.js';
 import { Stats } from '../proto_utils/stats.js';
 import { isHealingSpec, isTankSpec } from '../proto_utils/utils.js';
@@ -106,6 +108,52 @@ export class EncounterPicker extends Component {
 				});
 			}
 
+			if (simUI.isIndividualSim() && !isTankSpec((simUI as IndividualSimUI<any>).player.spec) && !isHealingSpec((simUI as IndividualSimUI<any>).player.spec)) {
+				const updateThreatCeiling = (eventID: EventID, raid: Raid, update: (threatCeiling: ThreatCeiling) => void) => {
+					const threatCeiling = raid.getThreatCeiling();
+					update(threatCeiling);
+					raid.setThreatCeiling(eventID, threatCeiling);
+				};
+
+				new BooleanPicker(this.rootElem, simUI.sim.raid, {
+					id: 'encounter-threat-ceiling',
+					label: 'Threat Ceiling',
+					labelTooltip: 'Limits your threat to what the tank can hold, following the 110% melee / 130% ranged aggro rules.',
+					changedEvent: (raid: Raid) => raid.threatCeilingChangeEmitter,
+					getValue: (raid: Raid) => raid.getThreatCeiling().enabled,
+					setValue: (eventID: EventID, raid: Raid, newValue: boolean) => {
+						updateThreatCeiling(eventID, raid, threatCeiling => (threatCeiling.enabled = newValue));
+					},
+				});
+				new NumberPicker(this.rootElem, simUI.sim.raid, {
+					id: 'encounter-tank-tps',
+					label: 'Tank TPS',
+					labelTooltip: 'Threat per second generated by the tank on each target.',
+					float: true,
+					changedEvent: (raid: Raid) => raid.threatCeilingChangeEmitter,
+					getValue: (raid: Raid) => raid.getThreatCeiling().tankTpsCurve[0]?.tps || 0,
+					setValue: (eventID: EventID, raid: Raid, newValue: number) => {
+						updateThreatCeiling(eventID, raid, threatCeiling => (threatCeiling.tankTpsCurve = [TankThreatPoint.create({ timeSeconds: 0, tps: newValue })]));
+					},
+					showWhen: (raid: Raid) => raid.getThreatCeiling().enabled,
+				});
+				new EnumPicker(this.rootElem, simUI.sim.raid, {
+					id: 'encounter-aggro-pull-action',
+					label: 'On Aggro Pull',
+					values: [
+						{ name: 'Report Only', value: AggroPullAction.AggroPullActionNone },
+						{ name: 'Wipe', value: AggroPullAction.AggroPullActionWipe },
+						{ name: 'Stop Attacking', value: AggroPullAction.AggroPullActionStop },
+					],
+					changedEvent: (raid: Raid) => raid.threatCeilingChangeEmitter,
+					getValue: (raid: Raid) => raid.getThreatCeiling().onPull,
+					setValue: (eventID: EventID, raid: Raid, newValue: number) => {
+						updateThreatCeiling(eventID, raid, threatCeiling => (threatCeiling.onPull = newValue));
+					},
+					showWhen: (raid: Raid) => raid.getThreatCeiling().enabled,
+				});
+			}
+
 			if (simUI.isIndividualSim() && isTankSpec((simUI as IndividualSimUI<any>).player.spec)) {
 				new NumberPicker(this.rootElem, modEncounter, {
 					id: 'encounter-min-base-damage',
@@ -175,6 +223,17 @@ class AdvancedEncounterModal extends BaseModal {
 				},
 			});
 		}
+		new BooleanPicker<Encounter>(header, encounter, {
+			id: 'encounter-use-positions',
+			label: 'Use Positions',
+			labelTooltip: 'Places players and targets on a 2D plane, so spell ranges, AoE areas and attacking from behind depend on where each unit stands.',
+			inline: true,
+			changedEvent: (encounter: Encounter) => encounter.changeEmitter,
+			getValue: (encounter: Encounter) => encounter.getUsePositions(),
+			setValue: (eventID: EventID, encounter: Encounter, newValue: boolean) => {
+				encounter.setUsePositions(eventID, newValue);
+			},
+		});
 		new ListPicker<Encounter, TargetProto>(targetsElem, this.encounter, {
 			extraCssClasses: ['targets-picker', 'mb-0'],
 			itemLabel: 'Target',
@@ -230,6 +289,9 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 	private readonly levelPicker: Input<null, number>;
 	private readonly mobTypePicker: Input<null, number>;
 	private readonly tankIndexPicker: Input<null, number>;
+	private readonly positionXPicker: Input<null, number>;
+	private readonly positionYPicker: Input<null, number>;
+	private readonly facingPicker: Input<null, number>;
 	private readonly statPickers: Array<Input<null, number>>;
 	private readonly swingSpeedPicker: Input<null, number>;
 	private readonly minBaseDamagePicker: Input<null, number>;
@@ -372,6 +434,48 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 			},
 		});
 
+		this.positionXPicker = new NumberPicker(section1, null, {
+			id: 'target-picker-position-x',
+			label: 'Position X',
+			labelTooltip: 'X coordinate of this enemy, in yards.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().position?.x || 0,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				const target = this.getTarget();
+				target.position = Vector2.create({ x: newValue, y: target.position?.y || 0 });
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => encounter.getUsePositions(),
+		});
+		this.positionYPicker = new NumberPicker(section1, null, {
+			id: 'target-picker-position-y',
+			label: 'Position Y',
+			labelTooltip: 'Y coordinate of this enemy, in yards.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().position?.y || 0,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				const target = this.getTarget();
+				target.position = Vector2.create({ x: target.position?.x || 0, y: newValue });
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => encounter.getUsePositions(),
+		});
+		this.facingPicker = new NumberPicker(section1, null, {
+			id: 'target-picker-facing',
+			label: 'Facing',
+			labelTooltip: 'Direction this enemy faces when it is not tanked, in degrees counterclockwise from the X axis. Tanked enemies face their tank.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().facingDegrees,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getTarget().facingDegrees = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+			showWhen: () => encounter.getUsePositions(),
+		});
+
 		this.targetInputPickers = makeTargetInputsPicker(section1, encounter, this.targetIndex);
 
 		this.statPickers = ALL_TARGET_STATS.map(statData => {
@@ -500,6 +604,8 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 			level: this.levelPicker.getInputValue(),
 			mobType: this.mobTypePicker.getInputValue(),
 			tankIndex: this.tankIndexPicker.getInputValue(),
+			position: Vector2.create({ x: this.positionXPicker.getInputValue(), y: this.positionYPicker.getInputValue() }),
+			facingDegrees: this.facingPicker.getInputValue(),
 			swingSpeed: this.swingSpeedPicker.getInputValue(),
 			minBaseDamage: this.minBaseDamagePicker.getInputValue(),
 			dualWield: this.dualWieldPicker.getInputValue(),
@@ -523,6 +629,9 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 		this.levelPicker.setInputValue(newValue.level);
 		this.mobTypePicker.setInputValue(newValue.mobType);
 		this.tankIndexPicker.setInputValue(newValue.tankIndex);
+		this.positionXPicker.setInputValue(newValue.position?.x || 0);
+		this.positionYPicker.setInputValue(newValue.position?.y || 0);
+		this.facingPicker.setInputValue(newValue.facingDegrees);
 		this.swingSpeedPicker.setInputValue(newValue.swingSpeed);
 		this.minBaseDamagePicker.setInputValue(newValue.minBaseDamage);
 		this.dualWieldPicker.setInputValue(newValue.dualWield);
@@ -617,15 +726,39 @@ function addEncounterFieldPickers(rootElem: HTMLElement, encounter: Encounter, s
 	const durationGroup = Input.newGroupContainer();
 	rootElem.appendChild(durationGroup);
 
+	const isEmpirical = () => encounter.getDurationDistribution() == DurationDistribution.DurationEmpirical;
+
+	new EnumPicker<Encounter>(durationGroup, encounter, {
+		id: 'encounter-duration-distribution',
+		label: 'Duration Distribution',
+		labelTooltip:
+			'How the fight length of each sim iteration is chosen. Kill Times picks one of a list of observed kill times, and Lognormal suits kill times with a long tail of slow kills.',
+		values: [
+			{ name: 'Uniform', value: DurationDistribution.DurationUniform },
+			{ name: 'Normal', value: DurationDistribution.DurationNormal },
+			{ name: 'Lognormal', value: DurationDistribution.DurationLognormal },
+			{ name: 'Kill Times', value: DurationDistribution.DurationEmpirical },
+		],
+		changedEvent: (encounter: Encounter) => encounter.changeEmitter,
+		getValue: (encounter: Encounter) => encounter.getDurationDistribution(),
+		setValue: (eventID: EventID, encounter: Encounter, newValue: number) => {
+			encounter.setDurationDistribution(eventID, newValue);
+		},
+		enableWhen: _ => {
+			return !encounter.getUseHealth();
+		},
+	});
 	new NumberPicker(durationGroup, encounter, {
 		id: 'encounter-duration',
 		label: 'Duration',
-		labelTooltip: 'The fight length for each sim iteration, in seconds.',
+		labelTooltip:
+			'The fight length for each sim iteration, in seconds. This is the mean fight length for the Normal and Lognormal distributions.',
 		changedEvent: (encounter: Encounter) => encounter.changeEmitter,
 		getValue: (encounter: Encounter) => encounter.getDuration(),
 		setValue: (eventID: EventID, encounter: Encounter, newValue: number) => {
 			encounter.setDuration(eventID, newValue);
 		},
+		showWhen: _ => !isEmpirical(),
 		enableWhen: _ => {
 			return !encounter.getUseHealth();
 		},
@@ -634,12 +767,28 @@ function addEncounterFieldPickers(rootElem: HTMLElement, encounter: Encounter, s
 		id: 'encounter-duration-variation',
 		label: 'Duration +/-',
 		labelTooltip:
-			'Adds a random amount of time, in seconds, between [value, -1 * value] to each sim iteration. For example, setting Duration to 180 and Duration +/- to 10 will result in random durations between 170s and 190s.',
+			'Adds a random amount of time, in seconds, between [value, -1 * value] to each sim iteration. For example, setting Duration to 180 and Duration +/- to 10 will result in random durations between 170s and 190s. For the Normal and Lognormal distributions, this is the standard deviation of the fight length.',
 		changedEvent: (encounter: Encounter) => encounter.changeEmitter,
 		getValue: (encounter: Encounter) => encounter.getDurationVariation(),
 		setValue: (eventID: EventID, encounter: Encounter, newValue: number) => {
 			encounter.setDurationVariation(eventID, newValue);
 		},
+		showWhen: _ => !isEmpirical(),
+		enableWhen: _ => {
+			return !encounter.getUseHealth();
+		},
+	});
+	new NumberListPicker(durationGroup, encounter, {
+		id: 'encounter-kill-times',
+		label: 'Kill Times',
+		labelTooltip: 'Comma-separated list of kill times, in seconds. Each sim iteration uses one of these, chosen at random.',
+		placeholder: '120,135,160',
+		changedEvent: (encounter: Encounter) => encounter.changeEmitter,
+		getValue: (encounter: Encounter) => encounter.getKillTimes(),
+		setValue: (eventID: EventID, encounter: Encounter, newValue: Array<number>) => {
+			encounter.setKillTimes(eventID, newValue.filter(killTime => killTime > 0));
+		},
+		showWhen: _ => isEmpirical(),
 		enableWhen: _ => {
 			return !encounter.getUseHealth();
 		},
@@ -692,6 +841,20 @@ function addEncounterFieldPickers(rootElem: HTMLElement, encounter: Encounter, s
 				return !encounter.getUseHealth();
 			},
 		});
+		new BooleanPicker(executeGroup, encounter, {
+			id: 'encounter-fixed-execute-duration',
+			label: 'Fixed Execute Duration',
+			labelTooltip:
+				'If checked, the execute percentages are of the Duration (or the mean kill time), so every sim iteration spends the same time in execute range. Otherwise they are percentages of each iteration\'s fight length.',
+			changedEvent: (encounter: Encounter) => encounter.changeEmitter,
+			getValue: (encounter: Encounter) => encounter.getFixedExecuteDuration(),
+			setValue: (eventID: EventID, encounter: Encounter, newValue: boolean) => {
+				encounter.setFixedExecuteDuration(eventID, newValue);
+			},
+			enableWhen: _ => {
+				return !encounter.getUseHealth();
+			},
+		});
 	}
 }
 
@@ -12,12 +12,15 @@ export class IconItemSwapPicker extends Component {
 	private readonly iconAnchor: HTMLAnchorElement;
 	private readonly player: Player<any>;
 	private readonly slot: ItemSlot;
+	// Index of the swap set this picks for, 0 for the main item swap.
+	private readonly setIndex: number;
 
-	constructor(parent: HTMLElement, simUI: SimUI, player: Player<any>, slot: ItemSlot) {
+	constructor(parent: HTMLElement, simUI: SimUI, player: Player<any>, slot: ItemSlot, setIndex = 0) {
 		super(parent, 'icon-picker-root');
 		this.rootElem.classList.add('icon-picker');
 		this.player = player;
 		this.slot = slot;
+		this.setIndex = setIndex;
 
 		this.iconAnchor = document.createElement('a');
 		this.iconAnchor.classList.add('icon-picker-button');
@@ -33,9 +36,10 @@ export class IconItemSwapPicker extends Component {
 			});
 		});
 
-		player.itemSwapChangeEmitter.on(() => {
-			this.update(player.getItemSwapGear().getEquippedItem(slot));
-		});
+		const updateItem = () => this.update(player.getItemSwapSet(this.setIndex).getEquippedItem(slot));
+		updateItem();
+		const itemSwapChangeEvent = player.itemSwapChangeEmitter.on(updateItem);
+		this.addOnDisposeCallback(() => itemSwapChangeEvent.dispose());
 	}
 
 	update(newItem: EquippedItem | null) {
@@ -56,9 +60,10 @@ export class IconItemSwapPicker extends Component {
 	private createGearData(): GearData {
 		return {
 			equipItem: (eventID: EventID, newItem: EquippedItem | null) => {
-				this.player.equipItemSwapitem(eventID, this.slot, newItem);
+				const itemSwapGear = this.player.getItemSwapSet(this.setIndex);
+				this.player.setItemSwapSet(eventID, this.setIndex, itemSwapGear.withEquippedItem(this.slot, newItem, this.player.canDualWield2H()));
 			},
-			getEquippedItem: () => this.player.getItemSwapItem(this.slot),
+			getEquippedItem: () => this.player.getItemSwapSet(this.setIndex).getEquippedItem(this.slot),
 			changeEvent: this.player.itemSwapChangeEmitter,
 		};
 	}
@@ -18,6 +18,7 @@ import {
 	APLActionMove,
 	APLActionMultidot,
 	APLActionMultishield,
+	APLActionReapplyWeaponImbue,
 	APLActionResetSequence,
 	APLActionSchedule,
 	APLActionSequence,
@@ -596,10 +597,18 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 	['itemSwap']: inputBuilder({
 		label: 'Item Swap',
 		submenu: ['Misc'],
-		shortDescription: 'Swaps items, using the swap set specified in Settings.',
+		shortDescription: 'Swaps items, using the swap sets specified in Settings.',
+		fullDescription: `
+			<p>When swapping to a non-Main set, <b>Set Name</b> selects which swap set to equip. Leave it empty to use the first swap set.</p>
+		`,
 		includeIf: (player: Player<any>, _isPrepull: boolean) => itemSwapEnabledSpecs.includes(player.spec),
 		newValue: () => APLActionItemSwap.create(),
-		fields: [itemSwapSetFieldConfig('swapSet')],
+		fields: [
+			itemSwapSetFieldConfig('swapSet'),
+			AplHelpers.stringFieldConfig('swapSetName', {
+				label: 'Set Name',
+			}),
+		],
 	}),
 	['move']: inputBuilder({
 		label: 'Move',
@@ -613,6 +622,18 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 			}),
 		],
 	}),
+	['reapplyWeaponImbue']: inputBuilder({
+		label: 'Reapply Weapon Imbue',
+		submenu: ['Misc'],
+		shortDescription: 'Reapplies an oil, stone or poison which has run out of charges or expired. Takes 3 seconds.',
+		includeIf: (_player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: () => APLActionReapplyWeaponImbue.create(),
+		fields: [
+			AplHelpers.booleanFieldConfig('offHand', 'Off Hand', {
+				labelTooltip: 'Reapply the off hand imbue instead of the main hand one.',
+			}),
+		],
+	}),
 	['customRotation']: inputBuilder({
 		label: 'Custom Rotation',
 		//submenu: ['Misc'],
@@ -1,7 +1,7 @@
 import tippy, { Instance as TippyInstance } from 'tippy.js';
 
 import { Player } from '../../player';
-import { APLAction, APLListItem, APLPrepullAction, APLValue } from '../../proto/apl';
+import { APLAction, APLListItem, APLPrepullAction, APLRotation, APLValue } from '../../proto/apl';
 import { ActionId } from '../../proto_utils/action_id';
 import { SimUI } from '../../sim_ui';
 import { EventID, TypedEvent } from '../../typed_event';
@@ -14,8 +14,11 @@ import { APLActionPicker } from './apl_actions';
 import { APLValueImplStruct } from './apl_values';
 
 export class APLRotationPicker extends Component {
-	constructor(parent: HTMLElement, simUI: SimUI, modPlayer: Player<any>) {
+	// getRotation selects the rotation to edit, e.g. one of the player's pet rotations. Warnings are only shown for the player's own rotation.
+	constructor(parent: HTMLElement, simUI: SimUI, modPlayer: Player<any>, getRotation?: (player: Player<any>) => APLRotation) {
 		super(parent, 'apl-rotation-picker-root');
+		const showWarnings = !getRotation;
+		const rotation = getRotation || ((player: Player<any>) => player.aplRotation);
 
 		new ListPicker<Player<any>, APLPrepullAction>(this.rootElem, modPlayer, {
 			extraCssClasses: ['apl-prepull-action-picker'],
@@ -23,9 +26,9 @@ export class APLRotationPicker extends Component {
 			titleTooltip: 'Actions to perform before the pull.',
 			itemLabel: 'Prepull Action',
 			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
-			getValue: (player: Player<any>) => player.aplRotation.prepullActions,
+			getValue: (player: Player<any>) => rotation(player).prepullActions,
 			setValue: (eventID: EventID, player: Player<any>, newValue: Array<APLPrepullAction>) => {
-				player.aplRotation.prepullActions = newValue;
+				rotation(player).prepullActions = newValue;
 				player.rotationChangeEmitter.emit(eventID);
 			},
 			newItem: () =>
@@ -41,7 +44,7 @@ export class APLRotationPicker extends Component {
 				listPicker: ListPicker<Player<any>, APLPrepullAction>,
 				index: number,
 				config: ListItemPickerConfig<Player<any>, APLPrepullAction>,
-			) => new APLPrepullActionPicker(parent, modPlayer, config, index),
+			) => new APLPrepullActionPicker(parent, modPlayer, config, index, showWarnings),
 			inlineMenuBar: true,
 		});
 
@@ -51,9 +54,9 @@ export class APLRotationPicker extends Component {
 			titleTooltip: 'At each decision point, the simulation will perform the first valid action from this list.',
 			itemLabel: 'Action',
 			changedEvent: (player: Player<any>) => player.rotationChangeEmitter,
-			getValue: (player: Player<any>) => player.aplRotation.priorityList,
+			getValue: (player: Player<any>) => rotation(player).priorityList,
 			setValue: (eventID: EventID, player: Player<any>, newValue: Array<APLListItem>) => {
-				player.aplRotation.priorityList = newValue;
+				rotation(player).priorityList = newValue;
 				player.rotationChangeEmitter.emit(eventID);
 			},
 			newItem: () =>
@@ -66,7 +69,7 @@ export class APLRotationPicker extends Component {
 				listPicker: ListPicker<Player<any>, APLListItem>,
 				index: number,
 				config: ListItemPickerConfig<Player<any>, APLListItem>,
-			) => new APLListItemPicker(parent, modPlayer, config, index),
+			) => new APLListItemPicker(parent, modPlayer, config, index, showWarnings),
 			inlineMenuBar: true,
 		});
 
@@ -90,13 +93,15 @@ class APLPrepullActionPicker extends Input<Player<any>, APLPrepullAction> {
 		);
 	}
 
-	constructor(parent: HTMLElement, player: Player<any>, config: ListItemPickerConfig<Player<any>, APLPrepullAction>, index: number) {
+	constructor(parent: HTMLElement, player: Player<any>, config: ListItemPickerConfig<Player<any>, APLPrepullAction>, index: number, showWarnings: boolean) {
 		config.enableWhen = () => !this.getItem().hide;
 		super(parent, 'apl-list-item-picker-root', player, config);
 		this.player = player;
 
 		const itemHeaderElem = ListPicker.getItemHeaderElem(this);
-		makeListItemWarnings(itemHeaderElem, player, player => player.getCurrentStats().rotationStats?.prepullActions[index]?.warnings || []);
+		if (showWarnings) {
+			makeListItemWarnings(itemHeaderElem, player, player => player.getCurrentStats().rotationStats?.prepullActions[index]?.warnings || []);
+		}
 
 		this.hidePicker = new HidePicker(itemHeaderElem, player, {
 			changedEvent: () => this.player.rotationChangeEmitter,
@@ -190,13 +195,15 @@ class APLListItemPicker extends Input<Player<any>, APLListItem> {
 		);
 	}
 
-	constructor(parent: HTMLElement, player: Player<any>, config: ListItemPickerConfig<Player<any>, APLListItem>, index: number) {
+	constructor(parent: HTMLElement, player: Player<any>, config: ListItemPickerConfig<Player<any>, APLListItem>, index: number, showWarnings: boolean) {
 		config.enableWhen = () => !this.getItem().hide;
 		super(parent, 'apl-list-item-picker-root', player, config);
 		this.player = player;
 
 		const itemHeaderElem = ListPicker.getItemHeaderElem(this);
-		makeListItemWarnings(itemHeaderElem, player, player => player.getCurrentStats().rotationStats?.priorityList[index]?.warnings || []);
+		if (showWarnings) {
+			makeListItemWarnings(itemHeaderElem, player, player => player.getCurrentStats().rotationStats?.priorityList[index]?.warnings || []);
+		}
 
 		this.hidePicker = new HidePicker(itemHeaderElem, player, {
 			changedEvent: () => this.player.rotationChangeEmitter,
@@ -44,6 +44,7 @@ import {
 	APLValueMin,
 	APLValueNot,
 	APLValueNumberTargets,
+	APLValueThreatPercentOfTank,
 	APLValueOr,
 	APLValueRemainingTime,
 	APLValueRemainingTimePercent,
@@ -588,6 +589,16 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueNumberTargets.create,
 		fields: [],
 	}),
+	threatPercentOfTank: inputBuilder({
+		label: 'Threat (% of Tank)',
+		submenu: ['Encounter'],
+		shortDescription: "Your threat on the target as a percentage of the tank's. Aggro is pulled above 110% in melee range, or 130% at range.",
+		fullDescription: `
+			<p>Always 0 unless the raid has a threat ceiling configured.</p>
+		`,
+		newValue: APLValueThreatPercentOfTank.create,
+		fields: [AplHelpers.unitFieldConfig('targetUnit', 'targets')],
+	}),
 	frontOfTarget: inputBuilder({
 		label: 'Front of Target',
 		submenu: ['Encounter'],
@@ -5,9 +5,12 @@ import { ItemSlot, Spec } from '../../proto/common.js';
 import { SimUI } from '../../sim_ui.jsx';
 import { EventID, TypedEvent } from '../../typed_event.js';
 import { BooleanPicker } from '../boolean_picker.js';
+import { ItemSwapGear } from '../../proto_utils/gear.js';
 import { Component } from '../component.js';
 import { IconItemSwapPicker } from '../gear_picker/icon_item_swap_picker.jsx';
 import { Input } from '../input.jsx';
+import { AdaptiveStringPicker } from '../inputs/string_picker.js';
+import { ListItemPickerConfig, ListPicker } from '../list_picker.jsx';
 
 export interface ItemSwapConfig {
 	itemSlots: Array<ItemSlot>;
@@ -80,6 +83,27 @@ export class ItemSwapPicker<SpecType extends Spec> extends Component {
 		this.itemSlots.forEach(itemSlot => {
 			new IconItemSwapPicker(itemSwapContainer, simUI, player, itemSlot);
 		});
+
+		new ListPicker<Player<SpecType>, ItemSwapGear>(swapPickerContainer, player, {
+			extraCssClasses: ['item-swap-sets-picker'],
+			title: 'Additional Swap Sets',
+			titleTooltip: 'Named swap sets, which the <b>Item Swap</b> APL action selects with its <b>Set Name</b>.',
+			itemLabel: 'Swap Set',
+			changedEvent: (player: Player<SpecType>) => player.itemSwapChangeEmitter,
+			getValue: (player: Player<SpecType>) => player.getItemSwapSets(),
+			setValue: (eventID: EventID, player: Player<SpecType>, newValue: Array<ItemSwapGear>) => {
+				player.setItemSwapSets(eventID, newValue);
+			},
+			newItem: () => new ItemSwapGear({}, `Swap ${player.getItemSwapSets().length + 2}`),
+			copyItem: (oldItem: ItemSwapGear) => oldItem,
+			newItemPicker: (
+				parent: HTMLElement,
+				_listPicker: ListPicker<Player<SpecType>, ItemSwapGear>,
+				index: number,
+				config: ListItemPickerConfig<Player<SpecType>, ItemSwapGear>,
+			) => new ItemSwapSetPicker(parent, simUI, player, this.itemSlots, index + 1, config),
+			allowedActions: ['create', 'delete'],
+		});
 	}
 
 	swapWithGear(eventID: EventID, player: Player<SpecType>) {
@@ -100,3 +124,59 @@ export class ItemSwapPicker<SpecType extends Spec> extends Component {
 		});
 	}
 }
+
+// Picker for one of the additional swap sets, selected by its index in
+// Player.getItemSwapSet.
+class ItemSwapSetPicker<SpecType extends Spec> extends Input<Player<SpecType>, ItemSwapGear> {
+	private readonly player: Player<SpecType>;
+	private readonly setIndex: number;
+	private readonly namePicker: Input<null, string>;
+
+	constructor(
+		parent: HTMLElement,
+		simUI: SimUI,
+		player: Player<SpecType>,
+		itemSlots: Array<ItemSlot>,
+		setIndex: number,
+		config: ListItemPickerConfig<Player<SpecType>, ItemSwapGear>,
+	) {
+		super(parent, 'item-swap-set-picker-root', player, config);
+		this.player = player;
+		this.setIndex = setIndex;
+
+		this.namePicker = new AdaptiveStringPicker<null>(this.rootElem, null, {
+			id: `item-swap-set-name-${setIndex}`,
+			label: 'Set Name',
+			extraCssClasses: ['input-inline'],
+			changedEvent: () => player.itemSwapChangeEmitter,
+			getValue: () => player.getItemSwapSet(this.setIndex).name,
+			setValue: (eventID: EventID, _: null, newValue: string) => {
+				player.setItemSwapSet(eventID, this.setIndex, player.getItemSwapSet(this.setIndex).withName(newValue));
+			},
+		});
+
+		const itemSwapContainer = Input.newGroupContainer();
+		itemSwapContainer.classList.add('icon-group');
+		this.rootElem.appendChild(itemSwapContainer);
+		itemSlots.forEach(itemSlot => {
+			new IconItemSwapPicker(itemSwapContainer, simUI, player, itemSlot, setIndex);
+		});
+
+		this.init();
+	}
+
+	getInputElem(): HTMLElement | null {
+		return null;
+	}
+
+	getInputValue(): ItemSwapGear {
+		return this.player.getItemSwapSet(this.setIndex).withName(this.namePicker.getInputValue());
+	}
+
+	setInputValue(newValue: ItemSwapGear) {
+		if (!newValue) {
+			return;
+		}
+		this.namePicker.setInputValue(newValue.name);
+	}
+}
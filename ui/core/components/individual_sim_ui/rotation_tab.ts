@@ -110,6 +110,13 @@ export class RotationTab extends SimTab {
 		this.leftPanel.appendChild(content);
 
 		new APLRotationPicker(content, this.simUI, this.simUI.player);
+
+		(this.simUI.individualConfig.petRotations || []).forEach(petName => {
+			const petContentBlock = new ContentBlock(content, 'pet-rotation-settings', {
+				header: { title: `${petName} Rotation`, tooltip: Tooltips.PET_ROTATION_SECTION },
+			});
+			new APLRotationPicker(petContentBlock.bodyElement, this.simUI, this.simUI.player, player => player.getPetRotation(petName));
+		});
 	}
 
 	private buildSimpleContent() {
@@ -3,7 +3,21 @@ import * as Tooltips from '../../constants/tooltips';
 import { Encounter } from '../../encounter';
 import { IndividualSimUI, InputSection } from '../../individual_sim_ui';
 import { Player } from '../../player';
-import { Consumes, Debuffs, HealingModel, IndividualBuffs, ItemSwap, PartyBuffs, Profession, RaidBuffs, Spec } from '../../proto/common';
+import {
+	Consumes,
+	DamageProfile,
+	Debuffs,
+	HealingModel,
+	IndividualBuffs,
+	ItemSwap,
+	LatencyModel,
+	PartyBuffs,
+	Profession,
+	RaidBuffs,
+	ReactionTimeDistribution,
+	Spec,
+	Vector2,
+} from '../../proto/common';
 import { SavedEncounter, SavedSettings } from '../../proto/ui';
 import { professionNames, raceNames } from '../../proto_utils/names';
 import { specToEligibleRaces } from '../../proto_utils/utils';
@@ -22,7 +36,7 @@ import { MultiIconPicker, MultiIconPickerItemConfig } from '../multi_icon_picker
 import { NumberPicker } from '../number_picker';
 import { SavedDataManager } from '../saved_data_manager';
 import { SimTab } from '../sim_tab';
-import { IsbConfig } from './../other_inputs';
+import { IsbConfig, PositionX, PositionY } from './../other_inputs';
 import { ConsumesPicker } from './consumes_picker';
 import { ItemSwapPicker } from './item_swap_picker';
 import { PresetBuildsPicker } from './preset_builds_picker';
@@ -74,6 +88,7 @@ export class SettingsTab extends SimTab {
 			this.buildCustomSettingsSections();
 			this.buildConsumesSection();
 			this.buildOtherSettings();
+			this.buildLatencySettings();
 			this.buildIsbSettings();
 
 			if (!this.simUI.isWithinRaidSim) {
@@ -200,24 +215,84 @@ export class SettingsTab extends SimTab {
 
 		const itemSwapConfig = this.simUI.individualConfig.itemSwapConfig;
 
-		if (settings.length || itemSwapConfig?.itemSlots.length) {
-			const contentBlock = new ContentBlock(this.column2, 'other-settings', {
-				header: { title: 'Other' },
-			});
+		const contentBlock = new ContentBlock(this.column2, 'other-settings', {
+			header: { title: 'Other' },
+		});
 
-			if (settings.length) {
-				this.configureInputSection(contentBlock.bodyElement, this.simUI.individualConfig.otherInputs);
-				contentBlock.bodyElement.querySelectorAll('.input-root').forEach(elem => {
-					elem.classList.add('input-inline');
-				});
-			}
+		if (settings.length) {
+			this.configureInputSection(contentBlock.bodyElement, this.simUI.individualConfig.otherInputs);
+		}
+		// Every spec gets a starting position, shown when the encounter uses positions.
+		this.configureInputSection(contentBlock.bodyElement, { inputs: [PositionX, PositionY] });
+		contentBlock.bodyElement.querySelectorAll('.input-root').forEach(elem => {
+			elem.classList.add('input-inline');
+		});
 
-			if (itemSwapConfig?.itemSlots.length) {
-				new ItemSwapPicker(contentBlock.bodyElement, this.simUI, this.simUI.player, itemSwapConfig);
-			}
+		if (itemSwapConfig?.itemSlots.length) {
+			new ItemSwapPicker(contentBlock.bodyElement, this.simUI, this.simUI.player, itemSwapConfig);
 		}
 	}
 
+	private buildLatencySettings() {
+		const contentBlock = new ContentBlock(this.column2, 'latency-settings', {
+			header: { title: 'Latency', tooltip: Tooltips.LATENCY_SECTION },
+		});
+
+		type LatencyField = 'pingMs' | 'spellQueueWindowMs' | 'reactionTimeStdevMs';
+		const latencyPicker = (config: { id: string; label: string; labelTooltip: string; field: LatencyField }) =>
+			new NumberPicker(contentBlock.bodyElement, this.simUI.player, {
+				id: config.id,
+				label: config.label,
+				labelTooltip: config.labelTooltip,
+				positive: true,
+				changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+				getValue: (player: Player<any>) => player.getLatency()[config.field],
+				setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+					const latency = player.getLatency();
+					latency[config.field] = newValue;
+					player.setLatency(eventID, latency);
+				},
+			});
+
+		latencyPicker({
+			id: 'latency-ping',
+			label: 'Ping (ms)',
+			labelTooltip: 'Round trip time to the server.',
+			field: 'pingMs',
+		});
+		latencyPicker({
+			id: 'latency-spell-queue-window',
+			label: 'Spell Queue Window (ms)',
+			labelTooltip: 'How long before the end of a GCD or cast the next cast can be queued. Queued casts go through without paying the ping.',
+			field: 'spellQueueWindowMs',
+		});
+
+		new EnumPicker(contentBlock.bodyElement, this.simUI.player, {
+			id: 'latency-reaction-time-distribution',
+			label: 'Reaction Time Distribution',
+			labelTooltip: 'How reaction times are drawn, around the Reaction Time as the mean.',
+			values: [
+				{ name: 'Fixed', value: ReactionTimeDistribution.ReactionTimeFixed },
+				{ name: 'Normal', value: ReactionTimeDistribution.ReactionTimeNormal },
+				{ name: 'Lognormal', value: ReactionTimeDistribution.ReactionTimeLognormal },
+			],
+			changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+			getValue: (player: Player<any>) => player.getLatency().reactionTimeDistribution,
+			setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+				const latency = player.getLatency();
+				latency.reactionTimeDistribution = newValue;
+				player.setLatency(eventID, latency);
+			},
+		});
+
+		latencyPicker({
+			id: 'latency-reaction-time-stdev',
+			label: 'Reaction Time Stdev (ms)',
+			labelTooltip: 'Standard deviation of the reaction time, when it is not fixed.',
+			field: 'reactionTimeStdevMs',
+		});
+	}
+
 	private buildIsbSettings() {
 		if (!this.simUI.isWithinRaidSim) {
 			const contentBlock = new ContentBlock(this.column1, 'other-settings', {
@@ -343,11 +418,15 @@ export class SettingsTab extends SimTab {
 					professions: player.getProfessions(),
 					enableItemSwap: player.getEnableItemSwap(),
 					itemSwap: player.getItemSwapGear().toProto(),
+					itemSwapSets: player.getItemSwapSets().map(itemSwapGear => itemSwapGear.toProto()),
 					reactionTimeMs: player.getReactionTime(),
 					channelClipDelayMs: player.getChannelClipDelay(),
+					latency: player.getLatency(),
 					inFrontOfTarget: player.getInFrontOfTarget(),
 					distanceFromTarget: player.getDistanceFromTarget(),
+					position: player.getPosition(),
 					healingModel: player.getHealingModel(),
+					damageProfile: player.getDamageProfile(),
 				});
 			},
 			setData: (eventID: EventID, simUI: IndividualSimUI<any>, newSettings: SavedSettings) => {
@@ -365,11 +444,15 @@ export class SettingsTab extends SimTab {
 					simUI.player.setProfessions(eventID, newSettings.professions);
 					simUI.player.setEnableItemSwap(eventID, newSettings.enableItemSwap);
 					simUI.player.setItemSwapGear(eventID, simUI.sim.db.lookupItemSwap(newSettings.itemSwap || ItemSwap.create()));
+					simUI.player.setItemSwapSets(eventID, newSettings.itemSwapSets.map(itemSwap => simUI.sim.db.lookupItemSwap(itemSwap)));
 					simUI.player.setReactionTime(eventID, newSettings.reactionTimeMs);
 					simUI.player.setChannelClipDelay(eventID, newSettings.channelClipDelayMs);
+					simUI.player.setLatency(eventID, newSettings.latency || LatencyModel.create());
 					simUI.player.setInFrontOfTarget(eventID, newSettings.inFrontOfTarget);
 					simUI.player.setDistanceFromTarget(eventID, newSettings.distanceFromTarget);
+					simUI.player.setPosition(eventID, newSettings.position || Vector2.create());
 					simUI.player.setHealingModel(eventID, newSettings.healingModel || HealingModel.create());
+					simUI.player.setDamageProfile(eventID, newSettings.damageProfile || DamageProfile.create());
 				});
 			},
 			changeEmitters: [
@@ -385,6 +468,7 @@ export class SettingsTab extends SimTab {
 				this.simUI.player.inFrontOfTargetChangeEmitter,
 				this.simUI.player.distanceFromTargetChangeEmitter,
 				this.simUI.player.healingModelChangeEmitter,
+				this.simUI.player.damageProfileChangeEmitter,
 			],
 			equals: (a: SavedSettings, b: SavedSettings) => SavedSettings.equals(a, b),
 			toJson: (a: SavedSettings) => SavedSettings.toJson(a),
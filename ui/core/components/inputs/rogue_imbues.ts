@@ -34,7 +34,7 @@ export const DeadlyPoisonWeaponImbue: ConsumableInputConfig<WeaponImbue> = {
 export const WoundPoisonWeaponImbue: ConsumableInputConfig<WeaponImbue> = {
 	actionId: player =>
 		player.getMatchingItemActionId([
-			{ id: 10918, minLevel: 32, maxLevel: 39 },
+			{ id: 10918, minLevel: 25, maxLevel: 39 },
 			{ id: 10920, minLevel: 40, maxLevel: 47 },
 			{ id: 10921, minLevel: 48, maxLevel: 55 },
 			{ id: 10922, minLevel: 56, maxLevel: 60 },
@@ -125,6 +125,34 @@ export const DistanceFromTarget = {
 	},
 };
 
+export const PositionX = {
+	id: 'position-x',
+	type: 'number' as const,
+	label: 'Position X',
+	labelTooltip: 'Starting X coordinate, in yards. Only used when the encounter uses positions. Standing on the target uses In Front of Target.',
+	float: true,
+	changedEvent: (player: Player<any>) => TypedEvent.onAny([player.distanceFromTargetChangeEmitter, player.sim.encounter.changeEmitter]),
+	getValue: (player: Player<any>) => player.getPosition().x,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		player.setPosition(eventID, { ...player.getPosition(), x: newValue });
+	},
+	showWhen: (player: Player<any>) => player.sim.encounter.getUsePositions(),
+};
+
+export const PositionY = {
+	id: 'position-y',
+	type: 'number' as const,
+	label: 'Position Y',
+	labelTooltip: 'Starting Y coordinate, in yards. Only used when the encounter uses positions. Standing on the target uses In Front of Target.',
+	float: true,
+	changedEvent: (player: Player<any>) => TypedEvent.onAny([player.distanceFromTargetChangeEmitter, player.sim.encounter.changeEmitter]),
+	getValue: (player: Player<any>) => player.getPosition().y,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		player.setPosition(eventID, { ...player.getPosition(), y: newValue });
+	},
+	showWhen: (player: Player<any>) => player.sim.encounter.getUsePositions(),
+};
+
 export const IsbUsingShadowflame = {
 	id: 'isb-using-shadowflame',
 	type: 'boolean' as const,
@@ -236,6 +264,9 @@ export const TankAssignment = {
 	},
 };
 
+const isTankOrTakesDamage = (player: Player<any>): boolean =>
+	player.hasDamageProfile() || (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null;
+
 export const IncomingHps = {
 	id: 'incoming-hps',
 	type: 'number' as const,
@@ -251,7 +282,7 @@ export const IncomingHps = {
 		healingModel.hps = newValue;
 		player.setHealingModel(eventID, healingModel);
 	},
-	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
+	enableWhen: (player: Player<any>) => isTankOrTakesDamage(player),
 };
 
 export const HealingCadence = {
@@ -271,7 +302,7 @@ export const HealingCadence = {
 		healingModel.cadenceSeconds = newValue;
 		player.setHealingModel(eventID, healingModel);
 	},
-	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
+	enableWhen: (player: Player<any>) => isTankOrTakesDamage(player),
 };
 
 export const HealingCadenceVariation = {
@@ -291,7 +322,7 @@ export const HealingCadenceVariation = {
 		healingModel.cadenceVariation = newValue;
 		player.setHealingModel(eventID, healingModel);
 	},
-	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
+	enableWhen: (player: Player<any>) => isTankOrTakesDamage(player),
 };
 
 export const BurstWindow = {
@@ -313,6 +344,67 @@ export const BurstWindow = {
 	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
 };
 
+const makeIncomingDtpsInput = (id: string, label: string, field: 'physicalDtps' | 'arcaneDtps' | 'fireDtps' | 'frostDtps' | 'natureDtps' | 'shadowDtps') => ({
+	id: id,
+	type: 'number' as const,
+	label: label,
+	labelTooltip: `
+		<p>Average damage per second taken from this school when not tanking, before armor and resistances, e.g. from raid-wide AoE.</p>
+		<p class="mb-0">The damage is dealt by the primary target and triggers effects depending on damage taken, such as rage generation.</p>
+	`,
+	changedEvent: (player: Player<any>) => player.damageProfileChangeEmitter,
+	getValue: (player: Player<any>) => player.getDamageProfile()[field],
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const damageProfile = player.getDamageProfile();
+		damageProfile[field] = newValue;
+		player.setDamageProfile(eventID, damageProfile);
+	},
+});
+
+export const IncomingPhysicalDtps = makeIncomingDtpsInput('incoming-physical-dtps', 'Incoming Physical DTPS', 'physicalDtps');
+export const IncomingArcaneDtps = makeIncomingDtpsInput('incoming-arcane-dtps', 'Incoming Arcane DTPS', 'arcaneDtps');
+export const IncomingFireDtps = makeIncomingDtpsInput('incoming-fire-dtps', 'Incoming Fire DTPS', 'fireDtps');
+export const IncomingFrostDtps = makeIncomingDtpsInput('incoming-frost-dtps', 'Incoming Frost DTPS', 'frostDtps');
+export const IncomingNatureDtps = makeIncomingDtpsInput('incoming-nature-dtps', 'Incoming Nature DTPS', 'natureDtps');
+export const IncomingShadowDtps = makeIncomingDtpsInput('incoming-shadow-dtps', 'Incoming Shadow DTPS', 'shadowDtps');
+
+export const IncomingDamageCadence = {
+	id: 'incoming-damage-cadence',
+	type: 'number' as const,
+	float: true,
+	label: 'Incoming Damage Cadence',
+	labelTooltip: `
+		<p>How often incoming damage is taken, in seconds. The damage of each hit is scaled by the time since the last one, to keep DTPS constant.</p>
+		<p class="mb-0">If set to 0, defaults to 2s.</p>
+	`,
+	changedEvent: (player: Player<any>) => player.damageProfileChangeEmitter,
+	getValue: (player: Player<any>) => player.getDamageProfile().cadenceSeconds,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const damageProfile = player.getDamageProfile();
+		damageProfile.cadenceSeconds = newValue;
+		player.setDamageProfile(eventID, damageProfile);
+	},
+	enableWhen: (player: Player<any>) => player.hasDamageProfile(),
+};
+
+export const IncomingDamageVariation = {
+	id: 'incoming-damage-variation',
+	type: 'number' as const,
+	float: true,
+	label: 'Incoming Damage +/- %',
+	labelTooltip: `
+		<p>Random variation in the size of each incoming hit, as a % of its average size.</p>
+	`,
+	changedEvent: (player: Player<any>) => player.damageProfileChangeEmitter,
+	getValue: (player: Player<any>) => player.getDamageProfile().damageVariation * 100,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const damageProfile = player.getDamageProfile();
+		damageProfile.damageVariation = Math.min(Math.max(newValue / 100, 0), 1);
+		player.setDamageProfile(eventID, damageProfile);
+	},
+	enableWhen: (player: Player<any>) => player.hasDamageProfile(),
+};
+
 export const HpPercentForDefensives = {
 	id: 'hp-percent-for-defensives',
 	type: 'number' as const,
@@ -32,6 +32,7 @@ export type ReferenceData = {
 };
 
 export interface ResultMetrics {
+	buffs: string;
 	cod: string;
 	dps: string;
 	dpasp: string;
@@ -61,6 +62,7 @@ export class RaidSimResultsManager {
 	static resultMetricCategories: { [ResultMetrics: string]: keyof ResultMetricCategories } = {
 		dps: 'damage',
 		dpasp: 'demo',
+		buffs: 'damage',
 		tps: 'threat',
 		dtps: 'threat',
 		tmi: 'threat',
@@ -70,6 +72,7 @@ export class RaidSimResultsManager {
 	};
 
 	static resultMetricClasses: { [ResultMetrics: string]: string } = {
+		buffs: 'results-sim-buffs',
 		cod: 'results-sim-cod',
 		dps: 'results-sim-dps',
 		dpasp: 'results-sim-dpasp',
@@ -168,6 +171,7 @@ export class RaidSimResultsManager {
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['hps']}`, 'Healing+Shielding Per Second, including overhealing.');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['tps']}`, 'Threat Per Second');
 		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['dtps']}`, 'Damage Taken Per Second');
+		setResultTooltip(`.${RaidSimResultsManager.resultMetricClasses['buffs']}`, 'Raid DPS gained from the buffs and debuffs this player provides');
 		setResultTooltip(
 			`.${RaidSimResultsManager.resultMetricClasses['tmi']}`,
 			<>
@@ -496,6 +500,15 @@ export class RaidSimResultsManager {
 				stdev: hpsMetrics.stdev,
 				classes: this.getResultsLineClasses('hps'),
 			});
+
+			// Raid DPS gained from the buffs each player provides.
+			simResult.result.raidMetrics?.buffContributions.forEach(contribution => {
+				resultColumns.push({
+					name: `${contribution.name} Buffs`,
+					average: contribution.raidDps,
+					classes: this.getResultsLineClasses('buffs'),
+				});
+			});
 		}
 
 		if (simResult.request.encounter?.useHealth) {
@@ -55,6 +55,7 @@ class EpWeightsMenu extends BaseModal {
 	private epPseudoStats: Array<PseudoStat>;
 	private epReferenceStat: Stat;
 	private showAllStats = false;
+	private effectiveHealing = false;
 
 	constructor(simUI: IndividualSimUI<any>, epStats: Array<Stat>, epPseudoStats: Array<PseudoStat>, epReferenceStat: Stat) {
 		super(simUI.rootElem, 'ep-weights-menu', getModalConfig(simUI));
@@ -74,6 +75,7 @@ class EpWeightsMenu extends BaseModal {
 					</select>
 				</div>
 				<div class="show-all-stats-container col col-sm-3"></div>
+				<div class="effective-healing-container col col-sm-3 healing-metrics"></div>
 			</div>
 			<div class="ep-reference-options row experimental">
 				<div class="col col-sm-4 damage-metrics">
@@ -311,6 +313,7 @@ class EpWeightsMenu extends BaseModal {
 				this.epStats,
 				this.epPseudoStats,
 				this.epReferenceStat,
+				this.effectiveHealing,
 				(progress: ProgressMetrics) => {
 					this.setSimProgress(progress);
 				},
@@ -448,6 +451,19 @@ class EpWeightsMenu extends BaseModal {
 			},
 		});
 
+		const effectiveHealingContainer = this.rootElem.getElementsByClassName('effective-healing-container')[0] as HTMLElement;
+		new BooleanPicker(effectiveHealingContainer, this, {
+			id: 'ep-effective-healing',
+			label: 'Effective Healing',
+			labelTooltip: 'Calculate HPS weights from effective healing, excluding overhealing.',
+			inline: true,
+			changedEvent: () => new TypedEvent(),
+			getValue: () => this.effectiveHealing,
+			setValue: (eventID: EventID, menu: EpWeightsMenu, newValue: boolean) => {
+				this.effectiveHealing = newValue;
+			},
+		});
+
 		this.updateTable();
 
 		const makeEpRatioCell = (cell: HTMLElement, idx: number) => {
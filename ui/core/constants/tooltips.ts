@@ -2,8 +2,11 @@ export const BUFFS_SECTION =
 	'Buffs provided by other party/raid members. Note only the highest available buff rank will be applied, if possible, based on level selected';
 export const WORLD_BUFFS_SECTION = 'World Buffs obtained from various sources across Azeroth.';
 export const DEBUFFS_SECTION = 'Debuffs applied by other raid members.';
+export const LATENCY_SECTION = 'Delays between the player and the game server. Reaction times are drawn around the Reaction Time setting, plus ping.';
 export const COOLDOWNS_SECTION =
 	'Specify cooldown timings, in seconds. Cooldowns will be used as soon as possible after their specified timings. When not specified, cooldowns will be used when ready and it is sensible to do so.<br><br>Multiple timings can be provided by separating with commas. Any cooldown usages after the last provided timing will use the default logic.';
+export const PET_ROTATION_SECTION =
+	"Actions for this pet to perform, using the same priority list as the player's APL. When the list is empty, the pet uses its default rotation.";
 export const BLESSINGS_SECTION =
 	'Specify Paladin Blessings for each role, in order of priority. Blessings in the 1st column will be used if there is at least 1 Paladin in the raid, 2nd column if at least 2, etc.';
 
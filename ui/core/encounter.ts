@@ -1,8 +1,9 @@
 import * as Mechanics from './constants/mechanics.js';
 import { UnitMetadataList } from './player.js';
-import { Encounter as EncounterProto, PresetEncounter, PresetTarget, Target as TargetProto } from './proto/common.js';
+import { DurationDistribution, Encounter as EncounterProto, PresetEncounter, PresetTarget, Target as TargetProto } from './proto/common.js';
 import { Sim } from './sim.js';
 import { EventID, TypedEvent } from './typed_event.js';
+import { arrayEquals } from './utils.js';
 
 const DEFAULT_DURATION = 120;
 const DEFAULT_VARIATION = 15;
@@ -16,10 +17,14 @@ export class Encounter {
 
 	private duration = DEFAULT_DURATION;
 	private durationVariation = DEFAULT_VARIATION;
+	private durationDistribution = DurationDistribution.DurationUniform;
+	private killTimes: Array<number> = [];
 	private executeProportion20 = DEFAULT_EXECUTE_20;
 	private executeProportion25 = DEFAULT_EXECUTE_25;
 	private executeProportion35 = DEFAULT_EXECUTE_35;
+	private fixedExecuteDuration = false;
 	private useHealth = false;
+	private usePositions = false;
 
 	targets!: Array<TargetProto>;
 	targetsMetadata: UnitMetadataList;
@@ -62,6 +67,26 @@ export class Encounter {
 		this.durationChangeEmitter.emit(eventID);
 	}
 
+	getDurationDistribution(): DurationDistribution {
+		return this.durationDistribution;
+	}
+	setDurationDistribution(eventID: EventID, newDurationDistribution: DurationDistribution) {
+		if (newDurationDistribution == this.durationDistribution) return;
+
+		this.durationDistribution = newDurationDistribution;
+		this.durationChangeEmitter.emit(eventID);
+	}
+
+	getKillTimes(): Array<number> {
+		return this.killTimes.slice();
+	}
+	setKillTimes(eventID: EventID, newKillTimes: Array<number>) {
+		if (arrayEquals(newKillTimes, this.killTimes)) return;
+
+		this.killTimes = newKillTimes.slice();
+		this.durationChangeEmitter.emit(eventID);
+	}
+
 	getDuration(): number {
 		return this.duration;
 	}
@@ -100,6 +125,16 @@ export class Encounter {
 		this.executeProportionChangeEmitter.emit(eventID);
 	}
 
+	getFixedExecuteDuration(): boolean {
+		return this.fixedExecuteDuration;
+	}
+	setFixedExecuteDuration(eventID: EventID, newFixedExecuteDuration: boolean) {
+		if (newFixedExecuteDuration == this.fixedExecuteDuration) return;
+
+		this.fixedExecuteDuration = newFixedExecuteDuration;
+		this.executeProportionChangeEmitter.emit(eventID);
+	}
+
 	getUseHealth(): boolean {
 		return this.useHealth;
 	}
@@ -111,6 +146,16 @@ export class Encounter {
 		this.executeProportionChangeEmitter.emit(eventID);
 	}
 
+	getUsePositions(): boolean {
+		return this.usePositions;
+	}
+	setUsePositions(eventID: EventID, newUsePositions: boolean) {
+		if (newUsePositions == this.usePositions) return;
+
+		this.usePositions = newUsePositions;
+		this.targetsChangeEmitter.emit(eventID);
+	}
+
 	matchesPreset(preset: PresetEncounter): boolean {
 		return preset.targets.length == this.targets.length && this.targets.every((t, i) => TargetProto.equals(t, preset.targets[i].target));
 	}
@@ -129,10 +174,14 @@ export class Encounter {
 		return EncounterProto.create({
 			duration: this.duration,
 			durationVariation: this.durationVariation,
+			durationDistribution: this.durationDistribution,
+			killTimes: this.killTimes,
 			executeProportion20: this.executeProportion20,
 			executeProportion25: this.executeProportion25,
 			executeProportion35: this.executeProportion35,
+			fixedExecuteDuration: this.fixedExecuteDuration,
 			useHealth: this.useHealth,
+			usePositions: this.usePositions,
 			targets: this.targets,
 		});
 	}
@@ -141,10 +190,14 @@ export class Encounter {
 		TypedEvent.freezeAllAndDo(() => {
 			this.setDuration(eventID, proto.duration);
 			this.setDurationVariation(eventID, proto.durationVariation);
+			this.setDurationDistribution(eventID, proto.durationDistribution);
+			this.setKillTimes(eventID, proto.killTimes);
 			this.setExecuteProportion20(eventID, proto.executeProportion20);
 			this.setExecuteProportion25(eventID, proto.executeProportion25);
 			this.setExecuteProportion35(eventID, proto.executeProportion35);
+			this.setFixedExecuteDuration(eventID, proto.fixedExecuteDuration);
 			this.setUseHealth(eventID, proto.useHealth);
+			this.setUsePositions(eventID, proto.usePositions);
 			this.targets = proto.targets;
 			this.targetsChangeEmitter.emit(eventID);
 		});
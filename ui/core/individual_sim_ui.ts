@@ -20,7 +20,7 @@ import * as Tooltips from './constants/tooltips';
 import { simLaunchStatuses } from './launched_sims';
 import { Player, PlayerConfig, registerSpecConfig as registerPlayerConfig } from './player';
 import { PresetBuild, PresetGear, PresetRotation } from './preset_utils';
-import { StatWeightsResult } from './proto/api';
+import { StatWeightsResult, ThreatCeiling } from './proto/api';
 import { APLRotation_Type as APLRotationType } from './proto/apl';
 import {
 	Consumes,
@@ -126,6 +126,8 @@ export interface IndividualSimUIConfig<SpecType extends Spec> extends PlayerConf
 	petConsumeInputs?: Array<IconInputs.IconInputConfig<Player<SpecType>, any>>;
 	rotationInputs?: InputSection;
 	rotationIconInputs?: Array<IconInputs.IconInputConfig<Player<any>, any>>;
+	// Names of pets that can be given their own APL rotation in the rotation tab.
+	petRotations?: Array<string>;
 	includeBuffDebuffInputs: Array<any>;
 	excludeBuffDebuffInputs: Array<any>;
 	otherInputs: InputSection;
@@ -402,6 +404,7 @@ export abstract class IndividualSimUI<SpecType extends Spec> extends SimUI {
 			this.player.setLevel(eventID, LEVEL_THRESHOLDS[simLaunchStatuses[this.player.spec].phase]);
 			this.player.setGear(eventID, this.sim.db.lookupEquipmentSpec(this.individualConfig.defaults.gear));
 			this.player.setItemSwapGear(eventID, new ItemSwapGear({}));
+			this.player.setItemSwapSets(eventID, []);
 			this.player.setConsumes(eventID, this.individualConfig.defaults.consumes);
 			this.player.setTalentsString(eventID, this.individualConfig.defaults.talents.talentsString);
 			this.player.setSpecOptions(eventID, this.individualConfig.defaults.specOptions);
@@ -479,6 +482,7 @@ export abstract class IndividualSimUI<SpecType extends Spec> extends SimUI {
 				raidBuffs: this.sim.raid.getBuffs(),
 				debuffs: this.sim.raid.getDebuffs(),
 				targetDummies: this.sim.raid.getTargetDummies(),
+				threatCeiling: this.sim.raid.getThreatCeiling(),
 			});
 		}
 		if (exportCategory(SimSettingCategories.UISettings)) {
@@ -523,6 +527,7 @@ export abstract class IndividualSimUI<SpecType extends Spec> extends SimUI {
 					party.setBuffs(eventID, settings.partyBuffs || PartyBuffs.create());
 				}
 				this.sim.raid.setTargetDummies(eventID, settings.targetDummies);
+				this.sim.raid.setThreatCeiling(eventID, settings.threatCeiling || ThreatCeiling.create());
 			}
 			if (loadCategory(SimSettingCategories.Encounter)) {
 				this.sim.encounter.fromProto(eventID, settings.encounter || EncounterProto.create());
@@ -16,12 +16,14 @@ import {
 	Class,
 	Consumes,
 	Cooldowns,
+	DamageProfile,
 	Faction,
 	HandType,
 	HealingModel,
 	IndividualBuffs,
 	ItemRandomSuffix,
 	ItemSlot,
+	LatencyModel,
 	Profession,
 	PseudoStat,
 	Race,
@@ -30,6 +32,7 @@ import {
 	Stat,
 	UnitReference,
 	UnitStats,
+	Vector2,
 } from './proto/common.js';
 import {
 	DungeonFilterOption,
@@ -230,19 +233,26 @@ export class Player<SpecType extends Spec> {
 	//private bulkEquipmentSpec: BulkEquipmentSpec = BulkEquipmentSpec.create();
 	private enableItemSwap = false;
 	private itemSwapGear: ItemSwapGear = new ItemSwapGear({});
+	// Additional named swap sets, after itemSwapGear.
+	private itemSwapSets: Array<ItemSwapGear> = [];
 	private race: Race;
 	private level: number;
 	private profession1: Profession = 0;
 	private profession2: Profession = 0;
 	aplRotation: APLRotation = APLRotation.create();
+	// APL rotations for pets, keyed by pet name. Pets without one use their built-in rotation.
+	petRotations: { [name: string]: APLRotation } = {};
 	private talentsString = '';
 	private specOptions: SpecOptions<SpecType>;
 	private reactionTime = 0;
 	private channelClipDelay = 0;
+	private latency: LatencyModel = LatencyModel.create();
 	private inFrontOfTarget = false;
 	private distanceFromTarget = 0;
+	private position: Vector2 = Vector2.create();
 	private healingModel: HealingModel = HealingModel.create();
 	private healingEnabled = false;
+	private damageProfile: DamageProfile = DamageProfile.create();
 
 	private isbUsingShadowflame = true;
 	private isbSbFrequency = 3.0;
@@ -282,6 +292,7 @@ export class Player<SpecType extends Spec> {
 	readonly inFrontOfTargetChangeEmitter = new TypedEvent<void>('PlayerInFrontOfTarget');
 	readonly distanceFromTargetChangeEmitter = new TypedEvent<void>('PlayerDistanceFromTarget');
 	readonly healingModelChangeEmitter = new TypedEvent<void>('PlayerHealingModel');
+	readonly damageProfileChangeEmitter = new TypedEvent<void>('PlayerDamageProfile');
 	readonly epWeightsChangeEmitter = new TypedEvent<void>('PlayerEpWeights');
 	readonly miscOptionsChangeEmitter = new TypedEvent<void>('PlayerMiscOptions');
 
@@ -337,6 +348,7 @@ export class Player<SpecType extends Spec> {
 				this.inFrontOfTargetChangeEmitter,
 				this.distanceFromTargetChangeEmitter,
 				this.healingModelChangeEmitter,
+				this.damageProfileChangeEmitter,
 				this.epWeightsChangeEmitter,
 				this.epRatiosChangeEmitter,
 				this.epRefStatChangeEmitter,
@@ -478,9 +490,10 @@ export class Player<SpecType extends Spec> {
 		epStats: Array<Stat>,
 		epPseudoStats: Array<PseudoStat>,
 		epReferenceStat: Stat,
+		effectiveHealing: boolean,
 		onProgress: (_?: any) => void,
 	): Promise<StatWeightsResult> {
-		const result = await this.sim.statWeights(this, epStats, epPseudoStats, epReferenceStat, onProgress);
+		const result = await this.sim.statWeights(this, epStats, epPseudoStats, epReferenceStat, effectiveHealing, onProgress);
 		return result;
 	}
 
@@ -663,6 +676,33 @@ export class Player<SpecType extends Spec> {
 		this.itemSwapChangeEmitter.emit(eventID);
 	}
 
+	getItemSwapSets(): Array<ItemSwapGear> {
+		return this.itemSwapSets.slice();
+	}
+
+	setItemSwapSets(eventID: EventID, newItemSwapSets: Array<ItemSwapGear>) {
+		if (newItemSwapSets.length == this.itemSwapSets.length && newItemSwapSets.every((set, i) => set.equals(this.itemSwapSets[i]))) return;
+
+		this.itemSwapSets = newItemSwapSets.slice();
+		this.itemSwapChangeEmitter.emit(eventID);
+	}
+
+	// Returns the swap set with the given index, where 0 is the main item swap
+	// and later indices are the additional swap sets.
+	getItemSwapSet(index: number): ItemSwapGear {
+		return index == 0 ? this.itemSwapGear : this.itemSwapSets[index - 1] || new ItemSwapGear({});
+	}
+
+	setItemSwapSet(eventID: EventID, index: number, newItemSwapGear: ItemSwapGear) {
+		if (index == 0) {
+			this.setItemSwapGear(eventID, newItemSwapGear);
+		} else {
+			const newItemSwapSets = this.getItemSwapSets();
+			newItemSwapSets[index - 1] = newItemSwapGear;
+			this.setItemSwapSets(eventID, newItemSwapSets);
+		}
+	}
+
 	/*
 	setBulkEquipmentSpec(eventID: EventID, newBulkEquipmentSpec: BulkEquipmentSpec) {
 		if (BulkEquipmentSpec.equals(this.bulkEquipmentSpec, newBulkEquipmentSpec))
@@ -794,6 +834,25 @@ export class Player<SpecType extends Spec> {
 		this.rotationChangeEmitter.emit(eventID);
 	}
 
+	getPetRotation(petName: string): APLRotation {
+		if (!this.petRotations[petName]) {
+			this.petRotations[petName] = APLRotation.create({ type: APLRotationType.TypeAPL });
+		}
+		return this.petRotations[petName];
+	}
+
+	setPetRotations(eventID: EventID, newRotations: { [name: string]: APLRotation }) {
+		this.petRotations = Object.fromEntries(Object.entries(newRotations).map(([petName, rotation]) => [petName, APLRotation.clone(rotation)]));
+		this.rotationChangeEmitter.emit(eventID);
+	}
+
+	// Only pet rotations with actions are sent, so an empty editor keeps the built-in rotation.
+	private getNonEmptyPetRotations(): { [name: string]: APLRotation } {
+		return Object.fromEntries(
+			Object.entries(this.petRotations).filter(([, rotation]) => rotation.priorityList.length > 0 || rotation.prepullActions.length > 0),
+		);
+	}
+
 	getRotationType(): APLRotationType {
 		if (this.aplRotation.type == APLRotationType.TypeUnknown) {
 			return APLRotationType.TypeAPL;
@@ -889,6 +948,19 @@ export class Player<SpecType extends Spec> {
 		this.miscOptionsChangeEmitter.emit(eventID);
 	}
 
+	getLatency(): LatencyModel {
+		// Make a defensive copy
+		return LatencyModel.clone(this.latency);
+	}
+
+	setLatency(eventID: EventID, newLatency: LatencyModel) {
+		if (LatencyModel.equals(this.latency, newLatency)) return;
+
+		// Make a defensive copy
+		this.latency = LatencyModel.clone(newLatency);
+		this.miscOptionsChangeEmitter.emit(eventID);
+	}
+
 	getInFrontOfTarget(): boolean {
 		return this.inFrontOfTarget;
 	}
@@ -911,6 +983,19 @@ export class Player<SpecType extends Spec> {
 		this.distanceFromTargetChangeEmitter.emit(eventID);
 	}
 
+	getPosition(): Vector2 {
+		// Make a defensive copy
+		return Vector2.clone(this.position);
+	}
+
+	setPosition(eventID: EventID, newPosition: Vector2) {
+		if (Vector2.equals(newPosition, this.position)) return;
+
+		// Make a defensive copy
+		this.position = Vector2.clone(newPosition);
+		this.distanceFromTargetChangeEmitter.emit(eventID);
+	}
+
 	setDefaultHealingParams(hm: HealingModel) {
 		const boss = this.sim.encounter.primaryTarget;
 		const dualWield = boss.dualWield;
@@ -954,6 +1039,24 @@ export class Player<SpecType extends Spec> {
 		this.healingModelChangeEmitter.emit(eventID);
 	}
 
+	getDamageProfile(): DamageProfile {
+		// Make a defensive copy
+		return DamageProfile.clone(this.damageProfile);
+	}
+
+	setDamageProfile(eventID: EventID, newDamageProfile: DamageProfile) {
+		if (DamageProfile.equals(this.damageProfile, newDamageProfile)) return;
+
+		// Make a defensive copy
+		this.damageProfile = DamageProfile.clone(newDamageProfile);
+		this.damageProfileChangeEmitter.emit(eventID);
+	}
+
+	hasDamageProfile(): boolean {
+		const dp = this.damageProfile;
+		return [dp.physicalDtps, dp.arcaneDtps, dp.fireDtps, dp.frostDtps, dp.holyDtps, dp.natureDtps, dp.shadowDtps].some(dtps => dtps > 0);
+	}
+
 	getIsbUsingShadowflame(): boolean {
 		return this.isbUsingShadowflame;
 	}
@@ -1272,9 +1375,11 @@ export class Player<SpecType extends Spec> {
 	}
 
 	private toDatabase(): SimDatabase {
-		const dbGear = this.getGear().toDatabase();
-		const dbItemSwapGear = this.getItemSwapGear().toDatabase();
-		return Database.mergeSimDatabases(dbGear, dbItemSwapGear);
+		let db = this.getGear().toDatabase();
+		[this.getItemSwapGear(), ...this.getItemSwapSets()].forEach(itemSwapGear => {
+			db = Database.mergeSimDatabases(db, itemSwapGear.toDatabase());
+		});
+		return db;
 	}
 
 	toProto(forExport?: boolean, forSimming?: boolean, exportCategories?: Array<SimSettingCategories>): PlayerProto {
@@ -1293,6 +1398,7 @@ export class Player<SpecType extends Spec> {
 				bonusStats: this.getBonusStats().toProto(),
 				enableItemSwap: this.getEnableItemSwap(),
 				itemSwap: this.getItemSwapGear().toProto(),
+				itemSwapSets: this.getItemSwapSets().map(itemSwapGear => itemSwapGear.toProto()),
 			});
 		}
 		if (exportCategory(SimSettingCategories.Talents)) {
@@ -1304,6 +1410,7 @@ export class Player<SpecType extends Spec> {
 			PlayerProto.mergePartial(player, {
 				cooldowns: Cooldowns.create({ hpPercentForDefensives: this.getSimpleCooldowns().hpPercentForDefensives }),
 				rotation: aplRotation,
+				petRotations: this.getNonEmptyPetRotations(),
 			});
 		}
 		if (exportCategory(SimSettingCategories.Consumes)) {
@@ -1320,9 +1427,12 @@ export class Player<SpecType extends Spec> {
 				profession2: this.getProfession2(),
 				reactionTimeMs: this.getReactionTime(),
 				channelClipDelayMs: this.getChannelClipDelay(),
+				latency: this.getLatency(),
 				inFrontOfTarget: this.getInFrontOfTarget(),
 				distanceFromTarget: this.getDistanceFromTarget(),
+				position: this.getPosition(),
 				healingModel: this.getHealingModel(),
+				damageProfile: this.getDamageProfile(),
 				isbUsingShadowflame: this.getIsbUsingShadowflame(),
 				isbSbFrequency: this.getIsbSbFrequency(),
 				isbCrit: this.getIsbCrit(),
@@ -1352,6 +1462,7 @@ export class Player<SpecType extends Spec> {
 				this.setGear(eventID, proto.equipment ? this.sim.db.lookupEquipmentSpec(proto.equipment) : new Gear({}));
 				this.setEnableItemSwap(eventID, proto.enableItemSwap);
 				this.setItemSwapGear(eventID, proto.itemSwap ? this.sim.db.lookupItemSwap(proto.itemSwap) : new ItemSwapGear({}));
+				this.setItemSwapSets(eventID, proto.itemSwapSets.map(itemSwap => this.sim.db.lookupItemSwap(itemSwap)));
 				this.setBonusStats(eventID, Stats.fromProto(proto.bonusStats || UnitStats.create()));
 				//this.setBulkEquipmentSpec(eventID, BulkEquipmentSpec.create()); // Do not persist the bulk equipment settings.
 			}
@@ -1366,6 +1477,7 @@ export class Player<SpecType extends Spec> {
 					proto.rotation.type = APLRotationType.TypeAuto;
 				}
 				this.setAplRotation(eventID, proto.rotation || APLRotation.create());
+				this.setPetRotations(eventID, proto.petRotations);
 			}
 			if (loadCategory(SimSettingCategories.Consumes)) {
 				this.setConsumes(eventID, proto.consumes || Consumes.create());
@@ -1379,9 +1491,12 @@ export class Player<SpecType extends Spec> {
 				this.setProfession2(eventID, proto.profession2);
 				this.setReactionTime(eventID, proto.reactionTimeMs);
 				this.setChannelClipDelay(eventID, proto.channelClipDelayMs);
+				this.setLatency(eventID, proto.latency || LatencyModel.create());
 				this.setInFrontOfTarget(eventID, proto.inFrontOfTarget);
 				this.setDistanceFromTarget(eventID, proto.distanceFromTarget);
+				this.setPosition(eventID, proto.position || Vector2.create());
 				this.setHealingModel(eventID, proto.healingModel || HealingModel.create());
+				this.setDamageProfile(eventID, proto.damageProfile || DamageProfile.create());
 				this.setIsbSbFrequency(eventID, proto.isbSbFrequency);
 				this.setIsbCrit(eventID, proto.isbCrit);
 				this.setIsbWarlocks(eventID, proto.isbWarlocks);
@@ -1403,6 +1518,7 @@ export class Player<SpecType extends Spec> {
 		TypedEvent.freezeAllAndDo(() => {
 			this.setEnableItemSwap(eventID, false);
 			this.setItemSwapGear(eventID, new ItemSwapGear({}));
+			this.setItemSwapSets(eventID, []);
 			this.setReactionTime(eventID, 200);
 			this.setInFrontOfTarget(eventID, isTankSpec(this.spec));
 			this.setHealingModel(
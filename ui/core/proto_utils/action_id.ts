@@ -112,6 +112,10 @@ export class ActionId {
 				baseName = 'Damage Taken';
 				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/inv_sword_04.jpg';
 				break;
+			case OtherAction.OtherActionDamageProfile:
+				baseName = 'Incoming Damage';
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/spell_fire_selfdestruct.jpg';
+				break;
 			case OtherAction.OtherActionHealingModel:
 				baseName = 'Incoming HPS';
 				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/spell_holy_renew.jpg';
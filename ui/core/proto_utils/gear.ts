@@ -181,20 +181,33 @@ export class Gear extends BaseGear {
  * This is an immutable type.
  */
 export class ItemSwapGear extends BaseGear {
-	constructor(gear: Partial<InternalGear>) {
+	// Name used by APL item swap actions to select this set.
+	readonly name: string;
+
+	constructor(gear: Partial<InternalGear>, name = '') {
 		super(gear);
+		this.name = name;
 	}
 
 	getItemSlots(): ItemSlot[] {
 		return [ItemSlot.ItemSlotMainHand, ItemSlot.ItemSlotOffHand, ItemSlot.ItemSlotRanged];
 	}
 
+	equals(other: ItemSwapGear): boolean {
+		return this.name == other.name && super.equals(other);
+	}
+
 	withEquippedItem(newSlot: ItemSlot, newItem: EquippedItem | null, canDualWield2H: boolean): ItemSwapGear {
-		return new ItemSwapGear(this.withEquippedItemInternal(newSlot, newItem, canDualWield2H));
+		return new ItemSwapGear(this.withEquippedItemInternal(newSlot, newItem, canDualWield2H), this.name);
+	}
+
+	withName(newName: string): ItemSwapGear {
+		return new ItemSwapGear(this.asMap(), newName);
 	}
 
 	toProto(): ItemSwap {
 		return ItemSwap.create({
+			name: this.name,
 			mhItem: this.gear[ItemSlot.ItemSlotMainHand]?.asSpec(),
 			ohItem: this.gear[ItemSlot.ItemSlotOffHand]?.asSpec(),
 			rangedItem: this.gear[ItemSlot.ItemSlotRanged]?.asSpec(),
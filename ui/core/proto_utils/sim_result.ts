@@ -878,6 +878,14 @@ export class ActionMetrics {
 		return this.combinedMetrics.avgBlockDamage;
 	}
 
+	get absorbedDamage() {
+		return this.combinedMetrics.absorbedDamage;
+	}
+
+	get avgAbsorbedDamage() {
+		return this.combinedMetrics.avgAbsorbedDamage;
+	}
+
 	get dps() {
 		return this.combinedMetrics.dps;
 	}
@@ -971,6 +979,26 @@ export class ActionMetrics {
 		return this.combinedMetrics.shielding;
 	}
 
+	get shieldingWasted() {
+		return this.combinedMetrics.shieldingWasted;
+	}
+
+	get effectiveHealing() {
+		return this.combinedMetrics.effectiveHealing;
+	}
+
+	get overhealing() {
+		return this.combinedMetrics.overhealing;
+	}
+
+	get overhealingPercent() {
+		return this.combinedMetrics.overhealingPercent;
+	}
+
+	get ehps() {
+		return this.combinedMetrics.ehps;
+	}
+
 	get avgCast() {
 		if (this.isPassiveAction) return 0;
 		return this.combinedMetrics.avgCast;
@@ -1389,6 +1417,14 @@ export class TargetedActionMetrics {
 		return this.data.blockDamage / this.iterations;
 	}
 
+	get absorbedDamage() {
+		return this.data.absorbedDamage;
+	}
+
+	get avgAbsorbedDamage() {
+		return this.data.absorbedDamage / this.iterations;
+	}
+
 	get dps() {
 		return this.data.damage / this.iterations / this.duration;
 	}
@@ -1425,10 +1461,30 @@ export class TargetedActionMetrics {
 		return this.data.shielding;
 	}
 
+	get shieldingWasted() {
+		return this.data.shieldingWasted;
+	}
+
+	get effectiveHealing() {
+		return this.data.effectiveHealing;
+	}
+
+	get overhealing() {
+		return this.data.overhealing;
+	}
+
+	get overhealingPercent() {
+		return (this.data.overhealing / (this.data.healing || 1)) * 100;
+	}
+
 	get hps() {
 		return (this.data.healing + this.data.shielding) / this.iterations / this.duration;
 	}
 
+	get ehps() {
+		return (this.data.effectiveHealing + this.data.shielding) / this.iterations / this.duration;
+	}
+
 	get casts() {
 		return this.data.casts / this.iterations;
 	}
@@ -1659,10 +1715,14 @@ export class TargetedActionMetrics {
 				resistedCritTickDamage: sum(actions.map(a => a.data.resistedCritTickDamage)),
 				glanceDamage: sum(actions.map(a => a.data.glanceDamage)),
 				blockDamage: sum(actions.map(a => a.data.blockDamage)),
+				absorbedDamage: sum(actions.map(a => a.data.absorbedDamage)),
 				threat: sum(actions.map(a => a.data.threat)),
 				healing: sum(actions.map(a => a.data.healing)),
 				critHealing: sum(actions.map(a => a.data.critHealing)),
+				effectiveHealing: sum(actions.map(a => a.data.effectiveHealing)),
+				overhealing: sum(actions.map(a => a.data.overhealing)),
 				shielding: sum(actions.map(a => a.data.shielding)),
+				shieldingWasted: sum(actions.map(a => a.data.shieldingWasted)),
 				castTimeMs: sum(actions.map(a => a.data.castTimeMs)),
 			}),
 			{
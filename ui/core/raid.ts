@@ -1,6 +1,6 @@
 import { MAX_PARTY_SIZE,Party } from './party.js';
 import { Player } from './player.js';
-import { Raid as RaidProto } from './proto/api.js';
+import { Raid as RaidProto, ThreatCeiling } from './proto/api.js';
 import {
 	Class,
 	Debuffs,
@@ -21,6 +21,7 @@ export class Raid {
 	private debuffs: Debuffs = Debuffs.create();
 	private tanks: Array<UnitReference> = [];
 	private targetDummies = 0;
+	private threatCeiling: ThreatCeiling = ThreatCeiling.create();
 	private numActiveParties = 5;
 
 	// Emits when a raid member is added/removed/moved.
@@ -30,6 +31,7 @@ export class Raid {
 	readonly debuffsChangeEmitter = new TypedEvent<void>();
 	readonly tanksChangeEmitter = new TypedEvent<void>();
 	readonly targetDummiesChangeEmitter = new TypedEvent<void>();
+	readonly threatCeilingChangeEmitter = new TypedEvent<void>();
 	readonly numActivePartiesChangeEmitter = new TypedEvent<void>();
 
 	// Emits when anything in the raid changes.
@@ -62,6 +64,7 @@ export class Raid {
 			this.debuffsChangeEmitter,
 			this.tanksChangeEmitter,
 			this.targetDummiesChangeEmitter,
+			this.threatCeilingChangeEmitter,
 		], 'RaidChange');
 
 		this.changeEmitter.on(() => {
@@ -173,6 +176,20 @@ export class Raid {
 		this.targetDummiesChangeEmitter.emit(eventID);
 	}
 
+	getThreatCeiling(): ThreatCeiling {
+		// Make a defensive copy
+		return ThreatCeiling.clone(this.threatCeiling);
+	}
+
+	setThreatCeiling(eventID: EventID, newThreatCeiling: ThreatCeiling) {
+		if (ThreatCeiling.equals(this.threatCeiling, newThreatCeiling))
+			return;
+
+		// Make a defensive copy
+		this.threatCeiling = ThreatCeiling.clone(newThreatCeiling);
+		this.threatCeilingChangeEmitter.emit(eventID);
+	}
+
 	getNumActiveParties(): number {
 		return this.numActiveParties;
 	}
@@ -200,6 +217,7 @@ export class Raid {
 			debuffs: this.getDebuffs(),
 			tanks: this.getTanks(),
 			targetDummies: this.getTargetDummies(),
+			threatCeiling: this.getThreatCeiling(),
 			numActiveParties: this.getNumActiveParties(),
 		});
 	}
@@ -220,6 +238,7 @@ export class Raid {
 			this.setDebuffs(eventID, proto.debuffs || Debuffs.create());
 			this.setTanks(eventID, proto.tanks);
 			this.setTargetDummies(eventID, proto.targetDummies);
+			this.setThreatCeiling(eventID, proto.threatCeiling || ThreatCeiling.create());
 			this.setNumActiveParties(eventID, proto.numActiveParties || 5);
 
 			for (let i = 0; i < MAX_NUM_PARTIES; i++) {
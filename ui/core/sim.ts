@@ -187,6 +187,8 @@ export class Sim {
 				iterations: debug ? 1 : this.getIterations(),
 				randomSeed: BigInt(this.nextRngSeed()),
 				debugFirstIteration: true,
+				// Buffs from other simulated players replace their toggles, so report what each player's buffs are worth.
+				buffContributions: !debug && this.raid.getPlayers().filter(player => player != null).length > 1,
 			}),
 		});
 	}
@@ -325,6 +327,7 @@ export class Sim {
 		epStats: Array<Stat>,
 		epPseudoStats: Array<PseudoStat>,
 		epReferenceStat: Stat,
+		effectiveHealing: boolean,
 		onProgress: (_?: any) => void,
 	): Promise<StatWeightsResult> {
 		if (this.raid.isEmpty()) {
@@ -361,6 +364,7 @@ export class Sim {
 				statsToWeigh: epStats,
 				pseudoStatsToWeigh: epPseudoStats,
 				epReferenceStat: epReferenceStat,
+				effectiveHealing: effectiveHealing,
 			});
 			const result = await this.workerPool.statWeightsAsync(request, onProgress);
 			return result;
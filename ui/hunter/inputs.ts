@@ -4,15 +4,18 @@ import { ItemSlot, Spec } from '../core/proto/common.js';
 import {
 	Hunter_Options_Ammo as Ammo,
 	Hunter_Options_PetAttackSpeed as PetAttackSpeed,
+	Hunter_Options_PetHappiness as PetHappiness,
+	Hunter_Options_PetLoyalty as PetLoyalty,
 	Hunter_Options_PetType,
 	Hunter_Options_QuiverBonus as QuiverBonus,
 	Hunter_Rotation_RotationType as RotationType,
 	Hunter_Rotation_StingType as StingType,
+	HunterPetTraining,
 	HunterRune,
 } from '../core/proto/hunter.js';
 import { ActionId } from '../core/proto_utils/action_id.js';
 import { makePetTypeInputConfig } from '../core/talents/hunter_pet.js';
-import { TypedEvent } from '../core/typed_event.js';
+import { EventID, TypedEvent } from '../core/typed_event.js';
 
 // Configuration for spec-specific UI elements on the settings tab.
 // These don't need to be in a separate file but it keeps things cleaner.
@@ -59,8 +62,115 @@ export const PetUptime = InputHelpers.makeSpecOptionsNumberInput<Spec.SpecHunter
 	label: 'Pet Uptime (%)',
 	labelTooltip: 'Percent of the fight duration for which your pet will be alive.',
 	percent: true,
+	showWhen: player => player.getSpecOptions().petType != Hunter_Options_PetType.PetNone,
+	changeEmitter: (player: Player<Spec.SpecHunter>) => TypedEvent.onAny([player.specOptionsChangeEmitter]),
+});
+
+export const PetHappinessInput = InputHelpers.makeSpecOptionsEnumInput<Spec.SpecHunter>({
+	fieldName: 'petHappiness',
+	label: 'Pet Happiness',
+	labelTooltip: 'Happy pets deal 25% more damage, unhappy pets deal 25% less damage.',
+	values: [
+		{ name: 'Happy', value: PetHappiness.Happy },
+		{ name: 'Content', value: PetHappiness.Content },
+		{ name: 'Unhappy', value: PetHappiness.Unhappy },
+	],
+	showWhen: player => player.getSpecOptions().petType != Hunter_Options_PetType.PetNone,
+	changeEmitter: (player: Player<Spec.SpecHunter>) => TypedEvent.onAny([player.specOptionsChangeEmitter]),
+});
+
+export const PetLoyaltyInput = InputHelpers.makeSpecOptionsEnumInput<Spec.SpecHunter>({
+	fieldName: 'petLoyalty',
+	label: 'Pet Loyalty',
+	labelTooltip: 'Loyalty limits the training points your pet can spend on training, to its level times one less than its loyalty level.',
+	values: [
+		{ name: 'Best Friend', value: PetLoyalty.BestFriend },
+		{ name: 'Faithful', value: PetLoyalty.Faithful },
+		{ name: 'Dependable', value: PetLoyalty.Dependable },
+		{ name: 'Submissive', value: PetLoyalty.Submissive },
+		{ name: 'Unruly', value: PetLoyalty.Unruly },
+		{ name: 'Rebellious', value: PetLoyalty.Rebellious },
+	],
+	showWhen: player => player.getSpecOptions().petType != Hunter_Options_PetType.PetNone,
+	changeEmitter: (player: Player<Spec.SpecHunter>) => TypedEvent.onAny([player.specOptionsChangeEmitter]),
 });
 
+export const PetTrainingPoints = InputHelpers.makeSpecOptionsNumberInput<Spec.SpecHunter>({
+	fieldName: 'petTrainingPoints',
+	label: 'Pet Training Points Spent',
+	labelTooltip: "Training points spent on your pet's training, as shown in its training window.",
+	positive: true,
+	showWhen: player => player.getSpecOptions().petType != Hunter_Options_PetType.PetNone,
+	changeEmitter: (player: Player<Spec.SpecHunter>) => TypedEvent.onAny([player.specOptionsChangeEmitter]),
+});
+
+export const PetCleaveDps = InputHelpers.makeSpecOptionsNumberInput<Spec.SpecHunter>({
+	fieldName: 'petCleaveDps',
+	label: 'Pet Cleave Damage Taken (DPS)',
+	labelTooltip: 'Damage per second your pet takes from boss cleaves, before armor. Your pet dies when its health runs out and is revived with Revive Pet.',
+	showWhen: player => player.getSpecOptions().petType != Hunter_Options_PetType.PetNone,
+	changeEmitter: (player: Player<Spec.SpecHunter>) => TypedEvent.onAny([player.specOptionsChangeEmitter]),
+});
+
+export const PetReviveDelay = InputHelpers.makeSpecOptionsNumberInput<Spec.SpecHunter>({
+	fieldName: 'petReviveDelay',
+	label: 'Pet Revive Delay (s)',
+	labelTooltip: 'Seconds after your pet dies before you can cast Revive Pet. Rotations without a Revive Pet action cast it with your other cooldowns.',
+	float: true,
+	showWhen: player => player.getSpecOptions().petType != Hunter_Options_PetType.PetNone && player.getSpecOptions().petCleaveDps > 0,
+	changeEmitter: (player: Player<Spec.SpecHunter>) => TypedEvent.onAny([player.specOptionsChangeEmitter]),
+});
+
+const makePetTrainingInput = (fieldName: keyof HunterPetTraining, label: string, labelTooltip: string) => ({
+	...InputHelpers.makeSpecOptionsNumberInput<Spec.SpecHunter>({
+		fieldName: 'petTraining',
+		label,
+		labelTooltip,
+		positive: true,
+		getValue: (player: Player<Spec.SpecHunter>) => player.getSpecOptions().petTraining?.[fieldName] ?? 0,
+		setValue: (eventID: EventID, player: Player<Spec.SpecHunter>, newVal: number) => {
+			const newOptions = player.getSpecOptions();
+			newOptions.petTraining = { ...(newOptions.petTraining ?? HunterPetTraining.create()), [fieldName]: newVal };
+			player.setSpecOptions(eventID, newOptions);
+		},
+		showWhen: player => player.getSpecOptions().petType != Hunter_Options_PetType.PetNone,
+		changeEmitter: (player: Player<Spec.SpecHunter>) => TypedEvent.onAny([player.specOptionsChangeEmitter]),
+	}),
+	id: `petTraining-${fieldName}`,
+});
+
+export const PetGreatStamina = makePetTrainingInput(
+	'greatStamina',
+	'Pet Great Stamina',
+	'Stamina granted by the rank of Great Stamina your pet is trained in.',
+);
+export const PetNaturalArmor = makePetTrainingInput('naturalArmor', 'Pet Natural Armor', 'Armor granted by the rank of Natural Armor your pet is trained in.');
+export const PetArcaneResistance = makePetTrainingInput(
+	'arcaneResistance',
+	'Pet Arcane Resistance',
+	'Arcane resistance granted by the rank of Arcane Resistance your pet is trained in.',
+);
+export const PetFireResistance = makePetTrainingInput(
+	'fireResistance',
+	'Pet Fire Resistance',
+	'Fire resistance granted by the rank of Fire Resistance your pet is trained in.',
+);
+export const PetFrostResistance = makePetTrainingInput(
+	'frostResistance',
+	'Pet Frost Resistance',
+	'Frost resistance granted by the rank of Frost Resistance your pet is trained in.',
+);
+export const PetNatureResistance = makePetTrainingInput(
+	'natureResistance',
+	'Pet Nature Resistance',
+	'Nature resistance granted by the rank of Nature Resistance your pet is trained in.',
+);
+export const PetShadowResistance = makePetTrainingInput(
+	'shadowResistance',
+	'Pet Shadow Resistance',
+	'Shadow resistance granted by the rank of Shadow Resistance your pet is trained in.',
+);
+
 export const NewRaptorStrike = InputHelpers.makeSpecOptionsBooleanInput<Spec.SpecHunter>({
 	fieldName: 'newRaptorStrike',
 	label: 'New Raptor Strike',
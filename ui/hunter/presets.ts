@@ -184,7 +184,6 @@ export const DefaultOptions = HunterOptions.create({
 	ammo: Ammo.ThoriumHeadedArrow,
 	quiverBonus: Hunter_Options_QuiverBonus.Speed15,
 	petAttackSpeed: 2.0,
-	petTalents: {},
 	petType: PetType.PetNone,
 	petUptime: 1,
 	sniperTrainingUptime: 1.0,
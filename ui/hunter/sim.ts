@@ -123,6 +123,18 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecHunter, {
 			//HunterInputs.NewRaptorStrike,
 			HunterInputs.PetAttackSpeedInput,
 			HunterInputs.PetUptime,
+			HunterInputs.PetHappinessInput,
+			HunterInputs.PetCleaveDps,
+			HunterInputs.PetReviveDelay,
+			HunterInputs.PetLoyaltyInput,
+			HunterInputs.PetTrainingPoints,
+			HunterInputs.PetGreatStamina,
+			HunterInputs.PetNaturalArmor,
+			HunterInputs.PetArcaneResistance,
+			HunterInputs.PetFireResistance,
+			HunterInputs.PetFrostResistance,
+			HunterInputs.PetNatureResistance,
+			HunterInputs.PetShadowResistance,
 			HunterInputs.SniperTrainingUptime,
 			OtherInputs.DistanceFromTarget,
 			OtherInputs.TankAssignment,
@@ -0,0 +1,12 @@
+package ui
+
+import (
+	"embed"
+)
+
+// Preset files for every spec, so binaries can set core.PresetFiles without
+// reading the ui directory at runtime. presets.gen.json is written by
+// vite.build-presets.ts, see the makefile.
+//
+//go:embed */presets.gen.json */apls */gear_sets
+var PresetFiles embed.FS
@@ -118,6 +118,11 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecTankWarlock, {
 		inputs: [
 			WarlockInputs.PetPoolManaInput(),
 			OtherInputs.TankAssignment,
+			OtherInputs.IncomingPhysicalDtps,
+			OtherInputs.IncomingFireDtps,
+			OtherInputs.IncomingShadowDtps,
+			OtherInputs.IncomingDamageCadence,
+			OtherInputs.IncomingDamageVariation,
 			OtherInputs.IncomingHps,
 			OtherInputs.HealingCadence,
 			OtherInputs.HealingCadenceVariation,
@@ -156,6 +156,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecWarlock, {
 	],
 	excludeBuffDebuffInputs: [BuffDebuffInputs.BleedDebuff, BuffDebuffInputs.SpellWintersChillDebuff, ...ConsumablesInputs.FROST_POWER_CONFIG],
 	petConsumeInputs: [ConsumablesInputs.PetAttackPowerConsumable, ConsumablesInputs.PetAgilityConsumable, ConsumablesInputs.PetStrengthConsumable],
+	petRotations: ['Imp', 'Voidwalker', 'Succubus', 'Felhunter', 'Felguard'],
 	// Inputs to include in the 'Other' section on the settings tab.
 	otherInputs: {
 		inputs: [WarlockInputs.PetPoolManaInput(), OtherInputs.DistanceFromTarget, OtherInputs.ChannelClipDelay],
@@ -87,7 +87,18 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecWarrior, {
 	excludeBuffDebuffInputs: [],
 	// Inputs to include in the 'Other' section on the settings tab.
 	otherInputs: {
-		inputs: [WarriorInputs.StartingRage<Spec.SpecWarrior>(), WarriorInputs.StanceSnapshot<Spec.SpecWarrior>(), OtherInputs.InFrontOfTarget],
+		inputs: [
+			WarriorInputs.StartingRage<Spec.SpecWarrior>(),
+			WarriorInputs.StanceSnapshot<Spec.SpecWarrior>(),
+			OtherInputs.InFrontOfTarget,
+			OtherInputs.IncomingPhysicalDtps,
+			OtherInputs.IncomingFireDtps,
+			OtherInputs.IncomingShadowDtps,
+			OtherInputs.IncomingDamageCadence,
+			OtherInputs.IncomingDamageVariation,
+			OtherInputs.IncomingHps,
+			OtherInputs.HealingCadence,
+		],
 	},
 	encounterPicker: {
 		// Whether to include 'Execute Duration (%)' in the 'Encounter' section of the settings tab.
@@ -0,0 +1,49 @@
+import fs from 'node:fs/promises';
+
+import glob from 'glob';
+import path from 'path';
+import { createServer } from 'vite';
+
+import { BASE_PATH, getBaseConfig } from './vite.config.mjs';
+
+// Writes the talents and consumes presets exported by each spec's presets.ts to
+// presets.gen.json next to it, so the Go preset registry can embed them.
+const buildPresets = async () => {
+	const server = await createServer({
+		...getBaseConfig({ command: 'build', mode: 'production' }),
+		configFile: false,
+		appType: 'custom',
+		server: { middlewareMode: true },
+		esbuild: {
+			jsxInject: "import { element, fragment } from 'tsx-vanilla';",
+		},
+	});
+
+	try {
+		const { Consumes } = await server.ssrLoadModule(path.resolve(BASE_PATH, 'core/proto/common.ts'));
+		const { SavedTalents } = await server.ssrLoadModule(path.resolve(BASE_PATH, 'core/proto/ui.ts'));
+
+		// Only spec directories, which have APLs. The raid sim's presets.ts pulls in every spec's UI.
+		for (const aplsPath of glob.sync(path.resolve(BASE_PATH, '*/apls').replace(/\\/g, '/'))) {
+			const presetsPath = path.join(path.dirname(aplsPath), 'presets.ts');
+			const presets = await server.ssrLoadModule(presetsPath);
+
+			const talents: Record<string, string> = {};
+			const consumes: Record<string, unknown> = {};
+			for (const [name, value] of Object.entries(presets)) {
+				if (SavedTalents.is(value?.data)) {
+					talents[name] = value.data.talentsString;
+				} else if (Consumes.is(value)) {
+					consumes[name] = Consumes.toJson(value);
+				}
+			}
+
+			const outPath = path.join(path.dirname(presetsPath), 'presets.gen.json');
+			await fs.writeFile(outPath, JSON.stringify({ talents, consumes }, null, '\t') + '\n');
+		}
+	} finally {
+		await server.close();
+	}
+};
+
+buildPresets();